@@ -0,0 +1,25 @@
+package batch
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/batch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "batch"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/batch",
+			Summary:  "Run several requests at once",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     batch.BatchRequest{},
+			Response: response.SuccessResponse[batch.BatchResponse]{},
+		},
+	)
+}
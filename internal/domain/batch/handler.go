@@ -0,0 +1,112 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/batch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+type (
+	HTTPHandlerParams struct {
+		Router    fiber.Router
+		Service   *Service
+		JWTSecret string
+	}
+
+	httpHandler struct {
+		service *Service
+	}
+
+	contextKey string
+)
+
+// operationKey marks the request context of operations, so batches sent as
+// an operation are refused.
+const operationKey = contextKey("batch_operation")
+
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		service: params.Service,
+	}
+
+	batchGroup := params.Router.Group("/batch", middleware.AuthMiddleware(params.JWTSecret))
+	batchGroup.Post("/", httpHandler.Batch)
+}
+
+func (h *httpHandler) Batch(c *fiber.Ctx) error {
+	if c.Locals(operationKey) != nil {
+		return ErrNestedBatch
+	}
+
+	var reqParams batch.BatchRequest
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
+	}
+
+	batchResponse, err := h.service.Execute(c.Context(), reqParams, runThroughApp(c))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(batchResponse))
+}
+
+// runThroughApp runs operations through the routes of the app serving the
+// batch, with its credentials.
+func runThroughApp(c *fiber.Ctx) RunFunc {
+	dispatch := c.App().Handler()
+	authorization := c.Get(fiber.HeaderAuthorization)
+
+	return func(ctx context.Context, operation batch.Operation) batch.OperationResult {
+		var request fasthttp.Request
+		request.Header.SetMethod(operation.Method)
+		request.SetRequestURI(operation.Path)
+		request.Header.Set(fiber.HeaderAuthorization, authorization)
+		request.Header.SetContentType(fiber.MIMEApplicationJSON)
+		for key, value := range operation.Headers {
+			request.Header.Set(key, value)
+		}
+
+		if len(operation.Body) > 0 && !bytes.Equal(operation.Body, []byte("null")) {
+			request.SetBody(operation.Body)
+		}
+
+		operationCtx := &fasthttp.RequestCtx{}
+		operationCtx.Init(&request, c.Context().RemoteAddr(), nil)
+		operationCtx.SetUserValue(operationKey, true)
+		repo.BindTx(ctx, operationCtx.SetUserValue)
+
+		dispatch(operationCtx)
+
+		return newOperationResult(&operationCtx.Response)
+	}
+}
+
+func newOperationResult(resp *fasthttp.Response) batch.OperationResult {
+	result := batch.OperationResult{
+		Status:  resp.StatusCode(),
+		Headers: map[string]string{},
+	}
+
+	resp.Header.VisitAll(func(key []byte, value []byte) {
+		result.Headers[string(key)] = string(value)
+	})
+
+	switch body := resp.Body(); {
+	case len(body) == 0:
+	case json.Valid(body):
+		result.Body = bytes.Clone(body)
+	default:
+		result.Body, _ = json.Marshal(string(body))
+	}
+
+	return result
+}
@@ -0,0 +1,79 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/batch"
+)
+
+type (
+	// Service runs the operations of a batch, in one transaction for atomic
+	// batches.
+	Service struct {
+		txRepo repo.Repository
+	}
+
+	ServiceParams struct {
+		TxRepo repo.Repository // Opens the transaction of atomic batches
+	}
+
+	// RunFunc runs an operation with ctx, which carries the transaction of
+	// an atomic batch, and returns its response.
+	RunFunc func(ctx context.Context, operation batch.Operation) batch.OperationResult
+)
+
+var (
+	ErrNestedBatch = domainerr.Validation("Batches can not be nested.", nil)
+
+	// errRolledBack rolls back the transaction of an atomic batch once an
+	// operation failed.
+	errRolledBack = errors.New("batch rolled back")
+)
+
+func NewService(params ServiceParams) *Service {
+	return &Service{
+		txRepo: params.TxRepo,
+	}
+}
+
+// Execute runs the operations of a batch in order. Every operation runs even
+// if an earlier one failed, except in atomic batches, where the first failure
+// rolls back the operations before it and the ones after it are skipped with
+// 424 Failed Dependency.
+func (s *Service) Execute(ctx context.Context, params batch.BatchRequest, run RunFunc) (*batch.BatchResponse, error) {
+	results := make([]batch.OperationResult, 0, len(params.Operations))
+
+	if !params.Atomic {
+		for _, operation := range params.Operations {
+			results = append(results, run(ctx, operation))
+		}
+
+		return &batch.BatchResponse{Results: results}, nil
+	}
+
+	err := s.txRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		for _, operation := range params.Operations {
+			result := run(txCtx, operation)
+			results = append(results, result)
+
+			if result.Status >= http.StatusBadRequest {
+				return errRolledBack
+			}
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errRolledBack) {
+		return nil, err
+	}
+
+	for len(results) < len(params.Operations) {
+		results = append(results, batch.OperationResult{Status: http.StatusFailedDependency})
+	}
+
+	return &batch.BatchResponse{RolledBack: err != nil, Results: results}, nil
+}
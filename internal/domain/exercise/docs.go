@@ -0,0 +1,87 @@
+package exercise
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const docsTag = "exercises"
+
+// deleteQuery documents the query parameters DeleteExercise reads directly.
+type deleteQuery struct {
+	ReplaceWith *uuid.UUID `query:"replace_with"` // Exercise that replaces the deleted one where it is in use
+}
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/exercises",
+			Summary:  "Create an exercise",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     exercise.CreateExerciseRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[exercise.ExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/exercises",
+			Summary:  "List exercises",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    exercise.ListExercisesQueryParams{},
+			Response: response.PaginationResponse[[]exercise.ExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPut,
+			Path:     "/exercises/:id",
+			Summary:  "Update an exercise",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     exercise.UpdateExerciseRequest{},
+			Response: response.SuccessResponse[exercise.ExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:          fiber.MethodPatch,
+			Path:            "/exercises/:id",
+			Summary:         "Patch an exercise",
+			Tag:             docsTag,
+			Auth:            true,
+			Body:            exercise.UpdateExerciseRequest{},
+			BodyContentType: mergepatch.MIMEMergePatchJSON,
+			Response:        response.SuccessResponse[exercise.ExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/exercises/:id",
+			Summary: "Delete an exercise",
+			Tag:     docsTag,
+			Auth:    true,
+			Query:   deleteQuery{},
+			Status:  fiber.StatusNoContent,
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/exercises/:id/restore",
+			Summary:  "Restore a deleted exercise (admin only)",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[exercise.ExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/exercises/:id/merge",
+			Summary:  "Merge exercises into an exercise (admin only)",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     exercise.MergeExercisesRequest{},
+			Response: response.SuccessResponse[exercise.MergeExercisesResponse]{},
+		},
+	)
+}
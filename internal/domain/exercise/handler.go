@@ -2,17 +2,19 @@ package exercise
 
 import (
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 )
 
 type (
 	HTTPHandlerParams struct {
-		App       *fiber.App
-		Service   *Service
-		JWTSecret string
+		Router      fiber.Router
+		Service     *Service
+		JWTSecret   string
+		AdminEmails []string
 	}
 
 	httpHandler struct {
@@ -25,92 +27,143 @@ func NewHTTPHandler(params HTTPHandlerParams) {
 		service: params.Service,
 	}
 
-	exerciseGroup := params.App.Group("/exercises", middleware.AuthMiddleware(params.JWTSecret))
+	exerciseGroup := params.Router.Group("/exercises", middleware.AuthMiddleware(params.JWTSecret))
 	exerciseGroup.Post("/", httpHandler.CreateExercise)
 	exerciseGroup.Get("/", httpHandler.ListExercises)
 	exerciseGroup.Put("/:id", httpHandler.UpdateExercise)
+	exerciseGroup.Patch("/:id", httpHandler.PatchExercise)
 	exerciseGroup.Delete("/:id", httpHandler.DeleteExercise)
+	exerciseGroup.Post("/:id/restore", middleware.AdminMiddleware(params.AdminEmails), httpHandler.RestoreExercise)
+	exerciseGroup.Post("/:id/merge", middleware.AdminMiddleware(params.AdminEmails), httpHandler.MergeExercises)
 }
 
 func (h *httpHandler) CreateExercise(c *fiber.Ctx) error {
 	var reqParams exercise.CreateExerciseRequest
 
-	if err := c.BodyParser(&reqParams); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
 	}
 
-	exercise, err := h.service.CreateExercise(c.Context(), reqParams)
+	exerciseModel, err := h.service.CreateExercise(c.Context(), reqParams)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(exercise))
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(exercise.NewExerciseResponse(exerciseModel)))
 }
 
 func (h *httpHandler) ListExercises(c *fiber.Ctx) error {
 	var reqParams exercise.ListExercisesQueryParams
 
-	if err := c.QueryParser(&reqParams); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseQuery(c, &reqParams); err != nil {
+		return err
 	}
 
 	reqParams.ValidateAndSetDefaults()
 
-	exercises, total, err := h.service.ListExercises(c.Context(), reqParams)
+	exercises, page, err := h.service.ListExercises(c.Context(), reqParams)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(exercises, response.Pagination{
-		Page:       reqParams.Page,
-		PerPage:    reqParams.PerPage,
-		TotalItems: total,
-	}))
+	responses, err := reqParams.Project(exercise.NewExerciseResponses(exercises), exercise.Fields)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(responses, reqParams.Pagination(page)))
 }
 
 func (h *httpHandler) UpdateExercise(c *fiber.Ctx) error {
-	exerciseID, err := uuid.Parse(c.Params("id"))
+	exerciseID, err := validation.ParseUUIDParam(c, "id")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidURLParam(&response.ErrorDetails{
-				response.NewErrorDetail("id", "Invalid UUID format"),
-			}))
+		return err
 	}
 
 	var bodyRequest exercise.UpdateExerciseRequest
 
-	if err := c.BodyParser(&bodyRequest); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &bodyRequest); err != nil {
+		return err
+	}
+
+	exerciseModel, err := h.service.UpdateExercise(c.Context(), exerciseID, bodyRequest, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.Set(c, exerciseModel.Version)
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(exercise.NewExerciseResponse(exerciseModel)))
+}
+
+func (h *httpHandler) PatchExercise(c *fiber.Ctx) error {
+	exerciseID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
 	}
 
-	exercise, err := h.service.UpdateExercise(c.Context(), exerciseID, bodyRequest)
+	patch, err := validation.ParseMergePatch(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(exercise))
+	exerciseModel, err := h.service.PatchExercise(c.Context(), exerciseID, patch, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.Set(c, exerciseModel.Version)
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(exercise.NewExerciseResponse(exerciseModel)))
 }
 
 func (h *httpHandler) DeleteExercise(c *fiber.Ctx) error {
-	exerciseID, err := uuid.ParseBytes([]byte(c.Params("id")))
+	exerciseID, err := validation.ParseUUIDParam(c, "id")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidURLParam(&response.ErrorDetails{
-				response.NewErrorDetail("id", "Invalid UUID format"),
-			}))
+		return err
 	}
 
-	err = h.service.DeleteExercise(c.Context(), exerciseID)
+	replacementID, err := validation.ParseOptionalUUIDQuery(c, "replace_with")
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
+	}
+
+	if err := h.service.DeleteExercise(c.Context(), exerciseID, replacementID); err != nil {
+		return err
 	}
 
 	return c.Status(fiber.StatusNoContent).JSON(nil)
 }
+
+func (h *httpHandler) RestoreExercise(c *fiber.Ctx) error {
+	exerciseID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	exerciseModel, err := h.service.RestoreExercise(c.Context(), exerciseID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(exercise.NewExerciseResponse(exerciseModel)))
+}
+
+func (h *httpHandler) MergeExercises(c *fiber.Ctx) error {
+	targetID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var bodyRequest exercise.MergeExercisesRequest
+	if err := validation.ParseBody(c, &bodyRequest); err != nil {
+		return err
+	}
+
+	result, err := h.service.MergeExercises(c.Context(), targetID, bodyRequest)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(result))
+}
@@ -2,9 +2,18 @@ package exercise
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +27,15 @@ type (
 	}
 )
 
+var (
+	ErrReplacementNotFound = domainerr.InvalidQueryParams(&response.ErrorDetails{exercise.ErrorReplaceWithNotFound})
+	ErrReplacementIsSame   = domainerr.InvalidQueryParams(&response.ErrorDetails{exercise.ErrorReplaceWithIsSame})
+	ErrMergeIntoItself     = domainerr.InvalidRequestBody(&response.ErrorDetails{exercise.ErrorSourceIDsContainsTarget})
+	ErrMergeSourceNotFound = domainerr.InvalidRequestBody(&response.ErrorDetails{exercise.ErrorSourceIDsNotFound})
+	ErrNameAlreadyExists   = domainerr.Conflict("Name already in use").WithDetails(&response.ErrorDetails{exercise.ErrorNameAlreadyExists})
+	ErrExerciseChanged     = domainerr.PreconditionFailed("Exercise was changed since it was read")
+)
+
 func NewService(params ServiceParams) *Service {
 	return &Service{
 		exerciseRepo: params.ExerciseRepo,
@@ -26,7 +44,7 @@ func NewService(params ServiceParams) *Service {
 
 func (s *Service) CreateExercise(ctx context.Context, params exercise.CreateExerciseRequest) (*exercise.Model, error) {
 	exerciseModel := exercise.Model{
-		Name:        params.Name,
+		Name:        strings.TrimSpace(params.Name),
 		Description: params.Description,
 	}
 
@@ -40,7 +58,7 @@ func (s *Service) CreateExercise(ctx context.Context, params exercise.CreateExer
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, translateNameConflict(err)
 	}
 
 	return s.exerciseRepo.GetByID(ctx, exerciseModel.ID)
@@ -63,30 +81,88 @@ func (s *Service) createExerciseAssociations(
 	return s.exerciseRepo.CreateExerciseMuscleGroupAssociations(ctx, associations)
 }
 
-func (s *Service) ListExercises(ctx context.Context, params exercise.ListExercisesQueryParams) ([]*exercise.Model, int, error) {
+func (s *Service) ListExercises(ctx context.Context, params exercise.ListExercisesQueryParams) ([]*exercise.Model, base.PageInfo, error) {
 	return s.exerciseRepo.GetPaginated(ctx, params)
 }
 
-func (s *Service) UpdateExercise(ctx context.Context, id uuid.UUID, exerciseEdited exercise.UpdateExerciseRequest) (*exercise.Model, error) {
+// UpdateExercise replaces the details and muscle groups of an exercise. With
+// ifMatch, the update only applies to the versions it lists.
+func (s *Service) UpdateExercise(ctx context.Context, id uuid.UUID, exerciseEdited exercise.UpdateExerciseRequest, ifMatch *etag.Precondition) (*exercise.Model, error) {
+	exerciseModel, err := s.exerciseRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ifMatch != nil && !ifMatch.Matches(exerciseModel.Version) {
+		return nil, ErrExerciseChanged
+	}
+
+	return s.saveExercise(ctx, exerciseModel, exerciseEdited, true)
+}
+
+// PatchExercise applies a JSON merge patch to an exercise, saving only the
+// members it changes. With ifMatch, the patch only applies to the versions it
+// lists.
+func (s *Service) PatchExercise(ctx context.Context, id uuid.UUID, patch mergepatch.Patch, ifMatch *etag.Precondition) (*exercise.Model, error) {
 	exerciseModel, err := s.exerciseRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	exerciseModel.Name = exerciseEdited.Name
+	if ifMatch != nil && !ifMatch.Matches(exerciseModel.Version) {
+		return nil, ErrExerciseChanged
+	}
+
+	exerciseEdited := exercise.NewUpdateExerciseRequest(exerciseModel)
+	if err := validation.ApplyMergePatch(patch, &exerciseEdited); err != nil {
+		return nil, err
+	}
+
+	columns := patch.Members("name", "description")
+	replaceMuscleGroups := patch.Has("muscle_group_ids")
+	if replaceMuscleGroups {
+		// Replacing the muscle groups is a change of the exercise too
+		columns = append(columns, "updated_at")
+	}
+
+	if len(columns) == 0 {
+		return exerciseModel, nil
+	}
+
+	return s.saveExercise(ctx, exerciseModel, exerciseEdited, replaceMuscleGroups, columns...)
+}
+
+// saveExercise saves the edited columns of an exercise, or all of them when
+// none are given, and its muscle groups when replaceMuscleGroups is set.
+func (s *Service) saveExercise(
+	ctx context.Context,
+	exerciseModel *exercise.Model,
+	exerciseEdited exercise.UpdateExerciseRequest,
+	replaceMuscleGroups bool,
+	columns ...string,
+) (*exercise.Model, error) {
+	exerciseModel.Name = strings.TrimSpace(exerciseEdited.Name)
 	exerciseModel.Description = exerciseEdited.Description
 
-	err = s.exerciseRepo.ExecTx(ctx, func(txCtx context.Context) error {
-		err := s.exerciseRepo.Update(txCtx, id, exerciseModel)
+	err := s.exerciseRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		err := s.exerciseRepo.Update(txCtx, exerciseModel.ID, exerciseModel, columns...)
 		if err != nil {
 			return err
 		}
 
-		return s.updateExerciseAssociations(txCtx, id, exerciseEdited)
+		if !replaceMuscleGroups {
+			return nil
+		}
+
+		return s.updateExerciseAssociations(txCtx, exerciseModel.ID, exerciseEdited)
 	})
 
+	if errors.Is(err, repo.ErrVersionConflict) {
+		return nil, ErrExerciseChanged.Wrap(err)
+	}
+
 	if err != nil {
-		return nil, err
+		return nil, translateNameConflict(err)
 	}
 
 	return s.exerciseRepo.GetByID(ctx, exerciseModel.ID)
@@ -105,6 +181,156 @@ func (s *Service) updateExerciseAssociations(ctx context.Context, exerciseID uui
 	return s.exerciseRepo.UpdateExerciseMuscleGroupAssociations(ctx, exerciseID, associations)
 }
 
-func (s *Service) DeleteExercise(ctx context.Context, id uuid.UUID) error {
-	return s.exerciseRepo.Delete(ctx, id)
+func (s *Service) DeleteExercise(ctx context.Context, id uuid.UUID, replacementID *uuid.UUID) error {
+	if replacementID != nil {
+		return s.replaceAndDeleteExercise(ctx, id, *replacementID)
+	}
+
+	return s.exerciseRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		usage, err := s.exerciseRepo.GetUsage(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if usage.IsInUse() {
+			return domainerr.Conflict("Exercise is in use, provide replace_with to move its references").WithMeta(usage)
+		}
+
+		return s.exerciseRepo.Delete(txCtx, id)
+	})
+}
+
+func (s *Service) replaceAndDeleteExercise(ctx context.Context, id uuid.UUID, replacementID uuid.UUID) error {
+	if id == replacementID {
+		return ErrReplacementIsSame
+	}
+
+	if _, err := s.exerciseRepo.GetByID(ctx, replacementID); err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return ErrReplacementNotFound
+		}
+
+		return err
+	}
+
+	return s.exerciseRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.exerciseRepo.ReplaceReferences(txCtx, id, replacementID); err != nil {
+			return err
+		}
+
+		return s.exerciseRepo.Delete(txCtx, id)
+	})
+}
+
+func (s *Service) RestoreExercise(ctx context.Context, id uuid.UUID) (*exercise.Model, error) {
+	if err := s.exerciseRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.NotFound("Exercise not found in trash").Wrap(err)
+		}
+
+		return nil, translateNameConflict(err)
+	}
+
+	return s.exerciseRepo.GetByID(ctx, id)
+}
+
+// MergeExercises folds the source exercises into the target: workout exercises,
+// progress logs and muscle group associations are moved to the target and the
+// source names are kept as aliases before the sources are deleted.
+func (s *Service) MergeExercises(ctx context.Context, targetID uuid.UUID, params exercise.MergeExercisesRequest) (*exercise.MergeExercisesResponse, error) {
+	sourceIDs := uniqueIDs(params.SourceIDs)
+	if slices.Contains(sourceIDs, targetID) {
+		return nil, ErrMergeIntoItself
+	}
+
+	if _, err := s.exerciseRepo.GetByID(ctx, targetID); err != nil {
+		return nil, err
+	}
+
+	result := &exercise.MergeExercisesResponse{
+		MergedIDs: sourceIDs,
+	}
+
+	err := s.exerciseRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		for _, sourceID := range sourceIDs {
+			if err := s.mergeExercise(txCtx, sourceID, targetID, &result.Moved); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.exerciseRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Target = exercise.NewExerciseResponse(target)
+
+	return result, nil
+}
+
+func (s *Service) mergeExercise(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID, moved *exercise.MovedReferences) error {
+	source, err := s.exerciseRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return ErrMergeSourceNotFound
+		}
+
+		return err
+	}
+
+	references, err := s.exerciseRepo.ReplaceReferences(ctx, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	muscleGroups, err := s.exerciseRepo.MoveMuscleGroupAssociations(ctx, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	names := []string{source.Name}
+	for _, alias := range source.Aliases {
+		names = append(names, alias.Name)
+	}
+
+	aliases, err := s.exerciseRepo.CreateAliases(ctx, targetID, names)
+	if err != nil {
+		return err
+	}
+
+	moved.WorkoutExercises += references.WorkoutExercises
+	moved.ProgressLogs += references.ProgressLogs
+	moved.MuscleGroups += muscleGroups
+	moved.Aliases += aliases
+
+	return s.exerciseRepo.Delete(ctx, sourceID)
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// translateNameConflict reports unique name violations as a conflict on the
+// name field.
+func translateNameConflict(err error) error {
+	if errors.Is(err, repo.ErrUniqueViolation) {
+		return ErrNameAlreadyExists.Wrap(err)
+	}
+
+	return err
 }
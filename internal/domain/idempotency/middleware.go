@@ -0,0 +1,90 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/idempotency"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	MiddlewareParams struct {
+		Service   *Service
+		JWTSecret string
+	}
+)
+
+// NewMiddleware makes authenticated POST requests sent with an
+// Idempotency-Key header safe to retry: the first response is stored and
+// replayed to later requests with the same key and body. Requests without a
+// key or a valid token are passed through untouched, leaving authentication
+// to the routes.
+func NewMiddleware(params MiddlewareParams) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(idempotency.Header)
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+
+		claims, err := jwt.ValidateToken(strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "), params.JWTSecret)
+		if err != nil {
+			return c.Next()
+		}
+
+		if len(key) > idempotency.KeyMaxLength {
+			return domainerr.Validation("Invalid request headers.", &response.ErrorDetails{
+				idempotency.ErrorKeyIsTooLong,
+			})
+		}
+
+		model, err := params.Service.Begin(c.Context(), claims.Email, key, fingerprint(c))
+		if err != nil {
+			return err
+		}
+
+		if model.IsCompleted() {
+			if model.ContentType != nil {
+				c.Set(fiber.HeaderContentType, *model.ContentType)
+			}
+
+			c.Set(idempotency.ReplayedHeader, "true")
+
+			return c.Status(*model.StatusCode).Send(model.Body)
+		}
+
+		// Errors are rendered here rather than by the app so the response
+		// stored is the one the client gets.
+		if err := c.Next(); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				if releaseErr := params.Service.Release(c.Context(), model); releaseErr != nil {
+					fmt.Printf("could not release idempotency key: %v\n", releaseErr)
+				}
+
+				return err
+			}
+		}
+
+		resp := c.Response()
+		if err := params.Service.Complete(c.Context(), model, resp.StatusCode(), string(resp.Header.ContentType()), resp.Body()); err != nil {
+			fmt.Printf("could not store idempotent response: %v\n", err)
+		}
+
+		return nil
+	}
+}
+
+// fingerprint identifies a request by its method, URL and body, so a key
+// reused for another request is told apart from a retry.
+func fingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+	hash.Write(c.Body())
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,116 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/idempotency"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+)
+
+type (
+	// Service stores the first response to requests sent with an idempotency
+	// key so retries of the same request get it back instead of running again.
+	Service struct {
+		idempotencyRepo repo.IdempotencyRepository
+		userRepo        repo.UserRepository
+		ttl             time.Duration
+		lockTimeout     time.Duration
+	}
+
+	ServiceParams struct {
+		IdempotencyRepo repo.IdempotencyRepository
+		UserRepo        repo.UserRepository
+		TTL             time.Duration // How long keys are kept after their first use
+		LockTimeout     time.Duration // How long a key waits for its first request to answer
+	}
+)
+
+var (
+	ErrKeyInProgress = domainerr.Conflict("A request with this idempotency key is still in progress")
+	ErrKeyReused     = domainerr.Malformed("Idempotency key was already used with a different request.", &response.ErrorDetails{
+		idempotency.ErrorKeyWasReused,
+	})
+)
+
+func NewService(params ServiceParams) *Service {
+	return &Service{
+		idempotencyRepo: params.IdempotencyRepo,
+		userRepo:        params.UserRepo,
+		ttl:             params.TTL,
+		lockTimeout:     params.LockTimeout,
+	}
+}
+
+// Begin reserves the key of a request for the user. The returned key holds
+// the stored response when the request was already answered; otherwise the
+// request should run and its response be passed to Complete. A reservation
+// that is not completed within the lock timeout can be taken by a retry.
+func (s *Service) Begin(ctx context.Context, userEmail string, key string, fingerprint string) (*idempotency.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lockedUntil := now.Add(s.lockTimeout)
+
+	model := &idempotency.Model{
+		UserID:      userModel.ID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(s.ttl),
+		LockedUntil: &lockedUntil,
+	}
+
+	reserved, err := s.idempotencyRepo.Reserve(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	if reserved {
+		return model, nil
+	}
+
+	stored, err := s.idempotencyRepo.Get(ctx, userModel.ID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, ErrKeyReused
+	}
+
+	if !stored.IsCompleted() {
+		return nil, ErrKeyInProgress
+	}
+
+	return stored, nil
+}
+
+// Complete stores the response to the request that reserved the key. Server
+// errors are not stored; the key is released so the request can be retried.
+func (s *Service) Complete(ctx context.Context, model *idempotency.Model, statusCode int, contentType string, body []byte) error {
+	if statusCode >= 500 {
+		return s.Release(ctx, model)
+	}
+
+	model.StatusCode = &statusCode
+	model.ContentType = &contentType
+	model.Body = body
+
+	return s.idempotencyRepo.Complete(ctx, model)
+}
+
+// Release frees a reserved key without storing a response.
+func (s *Service) Release(ctx context.Context, model *idempotency.Model) error {
+	return s.idempotencyRepo.Delete(ctx, model)
+}
+
+// PurgeExpired permanently removes the keys whose responses are no longer
+// replayed, returning how many were removed.
+func (s *Service) PurgeExpired(ctx context.Context) (int, error) {
+	return s.idempotencyRepo.DeleteExpired(ctx, time.Now())
+}
@@ -0,0 +1,87 @@
+package musclegroup
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const docsTag = "muscle-groups"
+
+// deleteQuery documents the query parameters DeleteMuscleGroup reads directly.
+type deleteQuery struct {
+	ReplaceWith *uuid.UUID `query:"replace_with"` // Muscle group that replaces the deleted one where it is in use
+}
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/muscle-groups",
+			Summary:  "Create a muscle group",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     musclegroup.CreateMuscleGroupRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[musclegroup.MuscleGroupResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/muscle-groups",
+			Summary:  "List muscle groups",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    musclegroup.ListMuscleGroupsQueryParams{},
+			Response: response.PaginationResponse[[]musclegroup.MuscleGroupResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPut,
+			Path:     "/muscle-groups/:id",
+			Summary:  "Update a muscle group",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     musclegroup.UpdateMuscleGroupRequest{},
+			Response: response.SuccessResponse[musclegroup.MuscleGroupResponse]{},
+		},
+		openapi.Route{
+			Method:          fiber.MethodPatch,
+			Path:            "/muscle-groups/:id",
+			Summary:         "Patch a muscle group",
+			Tag:             docsTag,
+			Auth:            true,
+			Body:            musclegroup.UpdateMuscleGroupRequest{},
+			BodyContentType: mergepatch.MIMEMergePatchJSON,
+			Response:        response.SuccessResponse[musclegroup.MuscleGroupResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/muscle-groups/:id",
+			Summary: "Delete a muscle group",
+			Tag:     docsTag,
+			Auth:    true,
+			Query:   deleteQuery{},
+			Status:  fiber.StatusNoContent,
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/muscle-groups/:id/restore",
+			Summary:  "Restore a deleted muscle group (admin only)",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[musclegroup.MuscleGroupResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/muscle-groups/:id/merge",
+			Summary:  "Merge muscle groups into a muscle group (admin only)",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     musclegroup.MergeMuscleGroupsRequest{},
+			Response: response.SuccessResponse[musclegroup.MergeMuscleGroupsResponse]{},
+		},
+	)
+}
@@ -2,17 +2,19 @@ package musclegroup
 
 import (
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 )
 
 type (
 	HTTPHandlerParams struct {
-		App       *fiber.App
-		Service   *Service
-		JWTSecret string
+		Router      fiber.Router
+		Service     *Service
+		JWTSecret   string
+		AdminEmails []string
 	}
 
 	httpHandler struct {
@@ -25,91 +27,137 @@ func NewHTTPHandler(params HTTPHandlerParams) {
 		service: params.Service,
 	}
 
-	muscleGroupGroup := params.App.Group("/muscle-groups", middleware.AuthMiddleware(params.JWTSecret))
+	muscleGroupGroup := params.Router.Group("/muscle-groups", middleware.AuthMiddleware(params.JWTSecret))
 	muscleGroupGroup.Post("/", httpHandler.CreateMuscleGroup)
 	muscleGroupGroup.Get("/", httpHandler.ListMuscleGroups)
 	muscleGroupGroup.Put("/:id", httpHandler.UpdateMuscleGroup)
+	muscleGroupGroup.Patch("/:id", httpHandler.PatchMuscleGroup)
 	muscleGroupGroup.Delete("/:id", httpHandler.DeleteMuscleGroup)
+	muscleGroupGroup.Post("/:id/restore", middleware.AdminMiddleware(params.AdminEmails), httpHandler.RestoreMuscleGroup)
+	muscleGroupGroup.Post("/:id/merge", middleware.AdminMiddleware(params.AdminEmails), httpHandler.MergeMuscleGroups)
 }
 
 func (h *httpHandler) CreateMuscleGroup(c *fiber.Ctx) error {
 	var reqParams musclegroup.CreateMuscleGroupRequest
 
-	if err := c.BodyParser(&reqParams); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
 	}
 
 	muscleGroup, err := h.service.CreateMuscleGroup(c.Context(), reqParams)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(muscleGroup))
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(musclegroup.NewMuscleGroupResponse(muscleGroup)))
 }
 
 func (h *httpHandler) ListMuscleGroups(c *fiber.Ctx) error {
 	var reqParams musclegroup.ListMuscleGroupsQueryParams
 
-	if err := c.QueryParser(&reqParams); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseQuery(c, &reqParams); err != nil {
+		return err
 	}
 
 	reqParams.ValidateAndSetDefaults()
 
-	muscleGroups, total, err := h.service.ListMuscleGroups(c.Context(), reqParams)
+	muscleGroups, page, err := h.service.ListMuscleGroups(c.Context(), reqParams)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(muscleGroups, response.Pagination{
-		Page:       reqParams.Page,
-		PerPage:    reqParams.PerPage,
-		TotalItems: total,
-	}))
+	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(musclegroup.NewMuscleGroupResponses(muscleGroups), reqParams.Pagination(page)))
 }
 
 func (h *httpHandler) UpdateMuscleGroup(c *fiber.Ctx) error {
-	muscleGroupID, err := uuid.Parse(c.Params("id"))
+	muscleGroupID, err := validation.ParseUUIDParam(c, "id")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(&response.ErrorDetails{
-				response.NewErrorDetail("id", "Invalid UUID format"),
-			}))
+		return err
 	}
 
 	var bodyRequest musclegroup.UpdateMuscleGroupRequest
-	if err := c.BodyParser(&bodyRequest); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &bodyRequest); err != nil {
+		return err
 	}
 
-	muscleGroup, err := h.service.UpdateMuscleGroup(c.Context(), muscleGroupID, bodyRequest)
+	muscleGroup, err := h.service.UpdateMuscleGroup(c.Context(), muscleGroupID, bodyRequest, etag.IfMatch(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(muscleGroup))
+	etag.Set(c, muscleGroup.Version)
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(musclegroup.NewMuscleGroupResponse(muscleGroup)))
+}
+
+func (h *httpHandler) PatchMuscleGroup(c *fiber.Ctx) error {
+	muscleGroupID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	patch, err := validation.ParseMergePatch(c)
+	if err != nil {
+		return err
+	}
+
+	muscleGroup, err := h.service.PatchMuscleGroup(c.Context(), muscleGroupID, patch, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.Set(c, muscleGroup.Version)
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(musclegroup.NewMuscleGroupResponse(muscleGroup)))
 }
 
 func (h *httpHandler) DeleteMuscleGroup(c *fiber.Ctx) error {
-	muscleGroupID, err := uuid.Parse(c.Params("id"))
+	muscleGroupID, err := validation.ParseUUIDParam(c, "id")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(&response.ErrorDetails{
-				response.NewErrorDetail("id", "Invalid UUID format"),
-			}))
+		return err
 	}
 
-	err = h.service.DeleteMuscleGroup(c.Context(), muscleGroupID)
+	replacementID, err := validation.ParseOptionalUUIDQuery(c, "replace_with")
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
+	}
+
+	if err := h.service.DeleteMuscleGroup(c.Context(), muscleGroupID, replacementID); err != nil {
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+func (h *httpHandler) RestoreMuscleGroup(c *fiber.Ctx) error {
+	muscleGroupID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	muscleGroup, err := h.service.RestoreMuscleGroup(c.Context(), muscleGroupID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(musclegroup.NewMuscleGroupResponse(muscleGroup)))
+}
+
+func (h *httpHandler) MergeMuscleGroups(c *fiber.Ctx) error {
+	targetID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var bodyRequest musclegroup.MergeMuscleGroupsRequest
+	if err := validation.ParseBody(c, &bodyRequest); err != nil {
+		return err
+	}
+
+	result, err := h.service.MergeMuscleGroups(c.Context(), targetID, bodyRequest)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(result))
+}
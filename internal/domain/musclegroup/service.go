@@ -2,9 +2,18 @@ package musclegroup
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/google/uuid"
 )
 
@@ -18,6 +27,15 @@ type (
 	}
 )
 
+var (
+	ErrReplacementNotFound = domainerr.InvalidQueryParams(&response.ErrorDetails{musclegroup.ErrorReplaceWithNotFound})
+	ErrReplacementIsSame   = domainerr.InvalidQueryParams(&response.ErrorDetails{musclegroup.ErrorReplaceWithIsSame})
+	ErrMergeIntoItself     = domainerr.InvalidRequestBody(&response.ErrorDetails{musclegroup.ErrorSourceIDsContainsTarget})
+	ErrMergeSourceNotFound = domainerr.InvalidRequestBody(&response.ErrorDetails{musclegroup.ErrorSourceIDsNotFound})
+	ErrNameAlreadyExists   = domainerr.Conflict("Name already in use").WithDetails(&response.ErrorDetails{musclegroup.ErrorNameAlreadyExists})
+	ErrMuscleGroupChanged  = domainerr.PreconditionFailed("Muscle group was changed since it was read")
+)
+
 func NewService(params ServiceParams) *Service {
 	return &Service{
 		muscleGroupRepo: params.MuscleGroupRepo,
@@ -26,32 +44,220 @@ func NewService(params ServiceParams) *Service {
 
 func (s *Service) CreateMuscleGroup(ctx context.Context, bodyRequest musclegroup.CreateMuscleGroupRequest) (*musclegroup.Model, error) {
 	model := musclegroup.Model{
-		Name: bodyRequest.Name,
+		Name: strings.TrimSpace(bodyRequest.Name),
 	}
 
 	if err := s.muscleGroupRepo.Create(ctx, &model); err != nil {
-		return nil, err
+		return nil, translateNameConflict(err)
 	}
 
 	return &model, nil
 }
 
-func (s *Service) ListMuscleGroups(ctx context.Context, params musclegroup.ListMuscleGroupsQueryParams) ([]*musclegroup.Model, int, error) {
+func (s *Service) ListMuscleGroups(ctx context.Context, params musclegroup.ListMuscleGroupsQueryParams) ([]*musclegroup.Model, base.PageInfo, error) {
 	return s.muscleGroupRepo.GetPaginated(ctx, params)
 }
 
-func (s *Service) UpdateMuscleGroup(ctx context.Context, id uuid.UUID, bodyRequest musclegroup.UpdateMuscleGroupRequest) (*musclegroup.Model, error) {
-	model := musclegroup.Model{
-		Name: bodyRequest.Name,
+// UpdateMuscleGroup renames a muscle group. With ifMatch, the update only
+// applies to the versions it lists.
+func (s *Service) UpdateMuscleGroup(ctx context.Context, id uuid.UUID, bodyRequest musclegroup.UpdateMuscleGroupRequest, ifMatch *etag.Precondition) (*musclegroup.Model, error) {
+	model, err := s.muscleGroupRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.muscleGroupRepo.Update(ctx, id, &model); err != nil {
+	if ifMatch != nil && !ifMatch.Matches(model.Version) {
+		return nil, ErrMuscleGroupChanged
+	}
+
+	return s.saveMuscleGroup(ctx, model, bodyRequest)
+}
+
+// PatchMuscleGroup applies a JSON merge patch to a muscle group, saving only
+// the members it changes. With ifMatch, the patch only applies to the
+// versions it lists.
+func (s *Service) PatchMuscleGroup(ctx context.Context, id uuid.UUID, patch mergepatch.Patch, ifMatch *etag.Precondition) (*musclegroup.Model, error) {
+	model, err := s.muscleGroupRepo.GetByID(ctx, id)
+	if err != nil {
 		return nil, err
 	}
 
-	return &model, nil
+	if ifMatch != nil && !ifMatch.Matches(model.Version) {
+		return nil, ErrMuscleGroupChanged
+	}
+
+	bodyRequest := musclegroup.NewUpdateMuscleGroupRequest(model)
+	if err := validation.ApplyMergePatch(patch, &bodyRequest); err != nil {
+		return nil, err
+	}
+
+	columns := patch.Members("name")
+	if len(columns) == 0 {
+		return model, nil
+	}
+
+	return s.saveMuscleGroup(ctx, model, bodyRequest, columns...)
+}
+
+// saveMuscleGroup saves the edited columns of a muscle group, or all of them
+// when none are given.
+func (s *Service) saveMuscleGroup(ctx context.Context, model *musclegroup.Model, bodyRequest musclegroup.UpdateMuscleGroupRequest, columns ...string) (*musclegroup.Model, error) {
+	model.Name = strings.TrimSpace(bodyRequest.Name)
+
+	if err := s.muscleGroupRepo.Update(ctx, model.ID, model, columns...); err != nil {
+		if errors.Is(err, repo.ErrVersionConflict) {
+			return nil, ErrMuscleGroupChanged.Wrap(err)
+		}
+
+		return nil, translateNameConflict(err)
+	}
+
+	return model, nil
+}
+
+func (s *Service) DeleteMuscleGroup(ctx context.Context, id uuid.UUID, replacementID *uuid.UUID) error {
+	if replacementID != nil {
+		return s.replaceAndDeleteMuscleGroup(ctx, id, *replacementID)
+	}
+
+	return s.muscleGroupRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		usage, err := s.muscleGroupRepo.GetUsage(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if usage.IsInUse() {
+			return domainerr.Conflict("Muscle group is in use, provide replace_with to move its references").WithMeta(usage)
+		}
+
+		return s.muscleGroupRepo.Delete(txCtx, id)
+	})
+}
+
+func (s *Service) replaceAndDeleteMuscleGroup(ctx context.Context, id uuid.UUID, replacementID uuid.UUID) error {
+	if id == replacementID {
+		return ErrReplacementIsSame
+	}
+
+	if _, err := s.muscleGroupRepo.GetByID(ctx, replacementID); err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return ErrReplacementNotFound
+		}
+
+		return err
+	}
+
+	return s.muscleGroupRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.muscleGroupRepo.ReplaceReferences(txCtx, id, replacementID); err != nil {
+			return err
+		}
+
+		return s.muscleGroupRepo.Delete(txCtx, id)
+	})
 }
 
-func (s *Service) DeleteMuscleGroup(ctx context.Context, id uuid.UUID) error {
-	return s.muscleGroupRepo.Delete(ctx, id)
+func (s *Service) RestoreMuscleGroup(ctx context.Context, id uuid.UUID) (*musclegroup.Model, error) {
+	if err := s.muscleGroupRepo.Restore(ctx, id); err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.NotFound("Muscle group not found in trash").Wrap(err)
+		}
+
+		return nil, translateNameConflict(err)
+	}
+
+	return s.muscleGroupRepo.GetByID(ctx, id)
+}
+
+// MergeMuscleGroups folds the source muscle groups into the target: exercise
+// associations are moved to the target and the source names are kept as
+// aliases before the sources are deleted.
+func (s *Service) MergeMuscleGroups(ctx context.Context, targetID uuid.UUID, params musclegroup.MergeMuscleGroupsRequest) (*musclegroup.MergeMuscleGroupsResponse, error) {
+	sourceIDs := uniqueIDs(params.SourceIDs)
+	if slices.Contains(sourceIDs, targetID) {
+		return nil, ErrMergeIntoItself
+	}
+
+	if _, err := s.muscleGroupRepo.GetByID(ctx, targetID); err != nil {
+		return nil, err
+	}
+
+	result := &musclegroup.MergeMuscleGroupsResponse{
+		MergedIDs: sourceIDs,
+	}
+
+	err := s.muscleGroupRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		for _, sourceID := range sourceIDs {
+			if err := s.mergeMuscleGroup(txCtx, sourceID, targetID, &result.Moved); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.muscleGroupRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Target = musclegroup.NewMuscleGroupResponse(target)
+
+	return result, nil
+}
+
+func (s *Service) mergeMuscleGroup(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID, moved *musclegroup.MovedReferences) error {
+	source, err := s.muscleGroupRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return ErrMergeSourceNotFound
+		}
+
+		return err
+	}
+
+	references, err := s.muscleGroupRepo.ReplaceReferences(ctx, sourceID, targetID)
+	if err != nil {
+		return err
+	}
+
+	names := []string{source.Name}
+	for _, alias := range source.Aliases {
+		names = append(names, alias.Name)
+	}
+
+	aliases, err := s.muscleGroupRepo.CreateAliases(ctx, targetID, names)
+	if err != nil {
+		return err
+	}
+
+	moved.Exercises += references.Exercises
+	moved.Aliases += aliases
+
+	return s.muscleGroupRepo.Delete(ctx, sourceID)
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
+
+// translateNameConflict reports unique name violations as a conflict on the
+// name field.
+func translateNameConflict(err error) error {
+	if errors.Is(err, repo.ErrUniqueViolation) {
+		return ErrNameAlreadyExists.Wrap(err)
+	}
+
+	return err
 }
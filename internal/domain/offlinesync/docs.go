@@ -0,0 +1,34 @@
+package offlinesync
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "sync"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/sync",
+			Summary:  "Read the changes made since a change token",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    offlinesync.PullQueryParams{},
+			Response: response.SuccessResponse[offlinesync.PullResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/sync",
+			Summary:  "Push changes made offline",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     offlinesync.PushRequest{},
+			Response: response.SuccessResponse[offlinesync.PushResponse]{},
+		},
+	)
+}
@@ -0,0 +1,72 @@
+package offlinesync
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	HTTPHandlerParams struct {
+		Router    fiber.Router
+		Service   *Service
+		JWTSecret string
+	}
+
+	httpHandler struct {
+		service *Service
+	}
+)
+
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		service: params.Service,
+	}
+
+	syncGroup := params.Router.Group("/sync", middleware.AuthMiddleware(params.JWTSecret))
+	syncGroup.Get("/", httpHandler.Pull)
+	syncGroup.Post("/", httpHandler.Push)
+}
+
+func (h *httpHandler) Pull(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqQuery offlinesync.PullQueryParams
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	set, token, err := h.service.Pull(c.Context(), claims.Email, reqQuery)
+	if err != nil {
+		return err
+	}
+
+	changeToken, err := offlinesync.EncodeChangeToken(token)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(offlinesync.PullResponse{
+		Changes:     offlinesync.NewChanges(set),
+		ChangeToken: changeToken,
+	}))
+}
+
+func (h *httpHandler) Push(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqParams offlinesync.PushRequest
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
+	}
+
+	result, err := h.service.Push(c.Context(), claims.Email, reqParams)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(result))
+}
@@ -0,0 +1,348 @@
+package offlinesync
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/google/uuid"
+)
+
+type (
+	// Service keeps offline clients in sync: it reads the changes made since
+	// a change token and applies the changes clients push, unless they were
+	// made to a copy the server changed since.
+	Service struct {
+		syncRepo     repo.SyncRepository
+		workoutRepo  repo.WorkoutRepository
+		sessionRepo  repo.SessionRepository
+		exerciseRepo repo.ExerciseRepository
+		userRepo     repo.UserRepository
+		retention    time.Duration
+	}
+
+	ServiceParams struct {
+		SyncRepo     repo.SyncRepository
+		WorkoutRepo  repo.WorkoutRepository
+		SessionRepo  repo.SessionRepository
+		ExerciseRepo repo.ExerciseRepository
+		UserRepo     repo.UserRepository
+		// Retention is how long deleted records stay in the trash, and with
+		// them the tombstones the change feed reads.
+		Retention time.Duration
+	}
+)
+
+var (
+	ErrWorkoutNotFound   = domainerr.NotFound("Workout not found")
+	ErrNameAlreadyExists = domainerr.Conflict("Exercise name already in use").WithDetails(&response.ErrorDetails{exercise.ErrorNameAlreadyExists})
+	ErrTokenExpired      = domainerr.Gone("Change token expired").WithDetails(&response.ErrorDetails{offlinesync.ErrorChangeTokenIsExpired})
+)
+
+func NewService(params ServiceParams) *Service {
+	return &Service{
+		syncRepo:     params.SyncRepo,
+		workoutRepo:  params.WorkoutRepo,
+		sessionRepo:  params.SessionRepo,
+		exerciseRepo: params.ExerciseRepo,
+		userRepo:     params.UserRepo,
+		retention:    params.Retention,
+	}
+}
+
+// Pull returns the changes made since the token in params, with the token
+// of the next pull. Tokens older than the trash retention are refused, as the
+// tombstones of the deletions made since may be purged: the client has to
+// pull the full feed again.
+func (s *Service) Pull(ctx context.Context, userEmail string, params offlinesync.PullQueryParams) (*offlinesync.ChangeSet, offlinesync.ChangeToken, error) {
+	now := time.Now()
+
+	var since *time.Time
+	if sinceToken := params.SinceToken(); sinceToken != nil {
+		if sinceToken.Expired(now, s.retention) {
+			return nil, offlinesync.ChangeToken{}, ErrTokenExpired
+		}
+
+		since = &sinceToken.ReadAt
+	}
+
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, offlinesync.ChangeToken{}, err
+	}
+
+	token := offlinesync.NewChangeToken(now)
+
+	set, err := s.syncRepo.GetChanges(ctx, userModel.ID, since)
+	if err != nil {
+		return nil, offlinesync.ChangeToken{}, err
+	}
+
+	return set, token, nil
+}
+
+// Push applies the changes of a client in one transaction. Changes based on
+// a copy older than the stored record are reported as conflicts, along with
+// the stored record, and left out. So are changes to records the user does
+// not own, such as the exercises of the global catalog or records of other
+// users whose ids a client happened to pick, though without their copies.
+func (s *Service) Push(ctx context.Context, userEmail string, params offlinesync.PushRequest) (*offlinesync.PushResponse, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &offlinesync.PushResponse{
+		Applied:   []offlinesync.Ref{},
+		Conflicts: []offlinesync.Ref{},
+	}
+
+	record := func(ref offlinesync.Ref, applied bool) {
+		if applied {
+			result.Applied = append(result.Applied, ref)
+		} else {
+			result.Conflicts = append(result.Conflicts, ref)
+		}
+	}
+
+	err = s.syncRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		for _, change := range params.Workouts {
+			applied, err := s.pushWorkout(txCtx, userModel.ID, change)
+			if err != nil {
+				return err
+			}
+
+			record(offlinesync.Ref{Type: offlinesync.TypeWorkout, ID: change.ID}, applied)
+		}
+
+		for _, change := range params.Sessions {
+			applied, err := s.pushSession(txCtx, userModel.ID, change)
+			if err != nil {
+				return err
+			}
+
+			record(offlinesync.Ref{Type: offlinesync.TypeSession, ID: change.ID}, applied)
+		}
+
+		for _, change := range params.Exercises {
+			applied, err := s.pushExercise(txCtx, userModel.ID, change)
+			if err != nil {
+				return err
+			}
+
+			record(offlinesync.Ref{Type: offlinesync.TypeExercise, ID: change.ID}, applied)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conflictIDs := make([]uuid.UUID, len(result.Conflicts))
+	for index, ref := range result.Conflicts {
+		conflictIDs[index] = ref.ID
+	}
+
+	current, err := s.syncRepo.GetRecords(ctx, userModel.ID, conflictIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Current = offlinesync.NewChanges(current)
+
+	return result, nil
+}
+
+// pushWorkout applies a workout change and reports whether it was applied.
+func (s *Service) pushWorkout(ctx context.Context, userID uuid.UUID, change offlinesync.WorkoutChange) (bool, error) {
+	workoutModel, err := s.syncRepo.GetWorkout(ctx, change.ID)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		if change.Deleted {
+			return true, nil
+		}
+
+		return true, s.syncRepo.CreateWorkout(ctx, &workout.Model{
+			Model:  base.Model{ID: change.ID},
+			UserID: userID,
+			Name:   change.Name,
+		})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if workoutModel.UserID != userID {
+		return false, nil
+	}
+
+	if workoutModel.DeletedAt != nil {
+		return change.Deleted, nil
+	}
+
+	if !offlinesync.SameVersion(change.UpdatedAt, workoutModel.UpdatedAt) {
+		return false, nil
+	}
+
+	if change.Deleted {
+		return true, s.workoutRepo.Delete(ctx, userID, change.ID)
+	}
+
+	workoutModel.Name = change.Name
+
+	return updated(s.workoutRepo.UpdateWorkout(ctx, change.ID, workoutModel, "name"))
+}
+
+// pushSession applies a session change and reports whether it was applied.
+func (s *Service) pushSession(ctx context.Context, userID uuid.UUID, change offlinesync.SessionChange) (bool, error) {
+	sessionModel, err := s.syncRepo.GetSession(ctx, change.ID)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		if change.Deleted {
+			return true, nil
+		}
+
+		if err := s.checkWorkout(ctx, userID, change.WorkoutID); err != nil {
+			return false, err
+		}
+
+		return true, s.syncRepo.CreateSession(ctx, &workouthistory.Model{
+			ID:          change.ID,
+			UserID:      userID,
+			WorkoutID:   change.WorkoutID,
+			StartedAt:   change.StartedAt,
+			CompletedAt: change.CompletedAt,
+			Notes:       change.Notes,
+		})
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if sessionModel.UserID != userID {
+		return false, nil
+	}
+
+	if sessionModel.DeletedAt != nil {
+		return change.Deleted, nil
+	}
+
+	if !offlinesync.SameVersion(change.UpdatedAt, sessionModel.UpdatedAt) {
+		return false, nil
+	}
+
+	if change.Deleted {
+		return true, s.sessionRepo.Delete(ctx, userID, change.ID)
+	}
+
+	if change.WorkoutID != sessionModel.WorkoutID {
+		if err := s.checkWorkout(ctx, userID, change.WorkoutID); err != nil {
+			return false, err
+		}
+	}
+
+	sessionModel.WorkoutID = change.WorkoutID
+	sessionModel.StartedAt = change.StartedAt
+	sessionModel.CompletedAt = change.CompletedAt
+	sessionModel.Notes = change.Notes
+
+	return true, s.sessionRepo.Update(ctx, sessionModel)
+}
+
+// pushExercise applies a change to an exercise of the user and reports
+// whether it was applied. The global catalog is only changed through the
+// exercise routes. Exercises still used by workouts or progress
+// logs are kept, as when deleting them through the exercise routes.
+func (s *Service) pushExercise(ctx context.Context, userID uuid.UUID, change offlinesync.ExerciseChange) (bool, error) {
+	exerciseModel, err := s.syncRepo.GetExercise(ctx, change.ID)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		if change.Deleted {
+			return true, nil
+		}
+
+		err := s.syncRepo.CreateExercise(ctx, &exercise.Model{
+			Model:       base.Model{ID: change.ID},
+			UserID:      &userID,
+			Name:        strings.TrimSpace(change.Name),
+			Description: change.Description,
+		})
+
+		return true, translateNameConflict(err)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !exerciseModel.IsOwnedBy(userID) {
+		return false, nil
+	}
+
+	if exerciseModel.DeletedAt != nil {
+		return change.Deleted, nil
+	}
+
+	if !offlinesync.SameVersion(change.UpdatedAt, exerciseModel.UpdatedAt) {
+		return false, nil
+	}
+
+	if change.Deleted {
+		usage, err := s.exerciseRepo.GetUsage(ctx, change.ID)
+		if err != nil {
+			return false, err
+		}
+
+		if usage.IsInUse() {
+			return false, nil
+		}
+
+		return true, s.exerciseRepo.Delete(ctx, change.ID)
+	}
+
+	exerciseModel.Name = strings.TrimSpace(change.Name)
+	exerciseModel.Description = change.Description
+
+	return updated(translateNameConflict(s.exerciseRepo.Update(ctx, change.ID, exerciseModel, "name", "description")))
+}
+
+// checkWorkout checks that sessions are logged for a workout of the user.
+func (s *Service) checkWorkout(ctx context.Context, userID uuid.UUID, workoutID uuid.UUID) error {
+	workoutModel, err := s.workoutRepo.GetByID(ctx, workoutID)
+	if errors.Is(err, domainerr.ErrNotFound) {
+		return ErrWorkoutNotFound.Wrap(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if workoutModel.UserID != userID {
+		return ErrWorkoutNotFound
+	}
+
+	return nil
+}
+
+// updated reports whether an update was applied, treating one that lost a
+// race with another update as a conflict.
+func updated(err error) (bool, error) {
+	if errors.Is(err, repo.ErrVersionConflict) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func translateNameConflict(err error) error {
+	if errors.Is(err, repo.ErrUniqueViolation) {
+		return ErrNameAlreadyExists.Wrap(err)
+	}
+
+	return err
+}
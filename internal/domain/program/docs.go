@@ -0,0 +1,70 @@
+package program
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "programs"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/programs",
+			Summary:  "Create a program",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     program.CreateProgramRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[program.ProgramResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/programs",
+			Summary:  "List programs",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    program.ListProgramsQueryParams{},
+			Response: response.PaginationResponse[[]program.ProgramResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/programs/today",
+			Summary:  "Get the workout prescribed for a date",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    program.TodayQueryParams{},
+			Response: response.SuccessResponse[program.TodayResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/programs/:id",
+			Summary:  "Get a program",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[program.ProgramResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/programs/:id",
+			Summary: "Delete a program",
+			Tag:     docsTag,
+			Auth:    true,
+			Status:  fiber.StatusNoContent,
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/programs/:id/enroll",
+			Summary:  "Enroll in a program",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     program.EnrollRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[program.EnrollmentResponse]{},
+		},
+	)
+}
@@ -0,0 +1,139 @@
+package program
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	HTTPHandlerParams struct {
+		Router    fiber.Router
+		Service   *Service
+		JWTSecret string
+	}
+
+	httpHandler struct {
+		service *Service
+	}
+)
+
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		service: params.Service,
+	}
+
+	programGroup := params.Router.Group("/programs", middleware.AuthMiddleware(params.JWTSecret))
+	programGroup.Post("/", httpHandler.CreateProgram)
+	programGroup.Get("/", httpHandler.ListPrograms)
+	programGroup.Get("/today", httpHandler.GetTodayWorkout)
+	programGroup.Get("/:id", httpHandler.GetProgram)
+	programGroup.Delete("/:id", httpHandler.DeleteProgram)
+	programGroup.Post("/:id/enroll", httpHandler.Enroll)
+}
+
+func (h *httpHandler) CreateProgram(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var req program.CreateProgramRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	programModel, err := h.service.CreateProgram(c.Context(), claims.Email, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(program.NewProgramResponse(programModel)))
+}
+
+func (h *httpHandler) ListPrograms(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqQuery program.ListProgramsQueryParams
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	reqQuery.ValidateAndSetDefaults()
+
+	programs, total, err := h.service.ListPrograms(c.Context(), claims.Email, reqQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(program.NewProgramResponses(programs), reqQuery.Pagination(base.PageInfo{Total: &total})))
+}
+
+func (h *httpHandler) GetTodayWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqQuery program.TodayQueryParams
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	today, err := h.service.GetTodayWorkout(c.Context(), claims.Email, reqQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(today))
+}
+
+func (h *httpHandler) GetProgram(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	programID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	programModel, err := h.service.GetProgram(c.Context(), claims.Email, programID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(program.NewProgramResponse(programModel)))
+}
+
+func (h *httpHandler) DeleteProgram(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	programID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteProgram(c.Context(), claims.Email, programID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+func (h *httpHandler) Enroll(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	programID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req program.EnrollRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	enrollment, err := h.service.Enroll(c.Context(), claims.Email, programID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(program.NewEnrollmentResponse(enrollment)))
+}
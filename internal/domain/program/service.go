@@ -0,0 +1,261 @@
+package program
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	Service struct {
+		programRepo repo.ProgramRepository
+		workoutRepo repo.WorkoutRepository
+		userRepo    repo.UserRepository
+		location    *time.Location
+	}
+
+	ServiceParams struct {
+		ProgramRepo repo.ProgramRepository
+		WorkoutRepo repo.WorkoutRepository
+		UserRepo    repo.UserRepository
+		// Location decides which calendar day "today" is when resolving the
+		// workout of the day.
+		Location *time.Location
+	}
+)
+
+var (
+	ErrWorkoutsNotFound = domainerr.InvalidRequestBody(&response.ErrorDetails{program.ErrorWorkoutsNotFound})
+)
+
+func NewService(params ServiceParams) *Service {
+	location := params.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &Service{
+		programRepo: params.ProgramRepo,
+		workoutRepo: params.WorkoutRepo,
+		userRepo:    params.UserRepo,
+		location:    location,
+	}
+}
+
+// CreateProgram creates a program with its weeks and days. Days can only be
+// mapped to workouts of the user.
+func (s *Service) CreateProgram(ctx context.Context, userEmail string, params program.CreateProgramRequest) (*program.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutIDs := params.WorkoutIDs()
+	owned, err := s.workoutRepo.CountByUser(ctx, userModel.ID, workoutIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if owned != len(workoutIDs) {
+		return nil, ErrWorkoutsNotFound
+	}
+
+	trainingMaxPercentage := program.DefaultTrainingMaxPercentage
+	if params.TrainingMaxPercentage != nil {
+		trainingMaxPercentage = *params.TrainingMaxPercentage
+	}
+
+	programModel := program.Model{
+		UserID:                userModel.ID,
+		Name:                  params.Name,
+		Description:           params.Description,
+		TrainingMaxPercentage: trainingMaxPercentage,
+	}
+
+	err = s.programRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.programRepo.Create(txCtx, &programModel); err != nil {
+			return err
+		}
+
+		return s.createWeeks(txCtx, programModel.ID, params.Weeks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.programRepo.GetByID(ctx, userModel.ID, programModel.ID)
+}
+
+func (s *Service) createWeeks(ctx context.Context, programID uuid.UUID, weeks []program.WeekRequest) error {
+	weekModels := make([]*program.WeekModel, len(weeks))
+	for index, week := range weeks {
+		weekModels[index] = &program.WeekModel{
+			ProgramID:           programID,
+			WeekNumber:          week.Week,
+			IntensityPercentage: week.IntensityPercentage,
+			Sets:                week.Sets,
+			Repetitions:         week.Repetitions,
+			Notes:               week.Notes,
+		}
+	}
+
+	if err := s.programRepo.CreateWeeks(ctx, weekModels); err != nil {
+		return err
+	}
+
+	var dayModels []*program.DayModel
+	for index, week := range weeks {
+		for _, day := range week.Days {
+			dayModels = append(dayModels, &program.DayModel{
+				ProgramWeekID: weekModels[index].ID,
+				DayNumber:     day.Day,
+				WorkoutID:     day.WorkoutID,
+			})
+		}
+	}
+
+	return s.programRepo.CreateDays(ctx, dayModels)
+}
+
+func (s *Service) ListPrograms(ctx context.Context, userEmail string, params program.ListProgramsQueryParams) ([]*program.Model, int, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.programRepo.GetPaginated(ctx, userModel.ID, params)
+}
+
+func (s *Service) GetProgram(ctx context.Context, userEmail string, id uuid.UUID) (*program.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.programRepo.GetByID(ctx, userModel.ID, id)
+}
+
+func (s *Service) DeleteProgram(ctx context.Context, userEmail string, id uuid.UUID) error {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
+	return s.programRepo.Delete(ctx, userModel.ID, id)
+}
+
+// Enroll makes the user follow a program from a start date, ending the
+// enrollment the user was following before.
+func (s *Service) Enroll(ctx context.Context, userEmail string, id uuid.UUID, params program.EnrollRequest) (*program.EnrollmentModel, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	programModel, err := s.programRepo.GetByID(ctx, userModel.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment := &program.EnrollmentModel{
+		UserID:    userModel.ID,
+		ProgramID: programModel.ID,
+		StartDate: params.ParseStartDate(),
+	}
+
+	err = s.programRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.programRepo.EndEnrollments(txCtx, userModel.ID); err != nil {
+			return err
+		}
+
+		return s.programRepo.CreateEnrollment(txCtx, enrollment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment.Program = programModel
+
+	return enrollment, nil
+}
+
+// GetTodayWorkout resolves the workout the active enrollment prescribes for a
+// date, today by default, with weights derived from the user's records.
+func (s *Service) GetTodayWorkout(ctx context.Context, userEmail string, params program.TodayQueryParams) (*program.TodayResponse, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.programRepo.GetActiveEnrollment(ctx, userModel.ID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.NotFound("No active program enrollment").Wrap(err)
+		}
+
+		return nil, err
+	}
+
+	programModel := enrollment.Program
+	date := params.ParseDate(base.Today(s.location))
+
+	today := &program.TodayResponse{
+		Date:         date.Format(validation.DateLayout),
+		ProgramID:    programModel.ID,
+		ProgramName:  programModel.Name,
+		EnrollmentID: enrollment.ID,
+	}
+
+	week, day, ok := enrollment.Position(date)
+	if !ok {
+		today.NotStarted = true
+		return today, nil
+	}
+
+	today.Week = week
+	today.Day = day
+
+	if week > programModel.Length() {
+		today.Finished = true
+		return today, nil
+	}
+
+	weekModel := programModel.Week(week)
+	if weekModel == nil || weekModel.Day(day) == nil {
+		today.RestDay = true
+		return today, nil
+	}
+
+	workoutModel, err := s.workoutRepo.GetByIDWithRelations(ctx, weekModel.Day(day).WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	exerciseIDs := make([]uuid.UUID, len(workoutModel.WorkoutExercises))
+	for index, workoutExercise := range workoutModel.WorkoutExercises {
+		exerciseIDs[index] = workoutExercise.ExerciseID
+	}
+
+	oneRepMaxes, err := s.programRepo.GetEstimatedOneRepMaxes(ctx, userModel.ID, exerciseIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	today.Workout = &program.TodayWorkout{
+		ID:                  workoutModel.ID,
+		Name:                workoutModel.Name,
+		IntensityPercentage: weekModel.IntensityPercentage,
+		Notes:               weekModel.Notes,
+		Exercises:           program.Prescribe(weekModel, programModel.TrainingMaxPercentage, workoutModel.WorkoutExercises, oneRepMaxes),
+	}
+
+	return today, nil
+}
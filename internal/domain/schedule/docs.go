@@ -0,0 +1,43 @@
+package schedule
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "schedule"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/schedule",
+			Summary:  "Schedule a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     schedule.ScheduleWorkoutRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[[]schedule.ScheduledWorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/schedule",
+			Summary:  "List scheduled workouts",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    schedule.ListScheduleQueryParams{},
+			Response: response.SuccessResponse[schedule.ListScheduleResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/schedule/:id",
+			Summary: "Unschedule a workout",
+			Tag:     docsTag,
+			Auth:    true,
+			Status:  fiber.StatusNoContent,
+		},
+	)
+}
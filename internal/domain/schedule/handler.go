@@ -0,0 +1,80 @@
+package schedule
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	HTTPHandlerParams struct {
+		Router    fiber.Router
+		Service   *Service
+		JWTSecret string
+	}
+
+	httpHandler struct {
+		service *Service
+	}
+)
+
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		service: params.Service,
+	}
+
+	scheduleGroup := params.Router.Group("/schedule", middleware.AuthMiddleware(params.JWTSecret))
+	scheduleGroup.Post("/", httpHandler.ScheduleWorkout)
+	scheduleGroup.Get("/", httpHandler.ListSchedule)
+	scheduleGroup.Delete("/:id", httpHandler.Unschedule)
+}
+
+func (h *httpHandler) ScheduleWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var req schedule.ScheduleWorkoutRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	scheduled, err := h.service.ScheduleWorkout(c.Context(), claims.Email, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(schedule.NewScheduledWorkoutResponses(scheduled)))
+}
+
+func (h *httpHandler) ListSchedule(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqQuery schedule.ListScheduleQueryParams
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	result, err := h.service.ListSchedule(c.Context(), claims.Email, reqQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(result))
+}
+
+func (h *httpHandler) Unschedule(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	scheduledID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.Unschedule(c.Context(), claims.Email, scheduledID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).JSON(nil)
+}
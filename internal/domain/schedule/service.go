@@ -0,0 +1,129 @@
+package schedule
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	Service struct {
+		scheduleRepo repo.ScheduleRepository
+		workoutRepo  repo.WorkoutRepository
+		userRepo     repo.UserRepository
+		location     *time.Location
+	}
+
+	ServiceParams struct {
+		ScheduleRepo repo.ScheduleRepository
+		WorkoutRepo  repo.WorkoutRepository
+		UserRepo     repo.UserRepository
+		// Location decides which calendar day "today" is when telling
+		// missed workouts apart from upcoming ones.
+		Location *time.Location
+	}
+)
+
+var (
+	ErrWorkoutNotFound = domainerr.NotFound("Workout not found")
+)
+
+func NewService(params ServiceParams) *Service {
+	location := params.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return &Service{
+		scheduleRepo: params.ScheduleRepo,
+		workoutRepo:  params.WorkoutRepo,
+		userRepo:     params.UserRepo,
+		location:     location,
+	}
+}
+
+// ScheduleWorkout plans one of the user's workout templates on every
+// requested date.
+func (s *Service) ScheduleWorkout(ctx context.Context, userEmail string, params schedule.ScheduleWorkoutRequest) ([]*schedule.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutModel, err := s.workoutRepo.GetByID(ctx, params.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workoutModel.UserID != userModel.ID {
+		return nil, ErrWorkoutNotFound
+	}
+
+	dates := params.ParseDates()
+	models := make([]*schedule.Model, len(dates))
+
+	for index, date := range dates {
+		models[index] = &schedule.Model{
+			UserID:       userModel.ID,
+			WorkoutID:    workoutModel.ID,
+			ScheduledFor: date,
+			Notes:        params.Notes,
+			Status:       schedule.StatusPlanned,
+		}
+	}
+
+	if err := s.scheduleRepo.Create(ctx, models); err != nil {
+		return nil, err
+	}
+
+	today := base.Today(s.location)
+	for _, model := range models {
+		model.Workout = workoutModel
+		model.ResolveStatus(today)
+	}
+
+	return models, nil
+}
+
+// ListSchedule returns the workouts scheduled in a period together with a
+// summary of planned against completed workouts.
+func (s *Service) ListSchedule(ctx context.Context, userEmail string, params schedule.ListScheduleQueryParams) (*schedule.ListScheduleResponse, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	today := base.Today(s.location)
+	from, to := params.Period(today)
+
+	models, err := s.scheduleRepo.GetByPeriod(ctx, userModel.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, model := range models {
+		model.ResolveStatus(today)
+	}
+
+	return &schedule.ListScheduleResponse{
+		From:    from.Format(validation.DateLayout),
+		To:      to.Format(validation.DateLayout),
+		Items:   schedule.NewScheduledWorkoutResponses(models),
+		Summary: schedule.NewSummary(models),
+	}, nil
+}
+
+func (s *Service) Unschedule(ctx context.Context, userEmail string, id uuid.UUID) error {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
+	return s.scheduleRepo.Delete(ctx, userModel.ID, id)
+}
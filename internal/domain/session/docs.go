@@ -0,0 +1,63 @@
+package session
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "sessions"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/sessions",
+			Summary:  "Start a workout session",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workouthistory.StartSessionRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[workouthistory.SessionResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/sessions",
+			Summary:  "List workout sessions",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    workouthistory.ListSessionsQueryParams{},
+			Response: response.PaginationResponse[[]workouthistory.SessionResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/sessions/:id/complete",
+			Summary:  "Complete a workout session",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workouthistory.CompleteSessionRequest{},
+			Response: response.SuccessResponse[workouthistory.SessionResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/sessions/:id/events",
+			Summary:  "Publish an event of a running session",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workouthistory.PublishEventRequest{},
+			Status:   fiber.StatusAccepted,
+			Response: response.SuccessResponse[workouthistory.Event]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/sessions/:id/events",
+			Summary:  "Stream the events of a session over a WebSocket",
+			Tag:      docsTag,
+			Auth:     true,
+			Status:   fiber.StatusSwitchingProtocols,
+			Response: workouthistory.Event{},
+		},
+	)
+}
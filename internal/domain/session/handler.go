@@ -0,0 +1,179 @@
+package session
+
+import (
+	"context"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type (
+	HTTPHandlerParams struct {
+		Router    fiber.Router
+		Service   *Service
+		JWTSecret string
+	}
+
+	httpHandler struct {
+		service *Service
+	}
+)
+
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		service: params.Service,
+	}
+
+	sessionGroup := params.Router.Group("/sessions", middleware.AuthMiddleware(params.JWTSecret))
+	sessionGroup.Post("/", httpHandler.StartSession)
+	sessionGroup.Get("/", httpHandler.ListSessions)
+	sessionGroup.Post("/:id/complete", httpHandler.CompleteSession)
+	sessionGroup.Post("/:id/events", httpHandler.PublishEvent)
+	sessionGroup.Get("/:id/events", httpHandler.AuthorizeEventStream, websocket.New(httpHandler.StreamEvents))
+}
+
+func (h *httpHandler) StartSession(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var req workouthistory.StartSessionRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	sessionModel, err := h.service.StartSession(c.Context(), claims.Email, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workouthistory.NewSessionResponse(sessionModel)))
+}
+
+func (h *httpHandler) ListSessions(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqQuery workouthistory.ListSessionsQueryParams
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	reqQuery.ValidateAndSetDefaults()
+
+	sessions, page, err := h.service.ListSessions(c.Context(), claims.Email, reqQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(workouthistory.NewSessionResponses(sessions), reqQuery.Pagination(page)))
+}
+
+func (h *httpHandler) CompleteSession(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	sessionID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	// The body is optional, a session can be completed without notes.
+	var req workouthistory.CompleteSessionRequest
+	if len(c.Body()) > 0 {
+		if err := validation.ParseBody(c, &req); err != nil {
+			return err
+		}
+	}
+
+	sessionModel, err := h.service.CompleteSession(c.Context(), claims.Email, sessionID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workouthistory.NewSessionResponse(sessionModel)))
+}
+
+func (h *httpHandler) PublishEvent(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	sessionID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var req workouthistory.PublishEventRequest
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
+	}
+
+	event, err := h.service.PublishEvent(c.Context(), claims.Email, sessionID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(response.NewSuccessResponse(event))
+}
+
+// AuthorizeEventStream answers requests for the events of a session with an
+// error status, before upgrading to a WebSocket, unless the session belongs
+// to the user. Only the owner's devices may watch: there is no coach or
+// viewer relationship to grant others access yet.
+func (h *httpHandler) AuthorizeEventStream(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	claims := c.Locals("session").(*jwt.Claims)
+
+	sessionID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.service.GetSession(c.Context(), claims.Email, sessionID); err != nil {
+		return err
+	}
+
+	c.Locals("session_id", sessionID)
+
+	return c.Next()
+}
+
+// StreamEvents sends the events of the session to the client as JSON text
+// messages until it disconnects.
+func (h *httpHandler) StreamEvents(conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := h.service.SubscribeEvents(ctx, conn.Locals("session_id").(uuid.UUID))
+	if err != nil {
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, ""))
+		return
+	}
+
+	// Clients only send control frames, reading them notices when the
+	// client goes away.
+	disconnected := make(chan struct{})
+	go func(conn *websocket.Conn) {
+		defer close(disconnected)
+		defer cancel()
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}(conn)
+
+	for message := range events {
+		if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			break
+		}
+	}
+
+	_ = conn.Close()
+	<-disconnected
+}
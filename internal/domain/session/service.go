@@ -0,0 +1,197 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/pubsub"
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+)
+
+type (
+	// Service manages workout sessions, which are stored as workout history.
+	Service struct {
+		sessionRepo  repo.SessionRepository
+		scheduleRepo repo.ScheduleRepository
+		workoutRepo  repo.WorkoutRepository
+		userRepo     repo.UserRepository
+		eventHub     pubsub.Hub
+	}
+
+	ServiceParams struct {
+		SessionRepo  repo.SessionRepository
+		ScheduleRepo repo.ScheduleRepository
+		WorkoutRepo  repo.WorkoutRepository
+		UserRepo     repo.UserRepository
+		EventHub     pubsub.Hub // Streams session events to the devices watching them
+	}
+)
+
+var (
+	ErrWorkoutNotFound         = domainerr.NotFound("Workout not found")
+	ErrAlreadyStarted          = domainerr.Conflict("A session was already started for this scheduled workout")
+	ErrSessionAlreadyCompleted = domainerr.Conflict("Session already completed")
+)
+
+func NewService(params ServiceParams) *Service {
+	return &Service{
+		sessionRepo:  params.SessionRepo,
+		scheduleRepo: params.ScheduleRepo,
+		workoutRepo:  params.WorkoutRepo,
+		userRepo:     params.UserRepo,
+		eventHub:     params.EventHub,
+	}
+}
+
+// StartSession starts performing a workout, either ad hoc from one of the
+// user's templates or from a scheduled workout, which it then marks as in
+// progress.
+func (s *Service) StartSession(ctx context.Context, userEmail string, params workouthistory.StartSessionRequest) (*workouthistory.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionModel := &workouthistory.Model{
+		UserID: userModel.ID,
+	}
+
+	if params.ScheduledWorkoutID != nil {
+		scheduled, err := s.scheduleRepo.GetByID(ctx, userModel.ID, *params.ScheduledWorkoutID)
+		if err != nil {
+			return nil, err
+		}
+
+		if scheduled.Session != nil {
+			return nil, ErrAlreadyStarted
+		}
+
+		sessionModel.WorkoutID = scheduled.WorkoutID
+		sessionModel.ScheduledWorkoutID = &scheduled.ID
+	} else {
+		workoutModel, err := s.workoutRepo.GetByID(ctx, *params.WorkoutID)
+		if err != nil {
+			return nil, err
+		}
+
+		if workoutModel.UserID != userModel.ID {
+			return nil, ErrWorkoutNotFound
+		}
+
+		sessionModel.WorkoutID = workoutModel.ID
+	}
+
+	if err := s.sessionRepo.Create(ctx, sessionModel); err != nil {
+		if errors.Is(err, repo.ErrUniqueViolation) {
+			return nil, ErrAlreadyStarted.Wrap(err)
+		}
+
+		return nil, err
+	}
+
+	return sessionModel, nil
+}
+
+// CompleteSession finishes a running session.
+func (s *Service) CompleteSession(ctx context.Context, userEmail string, id uuid.UUID, params workouthistory.CompleteSessionRequest) (*workouthistory.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionModel, err := s.sessionRepo.GetByID(ctx, userModel.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessionModel.IsCompleted() {
+		return nil, ErrSessionAlreadyCompleted
+	}
+
+	completedAt := time.Now()
+	sessionModel.CompletedAt = &completedAt
+	sessionModel.Notes = params.Notes
+
+	if err := s.sessionRepo.Update(ctx, sessionModel); err != nil {
+		return nil, err
+	}
+
+	// The session is completed even if watching devices miss the event.
+	finished := workouthistory.Event{
+		Type:       workouthistory.EventSessionFinished,
+		SessionID:  sessionModel.ID,
+		OccurredAt: completedAt,
+	}
+	if err := s.publish(ctx, finished); err != nil {
+		fmt.Printf("publishing %s of session %s failed: %v\n", finished.Type, sessionModel.ID, err)
+	}
+
+	return sessionModel, nil
+}
+
+func (s *Service) ListSessions(ctx context.Context, userEmail string, params workouthistory.ListSessionsQueryParams) ([]*workouthistory.Model, base.PageInfo, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, base.PageInfo{}, err
+	}
+
+	return s.sessionRepo.GetPaginated(ctx, userModel.ID, params)
+}
+
+// GetSession returns a session of the user.
+func (s *Service) GetSession(ctx context.Context, userEmail string, id uuid.UUID) (*workouthistory.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sessionRepo.GetByID(ctx, userModel.ID, id)
+}
+
+// PublishEvent streams what happened during a running session to the
+// devices watching it.
+func (s *Service) PublishEvent(ctx context.Context, userEmail string, id uuid.UUID, params workouthistory.PublishEventRequest) (*workouthistory.Event, error) {
+	sessionModel, err := s.GetSession(ctx, userEmail, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if sessionModel.IsCompleted() {
+		return nil, ErrSessionAlreadyCompleted
+	}
+
+	if params.WorkoutExerciseID != nil {
+		if _, err := s.workoutRepo.GetWorkoutExercise(ctx, sessionModel.WorkoutID, *params.WorkoutExerciseID); err != nil {
+			return nil, err
+		}
+	}
+
+	event := sessionModel.NewEvent(params)
+	if err := s.publish(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+// SubscribeEvents returns the events of session id, encoded as JSON, until
+// ctx is done.
+func (s *Service) SubscribeEvents(ctx context.Context, id uuid.UUID) (<-chan []byte, error) {
+	return s.eventHub.Subscribe(ctx, workouthistory.EventTopic(id))
+}
+
+func (s *Service) publish(ctx context.Context, event workouthistory.Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return s.eventHub.Publish(ctx, workouthistory.EventTopic(event.SessionID), message)
+}
@@ -0,0 +1,25 @@
+package trash
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/trash"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "trash"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/trash",
+			Summary:  "List deleted items",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    trash.ListTrashQueryParams{},
+			Response: response.PaginationResponse[[]trash.Item]{},
+		},
+	)
+}
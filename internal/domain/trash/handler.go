@@ -0,0 +1,53 @@
+package trash
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/trash"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	HTTPHandlerParams struct {
+		Router      fiber.Router
+		Service     *Service
+		JWTSecret   string
+		AdminEmails []string // Admins also see the deleted items of the catalog
+	}
+
+	httpHandler struct {
+		service     *Service
+		adminEmails []string
+	}
+)
+
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		service:     params.Service,
+		adminEmails: params.AdminEmails,
+	}
+
+	trashGroup := params.Router.Group("/trash", middleware.AuthMiddleware(params.JWTSecret))
+	trashGroup.Get("/", httpHandler.ListTrash)
+}
+
+func (h *httpHandler) ListTrash(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqQuery trash.ListTrashQueryParams
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	reqQuery.ValidateAndSetDefaults()
+
+	items, total, err := h.service.ListTrash(c.Context(), claims.Email, middleware.IsAdmin(c, h.adminEmails), reqQuery)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(items, reqQuery.Pagination(base.PageInfo{Total: &total})))
+}
@@ -0,0 +1,88 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type (
+	PurgeJob struct {
+		service   *Service
+		retention time.Duration
+		interval  time.Duration
+		cleanups  []Cleanup
+		stop      chan struct{}
+	}
+
+	PurgeJobParams struct {
+		Service   *Service
+		Retention time.Duration
+		Interval  time.Duration
+		Cleanups  []Cleanup // Run after the purge, on the same interval
+	}
+
+	// Cleanup permanently removes records of another domain that are only
+	// kept for a while, returning how many were removed.
+	Cleanup struct {
+		Name string // What is removed, e.g. "expired idempotency keys"
+		Run  func(ctx context.Context) (int, error)
+	}
+)
+
+func NewPurgeJob(params PurgeJobParams) *PurgeJob {
+	return &PurgeJob{
+		service:   params.Service,
+		retention: params.Retention,
+		interval:  params.Interval,
+		cleanups:  params.Cleanups,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start runs the purge on every interval until Stop is called, permanently
+// removing trashed items that were deleted longer ago than the retention,
+// followed by the cleanups.
+func (j *PurgeJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				j.run(ctx)
+			case <-j.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (j *PurgeJob) Stop() {
+	close(j.stop)
+}
+
+func (j *PurgeJob) run(ctx context.Context) {
+	purged, err := j.service.Purge(ctx, j.retention)
+	if err != nil {
+		fmt.Printf("could not purge trash: %v\n", err)
+	} else if purged > 0 {
+		fmt.Printf("purged %d items from trash\n", purged)
+	}
+
+	for _, cleanup := range j.cleanups {
+		removed, err := cleanup.Run(ctx)
+		if err != nil {
+			fmt.Printf("could not remove %s: %v\n", cleanup.Name, err)
+			continue
+		}
+
+		if removed > 0 {
+			fmt.Printf("removed %d %s\n", removed, cleanup.Name)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package trash
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/trash"
+)
+
+type (
+	Service struct {
+		trashRepo repo.TrashRepository
+		userRepo  repo.UserRepository
+	}
+
+	ServiceParams struct {
+		TrashRepo repo.TrashRepository
+		UserRepo  repo.UserRepository
+	}
+)
+
+func NewService(params ServiceParams) *Service {
+	return &Service{
+		trashRepo: params.TrashRepo,
+		userRepo:  params.UserRepo,
+	}
+}
+
+// ListTrash lists the deleted workouts of the user, along with the deleted
+// exercises and muscle groups of the catalog when withCatalog is set, which
+// only admins can restore.
+func (s *Service) ListTrash(ctx context.Context, userEmail string, withCatalog bool, params trash.ListTrashQueryParams) ([]*trash.Item, int, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.trashRepo.GetPaginated(ctx, userModel.ID, withCatalog, params)
+}
+
+func (s *Service) Purge(ctx context.Context, retention time.Duration) (int, error) {
+	return s.trashRepo.Purge(ctx, time.Now().Add(-retention))
+}
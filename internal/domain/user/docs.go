@@ -0,0 +1,41 @@
+package user
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const docsTag = "users"
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/register",
+			Summary:  "Register a user",
+			Tag:      docsTag,
+			Body:     user.RegisterUserRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[user.RegisterUserResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/login",
+			Summary:  "Log in and get a token",
+			Tag:      docsTag,
+			Body:     user.LoginUserRequest{},
+			Response: response.SuccessResponse[user.LoginUserResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/users/profile",
+			Summary:  "Get the profile of the current user",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[user.GetUserProfileResponse]{},
+		},
+	)
+}
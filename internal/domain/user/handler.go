@@ -1,65 +1,48 @@
 package user
 
 import (
-	"strings"
-
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 )
 
 type (
 	HTTPHandlerParams struct {
-		App       *fiber.App
+		Router    fiber.Router
 		Service   *Service
 		JWTSecret string
 	}
 
 	httpHandler struct {
-		app     *fiber.App
 		service *Service
 	}
 )
 
 func NewHTTPHandler(params HTTPHandlerParams) {
 	httpHandler := &httpHandler{
-		app:     params.App,
 		service: params.Service,
 	}
 
-	params.App.Post("/register", httpHandler.RegisterUser)
-	params.App.Post("/login", httpHandler.LoginUser)
+	params.Router.Post("/register", httpHandler.RegisterUser)
+	params.Router.Post("/login", httpHandler.LoginUser)
 
-	userGroup := params.App.Group("/users", middleware.AuthMiddleware(params.JWTSecret))
+	userGroup := params.Router.Group("/users", middleware.AuthMiddleware(params.JWTSecret))
 	userGroup.Get("/profile", httpHandler.GetUserProfile)
 }
 
 func (h *httpHandler) RegisterUser(c *fiber.Ctx) error {
 	var req user.RegisterUserRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
-	}
-
-	if validationErr := req.Validate(); validationErr != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(
-			response.NewErrorInvalidRequestBody(validationErr))
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	if err := h.service.CreateUser(c.Context(), req.Name, req.Email, req.Password); err != nil {
-		if strings.Contains(err.Error(), "idx_users_email") {
-			return c.Status(fiber.StatusBadRequest).JSON(
-				response.NewErrorInvalidRequestBody(&response.ErrorDetails{
-					response.NewErrorDetail("email", "It looks like this email is already registered on our platform"),
-				}))
-		}
-
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(
@@ -71,20 +54,13 @@ func (h *httpHandler) RegisterUser(c *fiber.Ctx) error {
 func (h *httpHandler) LoginUser(c *fiber.Ctx) error {
 	var req user.LoginUserRequest
 
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(
-			response.NewErrorInvalidRequestBody(nil))
-	}
-
-	if validationErr := req.Validate(); validationErr != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(
-			response.NewErrorInvalidRequestBody(validationErr))
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	token, err := h.service.LoginUser(c.Context(), req.Email, req.Password)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(
-			response.NewErrorResponse("Unauthorized", fiber.StatusUnauthorized, nil))
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(user.LoginUserResponse{Token: token}))
@@ -95,8 +71,7 @@ func (h *httpHandler) GetUserProfile(c *fiber.Ctx) error {
 
 	userModel, err := h.service.GetUserProfile(c.Context(), claims.Email)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(user.GetUserProfileResponse{
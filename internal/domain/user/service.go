@@ -2,11 +2,14 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
 )
 
 type (
@@ -21,6 +24,11 @@ type (
 	}
 )
 
+var (
+	ErrEmailAlreadyRegistered = domainerr.InvalidRequestBody(&response.ErrorDetails{user.ErrorEmailAlreadyRegistered})
+	ErrInvalidCredentials     = domainerr.Unauthorized("Unauthorized")
+)
+
 func NewService(params ServiceParams) *Service {
 	return &Service{
 		userRepo:     params.UserRepo,
@@ -39,17 +47,29 @@ func (s *Service) CreateUser(ctx context.Context, name, email, password string)
 		return fmt.Errorf("could not hash password: %w", err)
 	}
 
-	return s.userRepo.Create(ctx, userMode)
+	if err := s.userRepo.Create(ctx, userMode); err != nil {
+		if errors.Is(err, repo.ErrUniqueViolation) {
+			return ErrEmailAlreadyRegistered.Wrap(err)
+		}
+
+		return err
+	}
+
+	return nil
 }
 
 func (s *Service) LoginUser(ctx context.Context, email, password string) (*string, error) {
 	userModel, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, ErrInvalidCredentials.Wrap(err)
+		}
+
 		return nil, fmt.Errorf("could not find user: %w", err)
 	}
 
 	if !userModel.CheckPassword(password) {
-		return nil, fmt.Errorf("invalid password")
+		return nil, ErrInvalidCredentials
 	}
 
 	token, err := s.tokenService.GenerateToken(userModel.Email)
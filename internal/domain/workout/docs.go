@@ -0,0 +1,181 @@
+package workout
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	docsTag       = "workouts"
+	sharedDocsTag = "shared workouts"
+)
+
+// Docs documents the routes registered by NewHTTPHandler.
+func Docs(document *openapi.Document) {
+	document.Add(
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/workouts",
+			Summary:  "Create a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workout.CreateWorkoutRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/workouts",
+			Summary:  "List the workouts of the current user",
+			Tag:      docsTag,
+			Auth:     true,
+			Query:    workout.ListWorkoutsQueryParams{},
+			Response: response.PaginationResponse[[]workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/workouts/:id",
+			Summary:  "Get a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPut,
+			Path:     "/workouts/:id",
+			Summary:  "Update a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workout.UpdateWorkoutRequest{},
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:          fiber.MethodPatch,
+			Path:            "/workouts/:id",
+			Summary:         "Patch a workout",
+			Tag:             docsTag,
+			Auth:            true,
+			Body:            workout.UpdateWorkoutRequest{},
+			BodyContentType: mergepatch.MIMEMergePatchJSON,
+			Response:        response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/workouts/:id",
+			Summary: "Delete a workout",
+			Tag:     docsTag,
+			Auth:    true,
+			Status:  fiber.StatusNoContent,
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/workouts/:id/exercises",
+			Summary:  "Add an exercise to a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workout.AddWorkoutExerciseRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[workoutexercise.WorkoutExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPut,
+			Path:     "/workouts/:id/exercises/order",
+			Summary:  "Reorder the exercises of a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workout.ReorderWorkoutExercisesRequest{},
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPut,
+			Path:     "/workouts/:workoutID/exercises/:workoutExerciseID",
+			Summary:  "Update an exercise of a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workout.UpdateWorkoutExerciseRequest{},
+			Response: response.SuccessResponse[workoutexercise.WorkoutExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:          fiber.MethodPatch,
+			Path:            "/workouts/:workoutID/exercises/:workoutExerciseID",
+			Summary:         "Patch an exercise of a workout",
+			Tag:             docsTag,
+			Auth:            true,
+			Body:            workout.UpdateWorkoutExerciseRequest{},
+			BodyContentType: mergepatch.MIMEMergePatchJSON,
+			Response:        response.SuccessResponse[workoutexercise.WorkoutExerciseResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/workouts/:workoutID/exercises/:workoutExerciseID",
+			Summary: "Remove an exercise from a workout",
+			Tag:     docsTag,
+			Auth:    true,
+			Status:  fiber.StatusNoContent,
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/workouts/:id/restore",
+			Summary:  "Restore a deleted workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/workouts/:id/duplicate",
+			Summary:  "Duplicate a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Body:     workout.CopyWorkoutRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/workouts/:id/share-links",
+			Summary:  "Create a share link for a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[workout.ShareLinkResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/workouts/:id/share-links",
+			Summary:  "List the share links of a workout",
+			Tag:      docsTag,
+			Auth:     true,
+			Response: response.SuccessResponse[[]workout.ShareLinkResponse]{},
+		},
+		openapi.Route{
+			Method:  fiber.MethodDelete,
+			Path:    "/workouts/:id/share-links/:linkID",
+			Summary: "Revoke a share link",
+			Tag:     docsTag,
+			Auth:    true,
+			Status:  fiber.StatusNoContent,
+		},
+		openapi.Route{
+			Method:   fiber.MethodGet,
+			Path:     "/shared/workouts/:token",
+			Summary:  "Get a shared workout",
+			Tag:      sharedDocsTag,
+			Response: response.SuccessResponse[workout.SharedWorkoutResponse]{},
+		},
+		openapi.Route{
+			Method:   fiber.MethodPost,
+			Path:     "/shared/workouts/:token/import",
+			Summary:  "Import a shared workout",
+			Tag:      sharedDocsTag,
+			Auth:     true,
+			Body:     workout.CopyWorkoutRequest{},
+			Status:   fiber.StatusCreated,
+			Response: response.SuccessResponse[workout.WorkoutResponse]{},
+		},
+	)
+}
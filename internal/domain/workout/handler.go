@@ -2,16 +2,18 @@ package workout
 
 import (
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 )
 
 type (
 	HTTPHandlerParams struct {
-		App       *fiber.App
+		Router    fiber.Router
 		Service   *Service
 		JWTSecret string
 	}
@@ -26,103 +28,386 @@ func NewHTTPHandler(params HTTPHandlerParams) {
 		service: params.Service,
 	}
 
-	workoutGroup := params.App.Group("/workouts", middleware.AuthMiddleware(params.JWTSecret))
+	workoutGroup := params.Router.Group("/workouts", middleware.AuthMiddleware(params.JWTSecret))
 	workoutGroup.Post("/", httpHandler.CreateWorkout)
 	workoutGroup.Get("/", httpHandler.GetUserWorkoutPaginated)
+	workoutGroup.Get("/:id", httpHandler.GetWorkout)
 	workoutGroup.Put("/:id", httpHandler.UpdateWorkout)
+	workoutGroup.Patch("/:id", httpHandler.PatchWorkout)
+	workoutGroup.Delete("/:id", httpHandler.DeleteWorkout)
+	workoutGroup.Post("/:id/exercises", httpHandler.AddWorkoutExercise)
+	workoutGroup.Put("/:id/exercises/order", httpHandler.ReorderWorkoutExercises)
 	workoutGroup.Put("/:workoutID/exercises/:workoutExerciseID", httpHandler.UpdateWorkoutExercise)
+	workoutGroup.Patch("/:workoutID/exercises/:workoutExerciseID", httpHandler.PatchWorkoutExercise)
+	workoutGroup.Delete("/:workoutID/exercises/:workoutExerciseID", httpHandler.RemoveWorkoutExercise)
+	workoutGroup.Post("/:id/restore", httpHandler.RestoreWorkout)
+	workoutGroup.Post("/:id/duplicate", httpHandler.DuplicateWorkout)
+	workoutGroup.Post("/:id/share-links", httpHandler.CreateShareLink)
+	workoutGroup.Get("/:id/share-links", httpHandler.ListShareLinks)
+	workoutGroup.Delete("/:id/share-links/:linkID", httpHandler.RevokeShareLink)
+
+	sharedGroup := params.Router.Group("/shared/workouts")
+	sharedGroup.Get("/:token", httpHandler.GetSharedWorkout)
+	sharedGroup.Post("/:token/import", middleware.AuthMiddleware(params.JWTSecret), httpHandler.ImportSharedWorkout)
 }
 
 func (h *httpHandler) CreateWorkout(c *fiber.Ctx) error {
 	var req workout.CreateWorkoutRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &req); err != nil {
+		return err
 	}
 
 	workoutModel, err := h.service.CreateWorkout(c.Context(), req)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workoutModel))
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
 }
 
 func (h *httpHandler) GetUserWorkoutPaginated(c *fiber.Ctx) error {
 	claims := c.Locals("session").(*jwt.Claims)
 
 	var reqQuery workout.ListWorkoutsQueryParams
-	if err := c.QueryParser(&reqQuery); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseQuery(c, &reqQuery); err != nil {
+		return err
+	}
+
+	reqQuery.ValidateAndSetDefaults()
+
+	workouts, page, err := h.service.ListUserWorkouts(c.Context(), claims.Email, reqQuery)
+	if err != nil {
+		return err
+	}
+
+	responses, err := reqQuery.Project(workout.NewWorkoutResponses(workouts), workout.Fields)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(responses, reqQuery.Pagination(page)))
+}
+
+func (h *httpHandler) GetWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
 	}
 
-	workouts, total, err := h.service.ListUserWorkouts(c.Context(), claims.Email, reqQuery)
+	workoutModel, err := h.service.GetWorkout(c.Context(), claims.Email, workoutID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
+	}
+
+	if etag.NotModifiedTag(c, workoutModel.Tag()) {
+		return c.SendStatus(fiber.StatusNotModified)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewPaginationResponse(workouts, response.Pagination{
-		Page:       reqQuery.Page,
-		PerPage:    reqQuery.PerPage,
-		TotalItems: total,
-	}))
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
 }
 
 func (h *httpHandler) UpdateWorkout(c *fiber.Ctx) error {
-	workoutID, err := uuid.Parse(c.Params("id"))
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidURLParam(&response.ErrorDetails{
-				response.NewErrorDetail("id", "Invalid UUID format"),
-			}))
+		return err
 	}
 
 	var reqParams workout.UpdateWorkoutRequest
-	if err := c.BodyParser(&reqParams); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
+	}
+
+	workoutModel, err := h.service.UpdateWorkout(c.Context(), claims.Email, workoutID, reqParams, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.SetTag(c, workoutModel.Tag())
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
+}
+
+func (h *httpHandler) PatchWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	patch, err := validation.ParseMergePatch(c)
+	if err != nil {
+		return err
+	}
+
+	workoutModel, err := h.service.PatchWorkout(c.Context(), claims.Email, workoutID, patch, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.SetTag(c, workoutModel.Tag())
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
+}
+
+func (h *httpHandler) DeleteWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.DeleteWorkout(c.Context(), claims.Email, workoutID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+func (h *httpHandler) AddWorkoutExercise(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqParams workout.AddWorkoutExerciseRequest
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
 	}
 
-	workoutModel, err := h.service.UpdateWorkout(c.Context(), workoutID, reqParams)
+	workoutExercise, tag, err := h.service.AddWorkoutExercise(c.Context(), claims.Email, workoutID, reqParams, etag.IfMatch(c))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workoutModel))
+	etag.SetTag(c, tag)
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workoutexercise.NewWorkoutExerciseResponse(workoutExercise)))
 }
 
 func (h *httpHandler) UpdateWorkoutExercise(c *fiber.Ctx) error {
-	workoutID, err := uuid.Parse(c.Params("workoutID"))
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "workoutID")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidURLParam(&response.ErrorDetails{
-				response.NewErrorDetail("workoutID", "Invalid UUID format"),
-			}))
+		return err
 	}
 
-	workoutExerciseID, err := uuid.Parse(c.Params("workoutExerciseID"))
+	workoutExerciseID, err := validation.ParseUUIDParam(c, "workoutExerciseID")
 	if err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidURLParam(&response.ErrorDetails{
-				response.NewErrorDetail("workoutExerciseID", "Invalid UUID format"),
-			}))
+		return err
 	}
 
 	var reqParams workout.UpdateWorkoutExerciseRequest
-	if err := c.BodyParser(&reqParams); err != nil {
-		return c.Status(fiber.StatusUnprocessableEntity).JSON(
-			response.NewErrorInvalidRequestBody(nil))
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
+	}
+
+	workoutExercise, tag, err := h.service.UpdateWorkoutExercise(c.Context(), claims.Email, workoutID, workoutExerciseID, reqParams, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.SetTag(c, tag)
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workoutexercise.NewWorkoutExerciseResponse(workoutExercise)))
+}
+
+func (h *httpHandler) PatchWorkoutExercise(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "workoutID")
+	if err != nil {
+		return err
+	}
+
+	workoutExerciseID, err := validation.ParseUUIDParam(c, "workoutExerciseID")
+	if err != nil {
+		return err
+	}
+
+	patch, err := validation.ParseMergePatch(c)
+	if err != nil {
+		return err
+	}
+
+	workoutExercise, tag, err := h.service.PatchWorkoutExercise(c.Context(), claims.Email, workoutID, workoutExerciseID, patch, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.SetTag(c, tag)
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workoutexercise.NewWorkoutExerciseResponse(workoutExercise)))
+}
+
+func (h *httpHandler) RemoveWorkoutExercise(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "workoutID")
+	if err != nil {
+		return err
+	}
+
+	workoutExerciseID, err := validation.ParseUUIDParam(c, "workoutExerciseID")
+	if err != nil {
+		return err
+	}
+
+	tag, err := h.service.RemoveWorkoutExercise(c.Context(), claims.Email, workoutID, workoutExerciseID, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.SetTag(c, tag)
+
+	return c.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+func (h *httpHandler) ReorderWorkoutExercises(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqParams workout.ReorderWorkoutExercisesRequest
+	if err := validation.ParseBody(c, &reqParams); err != nil {
+		return err
+	}
+
+	workoutModel, err := h.service.ReorderWorkoutExercises(c.Context(), claims.Email, workoutID, reqParams, etag.IfMatch(c))
+	if err != nil {
+		return err
+	}
+
+	etag.SetTag(c, workoutModel.Tag())
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
+}
+
+func (h *httpHandler) RestoreWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	workoutModel, err := h.service.RestoreWorkout(c.Context(), claims.Email, workoutID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
+}
+
+func (h *httpHandler) DuplicateWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	var reqParams workout.CopyWorkoutRequest
+	if len(c.Body()) > 0 {
+		if err := validation.ParseBody(c, &reqParams); err != nil {
+			return err
+		}
+	}
+
+	workoutModel, err := h.service.DuplicateWorkout(c.Context(), claims.Email, workoutID, reqParams)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
+}
+
+func (h *httpHandler) CreateShareLink(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	link, err := h.service.CreateShareLink(c.Context(), claims.Email, workoutID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workout.NewShareLinkResponse(link)))
+}
+
+func (h *httpHandler) ListShareLinks(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	links, err := h.service.ListShareLinks(c.Context(), claims.Email, workoutID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewShareLinkResponses(links)))
+}
+
+func (h *httpHandler) RevokeShareLink(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	workoutID, err := validation.ParseUUIDParam(c, "id")
+	if err != nil {
+		return err
+	}
+
+	linkID, err := validation.ParseUUIDParam(c, "linkID")
+	if err != nil {
+		return err
+	}
+
+	if err := h.service.RevokeShareLink(c.Context(), claims.Email, workoutID, linkID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusNoContent).JSON(nil)
+}
+
+func (h *httpHandler) GetSharedWorkout(c *fiber.Ctx) error {
+	workoutModel, err := h.service.GetSharedWorkout(c.Context(), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workout.NewSharedWorkoutResponse(workoutModel)))
+}
+
+func (h *httpHandler) ImportSharedWorkout(c *fiber.Ctx) error {
+	claims := c.Locals("session").(*jwt.Claims)
+
+	var reqParams workout.CopyWorkoutRequest
+	if len(c.Body()) > 0 {
+		if err := validation.ParseBody(c, &reqParams); err != nil {
+			return err
+		}
 	}
 
-	workoutExercise, err := h.service.UpdateWorkoutExercise(c.Context(), workoutID, workoutExerciseID, reqParams)
+	workoutModel, err := h.service.ImportSharedWorkout(c.Context(), claims.Email, c.Params("token"), reqParams)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(
-			response.NewErrorResponse(err.Error(), fiber.StatusInternalServerError, nil))
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response.NewSuccessResponse(workoutExercise))
+	return c.Status(fiber.StatusCreated).JSON(response.NewSuccessResponse(workout.NewWorkoutResponse(workoutModel)))
 }
@@ -2,29 +2,45 @@ package workout
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/google/uuid"
 )
 
 type (
 	Service struct {
-		workoutRepo repo.WorkoutRepository
-		userRepo    repo.UserRepository
+		workoutRepo  repo.WorkoutRepository
+		exerciseRepo repo.ExerciseRepository
+		userRepo     repo.UserRepository
 	}
 
 	ServiceParams struct {
-		WorkoutRepo repo.WorkoutRepository
-		UserRepo    repo.UserRepository
+		WorkoutRepo  repo.WorkoutRepository
+		ExerciseRepo repo.ExerciseRepository
+		UserRepo     repo.UserRepository
 	}
 )
 
+var (
+	ErrWorkoutNotFound       = domainerr.NotFound("Workout not found")
+	ErrSharedWorkoutNotFound = domainerr.NotFound("Shared workout not found")
+	ErrWorkoutChanged        = domainerr.PreconditionFailed("Workout was changed since it was read")
+)
+
 func NewService(params ServiceParams) *Service {
 	return &Service{
-		workoutRepo: params.WorkoutRepo,
-		userRepo:    params.UserRepo,
+		workoutRepo:  params.WorkoutRepo,
+		exerciseRepo: params.ExerciseRepo,
+		userRepo:     params.UserRepo,
 	}
 }
 
@@ -52,66 +68,641 @@ func (s *Service) CreateWorkout(ctx context.Context, params workout.CreateWorkou
 }
 
 func (s *Service) createWorkoutExercises(ctx context.Context, workoutID uuid.UUID, params workout.CreateWorkoutRequest) error {
+	groups, err := s.createWorkoutExerciseGroups(ctx, workoutID, params.Groups)
+	if err != nil {
+		return err
+	}
+
 	workoutExercises := make([]*workoutexercise.Model, len(params.Exercises))
+	exerciseSets := make([][]*workoutexercise.SetModel, len(params.Exercises))
 
 	for index, workoutExercise := range params.Exercises {
+		var groupID *uuid.UUID
+		if workoutExercise.Group != nil {
+			groupID = &groups[*workoutExercise.Group].ID
+		}
+
+		exerciseSets[index] = workoutExercise.ExpandSets()
+		sets, repetitions, weight := workoutexercise.Summarize(exerciseSets[index])
+
 		workoutExercises[index] = &workoutexercise.Model{
 			WorkoutID:   workoutID,
 			ExerciseID:  workoutExercise.ExerciseID,
-			Sets:        workoutExercise.Sets,
-			Repetitions: workoutExercise.Repetitions,
-			Weight:      workoutExercise.Weight,
+			Position:    index,
+			GroupID:     groupID,
+			Sets:        sets,
+			Repetitions: repetitions,
+			Weight:      weight,
 			Duration:    workoutExercise.Duration,
 			RestTime:    workoutExercise.RestTime,
 			Notes:       workoutExercise.Notes,
 		}
 	}
 
-	return s.workoutRepo.CreateWorkoutExercises(ctx, workoutExercises)
+	if err := s.workoutRepo.CreateWorkoutExercises(ctx, workoutExercises); err != nil {
+		return err
+	}
+
+	var allSets []*workoutexercise.SetModel
+	for index, sets := range exerciseSets {
+		for _, set := range sets {
+			set.WorkoutExerciseID = workoutExercises[index].ID
+			allSets = append(allSets, set)
+		}
+	}
+
+	return s.workoutRepo.CreateWorkoutExerciseSets(ctx, allSets)
+}
+
+func (s *Service) createWorkoutExerciseGroups(ctx context.Context, workoutID uuid.UUID, params []workout.ExerciseGroup) ([]*workoutexercise.GroupModel, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+
+	groups := make([]*workoutexercise.GroupModel, len(params))
+	for index, group := range params {
+		groups[index] = &workoutexercise.GroupModel{
+			WorkoutID: workoutID,
+			Type:      group.Type,
+			TimeCap:   group.TimeCap,
+		}
+	}
+
+	return groups, s.workoutRepo.CreateWorkoutExerciseGroups(ctx, groups)
+}
+
+// GetWorkout returns a workout of the user with its exercises arranged.
+func (s *Service) GetWorkout(ctx context.Context, userEmail string, workoutID uuid.UUID) (*workout.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	workoutModel, err := s.workoutRepo.GetByIDWithRelations(ctx, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if workoutModel.UserID != userModel.ID {
+		return nil, ErrWorkoutNotFound
+	}
+
+	return workoutModel, nil
+}
+
+func (s *Service) DeleteWorkout(ctx context.Context, userEmail string, workoutID uuid.UUID) error {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return err
+	}
+
+	return s.workoutRepo.Delete(ctx, userModel.ID, workoutID)
+}
+
+// AddWorkoutExercise appends an exercise to the end of a workout, returning
+// it with the tag of the workout after the change. With ifMatch, the exercise
+// is only added to the workout versions it lists.
+func (s *Service) AddWorkoutExercise(ctx context.Context, userEmail string, workoutID uuid.UUID, params workout.AddWorkoutExerciseRequest, ifMatch *etag.Precondition) (*workoutexercise.Model, string, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := s.exerciseRepo.GetForUser(ctx, workoutModel.UserID, params.ExerciseID); err != nil {
+		return nil, "", err
+	}
+
+	position := 0
+	for _, workoutExercise := range workoutModel.WorkoutExercises {
+		position = max(position, workoutExercise.Position+1)
+	}
+
+	sets := params.ExpandSets()
+	workoutExercise := &workoutexercise.Model{
+		WorkoutID:  workoutID,
+		ExerciseID: params.ExerciseID,
+		Position:   position,
+		Duration:   params.Duration,
+		RestTime:   params.RestTime,
+		Notes:      params.Notes,
+	}
+	workoutExercise.Sets, workoutExercise.Repetitions, workoutExercise.Weight = workoutexercise.Summarize(sets)
+
+	err = s.workoutRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.workoutRepo.CreateWorkoutExercises(txCtx, []*workoutexercise.Model{workoutExercise}); err != nil {
+			return err
+		}
+
+		for _, set := range sets {
+			set.WorkoutExerciseID = workoutExercise.ID
+		}
+
+		if err := s.workoutRepo.CreateWorkoutExerciseSets(txCtx, sets); err != nil {
+			return err
+		}
+
+		return s.touchWorkout(txCtx, workoutModel, ifMatch)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.getWorkoutExercise(ctx, workoutID, workoutExercise.ID)
+}
+
+// RemoveWorkoutExercise removes an exercise from a workout, returning the tag
+// of the workout after the change. With ifMatch, the exercise is only removed
+// from the workout versions it lists.
+func (s *Service) RemoveWorkoutExercise(ctx context.Context, userEmail string, workoutID uuid.UUID, workoutExerciseID uuid.UUID, ifMatch *etag.Precondition) (string, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.workoutRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.workoutRepo.DeleteWorkoutExercise(txCtx, workoutID, workoutExerciseID); err != nil {
+			return err
+		}
+
+		return s.touchWorkout(txCtx, workoutModel, ifMatch)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	updated, err := s.workoutRepo.GetByIDWithRelations(ctx, workoutID)
+	if err != nil {
+		return "", err
+	}
+
+	return updated.Tag(), nil
 }
 
-func (s *Service) ListUserWorkouts(ctx context.Context, userEmail string, params workout.ListWorkoutsQueryParams) ([]*workout.Model, int, error) {
+func (s *Service) ListUserWorkouts(ctx context.Context, userEmail string, params workout.ListWorkoutsQueryParams) ([]*workout.Model, base.PageInfo, error) {
 	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
 	if err != nil {
-		return nil, 0, err
+		return nil, base.PageInfo{}, err
 	}
 
 	return s.workoutRepo.GetPaginated(ctx, userModel.ID, params)
 }
 
-func (s *Service) UpdateWorkout(ctx context.Context, workoutID uuid.UUID, params workout.UpdateWorkoutRequest) (*workout.Model, error) {
-	workoutModel, err := s.workoutRepo.GetByID(ctx, workoutID)
+// UpdateWorkout renames a workout of the user. With ifMatch, the update only
+// applies to the versions it lists.
+func (s *Service) UpdateWorkout(ctx context.Context, userEmail string, workoutID uuid.UUID, params workout.UpdateWorkoutRequest, ifMatch *etag.Precondition) (*workout.Model, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveWorkout(ctx, workoutModel, params)
+}
+
+// PatchWorkout applies a JSON merge patch to a workout of the user, saving
+// only the members it changes. With ifMatch, the patch only applies to the
+// versions it lists.
+func (s *Service) PatchWorkout(ctx context.Context, userEmail string, workoutID uuid.UUID, patch mergepatch.Patch, ifMatch *etag.Precondition) (*workout.Model, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
 	if err != nil {
 		return nil, err
 	}
 
+	params := workout.NewUpdateWorkoutRequest(workoutModel)
+	if err := validation.ApplyMergePatch(patch, &params); err != nil {
+		return nil, err
+	}
+
+	columns := patch.Members("name")
+	if len(columns) == 0 {
+		return workoutModel, nil
+	}
+
+	return s.saveWorkout(ctx, workoutModel, params, columns...)
+}
+
+// saveWorkout saves the edited columns of a workout, or all of them when none
+// are given.
+func (s *Service) saveWorkout(ctx context.Context, workoutModel *workout.Model, params workout.UpdateWorkoutRequest, columns ...string) (*workout.Model, error) {
 	workoutModel.Name = params.Name
 
-	err = s.workoutRepo.UpdateWorkout(ctx, workoutID, workoutModel)
+	err := s.workoutRepo.UpdateWorkout(ctx, workoutModel.ID, workoutModel, columns...)
+	if errors.Is(err, repo.ErrVersionConflict) {
+		return nil, ErrWorkoutChanged.Wrap(err)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
-	return s.workoutRepo.GetByIDWithRelations(ctx, workoutID)
+	return s.workoutRepo.GetByIDWithRelations(ctx, workoutModel.ID)
 }
 
-func (s *Service) UpdateWorkoutExercise(ctx context.Context, workoutID uuid.UUID, workoutExerciseID uuid.UUID, params workout.UpdateWorkoutExerciseRequest) (*workoutexercise.Model, error) {
+// UpdateWorkoutExercise replaces an exercise of a workout of the user,
+// returning it with the tag of the workout after the change. With ifMatch,
+// the exercise is only replaced in the workout versions it lists.
+func (s *Service) UpdateWorkoutExercise(ctx context.Context, userEmail string, workoutID uuid.UUID, workoutExerciseID uuid.UUID, params workout.UpdateWorkoutExerciseRequest, ifMatch *etag.Precondition) (*workoutexercise.Model, string, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
+	if err != nil {
+		return nil, "", err
+	}
+
 	workoutExercise, err := s.workoutRepo.GetWorkoutExercise(ctx, workoutID, workoutExerciseID)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	return s.saveWorkoutExercise(ctx, workoutModel, workoutExercise, params, ifMatch, true)
+}
+
+// PatchWorkoutExercise applies a JSON merge patch to an exercise of a workout
+// of the user, saving only the members it changes. Patching any member of the
+// load prescribes it anew, from the flat summary of the current sets unless
+// set_prescriptions is sent. With ifMatch, the patch only applies to the
+// workout versions it lists.
+func (s *Service) PatchWorkoutExercise(ctx context.Context, userEmail string, workoutID uuid.UUID, workoutExerciseID uuid.UUID, patch mergepatch.Patch, ifMatch *etag.Precondition) (*workoutexercise.Model, string, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
+	if err != nil {
+		return nil, "", err
+	}
+
+	workoutExercise, err := s.workoutRepo.GetWorkoutExercise(ctx, workoutID, workoutExerciseID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	params := workout.NewUpdateWorkoutExerciseRequest(workoutExercise)
+	if patch.Has("set_prescriptions") {
+		// The prescription per set replaces the flat one
+		params.Sets, params.Repetitions, params.Weight = 0, nil, nil
+	}
+
+	if err := validation.ApplyMergePatch(patch, &params); err != nil {
+		return nil, "", err
 	}
 
-	workoutExercise.Sets = params.Sets
-	workoutExercise.Repetitions = params.Repetitions
-	workoutExercise.Weight = params.Weight
+	columns := patch.Members("duration", "rest_time", "notes")
+	replaceSets := patch.Has("sets", "repetitions", "weight", "set_prescriptions")
+	if replaceSets {
+		columns = append(columns, "sets", "repetitions", "weight")
+	}
+
+	if len(columns) == 0 {
+		return workoutExercise, workoutModel.Tag(), nil
+	}
+
+	return s.saveWorkoutExercise(ctx, workoutModel, workoutExercise, params, ifMatch, replaceSets, columns...)
+}
+
+// saveWorkoutExercise saves the edited columns of a workout exercise, or all
+// of them when none are given, and its sets when replaceSets is set.
+func (s *Service) saveWorkoutExercise(
+	ctx context.Context,
+	workoutModel *workout.Model,
+	workoutExercise *workoutexercise.Model,
+	params workout.UpdateWorkoutExerciseRequest,
+	ifMatch *etag.Precondition,
+	replaceSets bool,
+	columns ...string,
+) (*workoutexercise.Model, string, error) {
+	var sets []*workoutexercise.SetModel
+	if replaceSets {
+		sets = params.ExpandSets()
+		workoutExercise.Sets, workoutExercise.Repetitions, workoutExercise.Weight = workoutexercise.Summarize(sets)
+	}
 	workoutExercise.Duration = params.Duration
 	workoutExercise.RestTime = params.RestTime
 	workoutExercise.Notes = params.Notes
 
-	err = s.workoutRepo.UpdateWorkoutExercise(ctx, workoutID, workoutExerciseID, workoutExercise)
+	workoutID, workoutExerciseID := workoutExercise.WorkoutID, workoutExercise.ID
+
+	err := s.workoutRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		err := s.workoutRepo.UpdateWorkoutExercise(txCtx, workoutID, workoutExerciseID, workoutExercise, columns...)
+		if err != nil {
+			return err
+		}
+
+		if replaceSets {
+			if err := s.workoutRepo.ReplaceWorkoutExerciseSets(txCtx, workoutExerciseID, sets); err != nil {
+				return err
+			}
+		}
+
+		return s.touchWorkout(txCtx, workoutModel, ifMatch)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.getWorkoutExercise(ctx, workoutID, workoutExerciseID)
+}
+
+// getWorkoutIfMatch returns a workout of the user, unless ifMatch is given
+// and does not list its tag.
+func (s *Service) getWorkoutIfMatch(ctx context.Context, userEmail string, workoutID uuid.UUID, ifMatch *etag.Precondition) (*workout.Model, error) {
+	workoutModel, err := s.GetWorkout(ctx, userEmail, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ifMatch != nil && !ifMatch.MatchesTag(workoutModel.Tag()) {
+		return nil, ErrWorkoutChanged
+	}
+
+	return workoutModel, nil
+}
+
+// touchWorkout marks a workout as changed once its exercises changed. With
+// ifMatch, it fails with ErrWorkoutChanged when the workout changed since it
+// was read for the precondition.
+func (s *Service) touchWorkout(ctx context.Context, workoutModel *workout.Model, ifMatch *etag.Precondition) error {
+	if ifMatch == nil {
+		return s.workoutRepo.IncrementVersion(ctx, workoutModel.ID)
+	}
+
+	err := s.workoutRepo.UpdateWorkout(ctx, workoutModel.ID, workoutModel, "updated_at")
+	if errors.Is(err, repo.ErrVersionConflict) {
+		return ErrWorkoutChanged.Wrap(err)
+	}
+
+	return err
+}
+
+// getWorkoutExercise returns an exercise of a workout with the tag of the
+// workout.
+func (s *Service) getWorkoutExercise(ctx context.Context, workoutID uuid.UUID, workoutExerciseID uuid.UUID) (*workoutexercise.Model, string, error) {
+	workoutModel, err := s.workoutRepo.GetByIDWithRelations(ctx, workoutID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	workoutExercise, err := s.workoutRepo.GetWorkoutExercise(ctx, workoutID, workoutExerciseID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return workoutExercise, workoutModel.Tag(), nil
+}
+
+// ReorderWorkoutExercises moves the exercises of a workout to the order of
+// the given IDs, which must list every exercise once and keep groups together.
+// With ifMatch, the order only changes for the workout versions it lists.
+func (s *Service) ReorderWorkoutExercises(ctx context.Context, userEmail string, workoutID uuid.UUID, params workout.ReorderWorkoutExercisesRequest, ifMatch *etag.Precondition) (*workout.Model, error) {
+	workoutModel, err := s.getWorkoutIfMatch(ctx, userEmail, workoutID, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkReorder(workoutModel.WorkoutExercises, params.WorkoutExerciseIDs); err != nil {
+		return nil, err
+	}
+
+	err = s.workoutRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.workoutRepo.ReorderWorkoutExercises(txCtx, workoutID, params.WorkoutExerciseIDs); err != nil {
+			return err
+		}
+
+		// Saving the loaded workout fails when it changed since the exercises
+		// were checked.
+		return s.workoutRepo.UpdateWorkout(txCtx, workoutID, workoutModel)
+	})
+	if errors.Is(err, repo.ErrVersionConflict) {
+		return nil, ErrWorkoutChanged.Wrap(err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.workoutRepo.GetByIDWithRelations(ctx, workoutID)
+}
+
+func checkReorder(exercises []*workoutexercise.Model, ids []uuid.UUID) error {
+	byID := make(map[uuid.UUID]*workoutexercise.Model, len(exercises))
+	for _, exercise := range exercises {
+		byID[exercise.ID] = exercise
+	}
+
+	if len(ids) != len(exercises) {
+		return domainerr.InvalidRequestBody(&response.ErrorDetails{workout.ErrorExercisesMismatch})
+	}
+
+	groupPositions := make(map[uuid.UUID][]int)
+	for position, id := range ids {
+		exercise, ok := byID[id]
+		if !ok {
+			return domainerr.InvalidRequestBody(&response.ErrorDetails{workout.ErrorExercisesMismatch})
+		}
+
+		if exercise.GroupID != nil {
+			groupPositions[*exercise.GroupID] = append(groupPositions[*exercise.GroupID], position)
+		}
+	}
+
+	for _, positions := range groupPositions {
+		if !workoutexercise.IsContiguous(positions) {
+			return domainerr.InvalidRequestBody(&response.ErrorDetails{workout.ErrorReorderSplitsGroup})
+		}
+	}
+
+	return nil
+}
+
+// DuplicateWorkout copies a workout of the user, with its groups, exercises
+// and set prescriptions, into a new workout of the user.
+func (s *Service) DuplicateWorkout(ctx context.Context, userEmail string, workoutID uuid.UUID, params workout.CopyWorkoutRequest) (*workout.Model, error) {
+	source, err := s.GetWorkout(ctx, userEmail, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.copyWorkout(ctx, source, source.UserID, params)
+}
+
+func (s *Service) CreateShareLink(ctx context.Context, userEmail string, workoutID uuid.UUID) (*workout.ShareLinkModel, error) {
+	workoutModel, err := s.GetWorkout(ctx, userEmail, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := workout.NewShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link := &workout.ShareLinkModel{
+		WorkoutID: workoutModel.ID,
+		UserID:    workoutModel.UserID,
+		Token:     token,
+	}
+
+	if err := s.workoutRepo.CreateShareLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func (s *Service) ListShareLinks(ctx context.Context, userEmail string, workoutID uuid.UUID) ([]*workout.ShareLinkModel, error) {
+	if _, err := s.GetWorkout(ctx, userEmail, workoutID); err != nil {
+		return nil, err
+	}
+
+	return s.workoutRepo.GetShareLinks(ctx, workoutID)
+}
+
+func (s *Service) RevokeShareLink(ctx context.Context, userEmail string, workoutID uuid.UUID, linkID uuid.UUID) error {
+	if _, err := s.GetWorkout(ctx, userEmail, workoutID); err != nil {
+		return err
+	}
+
+	return s.workoutRepo.DeleteShareLink(ctx, workoutID, linkID)
+}
+
+// GetSharedWorkout returns the workout behind an active share link.
+func (s *Service) GetSharedWorkout(ctx context.Context, token string) (*workout.Model, error) {
+	link, err := s.workoutRepo.GetShareLinkByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, ErrSharedWorkoutNotFound.Wrap(err)
+		}
+
+		return nil, err
+	}
+
+	workoutModel, err := s.workoutRepo.GetByIDWithRelations(ctx, link.WorkoutID)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, ErrSharedWorkoutNotFound.Wrap(err)
+		}
+
+		return nil, err
+	}
+
+	return workoutModel, nil
+}
+
+// ImportSharedWorkout copies the workout behind a share link into the
+// account of the user.
+func (s *Service) ImportSharedWorkout(ctx context.Context, userEmail string, token string, params workout.CopyWorkoutRequest) (*workout.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.GetSharedWorkout(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.copyWorkout(ctx, source, userModel.ID, params)
+}
+
+func (s *Service) copyWorkout(ctx context.Context, source *workout.Model, userID uuid.UUID, params workout.CopyWorkoutRequest) (*workout.Model, error) {
+	workoutModel := workout.Model{
+		UserID: userID,
+		Name:   source.CopyName(),
+	}
+
+	if params.Name != nil {
+		workoutModel.Name = *params.Name
+	}
+
+	err := s.workoutRepo.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.workoutRepo.Create(txCtx, &workoutModel); err != nil {
+			return err
+		}
+
+		return s.copyWorkoutExercises(txCtx, workoutModel.ID, source.WorkoutExercises)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.workoutRepo.GetByIDWithRelations(ctx, workoutModel.ID)
+}
+
+func (s *Service) copyWorkoutExercises(ctx context.Context, workoutID uuid.UUID, sources []*workoutexercise.Model) error {
+	groupIDs := make(map[uuid.UUID]*uuid.UUID)
+	var groups []*workoutexercise.GroupModel
+
+	for _, source := range sources {
+		if source.Group == nil {
+			continue
+		}
+
+		if _, ok := groupIDs[source.Group.ID]; ok {
+			continue
+		}
+
+		group := &workoutexercise.GroupModel{
+			WorkoutID: workoutID,
+			Type:      source.Group.Type,
+			TimeCap:   source.Group.TimeCap,
+		}
+		groups = append(groups, group)
+		groupIDs[source.Group.ID] = &group.ID
+	}
+
+	if len(groups) > 0 {
+		if err := s.workoutRepo.CreateWorkoutExerciseGroups(ctx, groups); err != nil {
+			return err
+		}
+	}
+
+	if len(sources) == 0 {
+		return nil
+	}
+
+	workoutExercises := make([]*workoutexercise.Model, len(sources))
+	for index, source := range sources {
+		var groupID *uuid.UUID
+		if source.GroupID != nil {
+			groupID = groupIDs[*source.GroupID]
+		}
+
+		workoutExercises[index] = &workoutexercise.Model{
+			WorkoutID:   workoutID,
+			ExerciseID:  source.ExerciseID,
+			Position:    source.Position,
+			GroupID:     groupID,
+			Sets:        source.Sets,
+			Repetitions: source.Repetitions,
+			Weight:      source.Weight,
+			Duration:    source.Duration,
+			RestTime:    source.RestTime,
+			Notes:       source.Notes,
+		}
+	}
+
+	if err := s.workoutRepo.CreateWorkoutExercises(ctx, workoutExercises); err != nil {
+		return err
+	}
+
+	var sets []*workoutexercise.SetModel
+	for index, source := range sources {
+		for _, set := range source.SetPrescriptions {
+			copied := *set
+			copied.WorkoutExerciseID = workoutExercises[index].ID
+			sets = append(sets, &copied)
+		}
+	}
+
+	return s.workoutRepo.CreateWorkoutExerciseSets(ctx, sets)
+}
+
+func (s *Service) RestoreWorkout(ctx context.Context, userEmail string, workoutID uuid.UUID) (*workout.Model, error) {
+	userModel, err := s.userRepo.FindByEmail(ctx, userEmail)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.workoutRepo.GetWorkoutExercise(ctx, workoutID, workoutExerciseID)
+	if err := s.workoutRepo.Restore(ctx, userModel.ID, workoutID); err != nil {
+		if errors.Is(err, domainerr.ErrNotFound) {
+			return nil, domainerr.NotFound("Workout not found in trash").Wrap(err)
+		}
+
+		return nil, err
+	}
+
+	return s.workoutRepo.GetByIDWithRelations(ctx, workoutID)
 }
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/pubsub"
+)
+
+// subscriberBuffer is the number of messages a subscriber can fall behind
+// before it misses messages.
+const subscriberBuffer = 64
+
+type (
+	// Hub is an in-process pubsub.Hub. It only reaches the subscribers of the
+	// same instance, so running several instances needs a hub backed by a
+	// shared broker instead.
+	Hub struct {
+		mu     sync.Mutex
+		topics map[string]map[chan []byte]struct{}
+	}
+)
+
+var _ pubsub.Hub = (*Hub)(nil)
+
+func NewHub() *Hub {
+	return &Hub{
+		topics: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+// Publish delivers message to every subscriber of topic. Subscribers that
+// fell too far behind miss it rather than holding up the publisher.
+func (h *Hub) Publish(ctx context.Context, topic string, message []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscriber := range h.topics[topic] {
+		select {
+		case subscriber <- message:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (h *Hub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	subscriber := make(chan []byte, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[chan []byte]struct{})
+	}
+	h.topics[topic][subscriber] = struct{}{}
+	h.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.topics[topic], subscriber)
+		if len(h.topics[topic]) == 0 {
+			delete(h.topics, topic)
+		}
+
+		close(subscriber)
+	})
+
+	return subscriber, nil
+}
@@ -0,0 +1,69 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/adapters/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should deliver messages to the subscribers of the topic", func(t *testing.T) {
+		t.Parallel()
+
+		hub := memory.NewHub()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		first, err := hub.Subscribe(ctx, "sessions/1")
+		assert.Nil(t, err)
+
+		second, err := hub.Subscribe(ctx, "sessions/1")
+		assert.Nil(t, err)
+
+		other, err := hub.Subscribe(ctx, "sessions/2")
+		assert.Nil(t, err)
+
+		assert.Nil(t, hub.Publish(ctx, "sessions/1", []byte("set_logged")))
+
+		assert.Equal(t, []byte("set_logged"), <-first)
+		assert.Equal(t, []byte("set_logged"), <-second)
+		assert.Empty(t, other)
+	})
+
+	t.Run("should close the subscription once its context is done", func(t *testing.T) {
+		t.Parallel()
+
+		hub := memory.NewHub()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		messages, err := hub.Subscribe(ctx, "sessions/1")
+		assert.Nil(t, err)
+
+		cancel()
+
+		_, open := <-messages
+		assert.False(t, open)
+		assert.Nil(t, hub.Publish(context.Background(), "sessions/1", []byte("set_logged")))
+	})
+
+	t.Run("should not block on subscribers that fell behind", func(t *testing.T) {
+		t.Parallel()
+
+		hub := memory.NewHub()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages, err := hub.Subscribe(ctx, "sessions/1")
+		assert.Nil(t, err)
+
+		for range 100 {
+			assert.Nil(t, hub.Publish(ctx, "sessions/1", []byte("set_logged")))
+		}
+
+		assert.Equal(t, 64, len(messages))
+	})
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/lib/pq"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	exercisesNameIndex       = "idx_exercises_lower_name"
+	customExercisesNameIndex = "idx_exercises_user_id_lower_name"
+	muscleGroupsNameIndex    = "idx_muscle_groups_lower_name"
+	usersEmailIndex          = "idx_users_email"
+
+	sessionsScheduledWorkoutIndex = "idx_workout_history_scheduled_workout_id"
+
+	// uniqueViolationCode is the SQLSTATE of unique_violation errors
+	uniqueViolationCode = "23505"
+)
+
+// translateUniqueViolation wraps violations of the given unique indexes
+// with repo.ErrUniqueViolation.
+func translateUniqueViolation(err error, indexes ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	if index := violatedUniqueIndex(err); slices.Contains(indexes, index) {
+		return fmt.Errorf("%w: %s", repo.ErrUniqueViolation, index)
+	}
+
+	return err
+}
+
+// violatedUniqueIndex returns the name of the unique index a unique
+// violation was raised on, or an empty string for other errors. The app
+// connects through pgdriver and the tests through lib/pq, so the errors of
+// both drivers are read.
+func violatedUniqueIndex(err error) string {
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) && pgErr.Field('C') == uniqueViolationCode {
+		return pgErr.Field('n')
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return pqErr.Constraint
+	}
+
+	return ""
+}
+
+// translateNotFound turns sql.ErrNoRows into a not found domain error for the
+// given resource, keeping sql.ErrNoRows in the chain for errors.Is.
+func translateNotFound(err error, resource string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return domainerr.NotFound(resource + " not found").Wrap(err)
+	}
+
+	return err
+}
@@ -2,9 +2,14 @@ package postgres
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exerciseprogress"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -25,75 +30,259 @@ func NewExerciseRepository(db *bun.DB) ExerciseRepository {
 }
 
 func (r *ExerciseRepository) Create(ctx context.Context, model *exercise.Model) error {
-	_, err := r.GetDB().NewInsert().Model(model).Exec(ctx)
-	return err
+	_, err := r.Conn(ctx).NewInsert().Model(model).Exec(ctx)
+	return translateUniqueViolation(err, exercisesNameIndex, customExercisesNameIndex)
 }
 
 func (r *ExerciseRepository) CreateExerciseMuscleGroupAssociations(ctx context.Context, associations []*exercise.ExerciseMuscleGroupModel) error {
-	_, err := r.GetDB().NewInsert().Model(&associations).Exec(ctx)
+	if len(associations) == 0 {
+		return nil
+	}
+
+	_, err := r.Conn(ctx).NewInsert().Model(&associations).Exec(ctx)
 	return err
 }
 
+// GetByID returns an exercise of the global catalog.
 func (r *ExerciseRepository) GetByID(ctx context.Context, id uuid.UUID) (*exercise.Model, error) {
 	model := &exercise.Model{}
-	err := r.GetDB().NewSelect().Model(model).Relation("MuscleGroups").Where("id = ?", id).Scan(ctx)
-	return model, err
+	err := r.Conn(ctx).NewSelect().Model(model).Relation("MuscleGroups").Relation("Aliases").Where("id = ? AND user_id IS NULL", id).Scan(ctx)
+	return model, translateNotFound(err, "Exercise")
+}
+
+// GetForUser returns an exercise the user can add to workouts: one of the
+// global catalog or of the user's own.
+func (r *ExerciseRepository) GetForUser(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*exercise.Model, error) {
+	model := &exercise.Model{}
+	err := r.Conn(ctx).NewSelect().Model(model).Where("id = ? AND (user_id IS NULL OR user_id = ?)", id, userID).Scan(ctx)
+	return model, translateNotFound(err, "Exercise")
 }
 
-func (r *ExerciseRepository) GetPaginated(ctx context.Context, params exercise.ListExercisesQueryParams) ([]*exercise.Model, int, error) {
+func (r *ExerciseRepository) GetPaginated(ctx context.Context, params exercise.ListExercisesQueryParams) ([]*exercise.Model, base.PageInfo, error) {
 	var models []*exercise.Model
-	limit := params.PerPage
-	offset := (params.Page - 1) * params.PerPage
 
-	subQuery := r.GetDB().NewSelect().
+	subQuery := r.Conn(ctx).NewSelect().
 		ColumnExpr("emg.exercise_id").
 		TableExpr("muscle_groups as mg").
-		Join("LEFT JOIN exercise_muscle_groups emg ON mg.id = emg.muscle_group_id")
+		Join("LEFT JOIN exercise_muscle_groups emg ON mg.id = emg.muscle_group_id").
+		Where("mg.deleted_at IS NULL")
 
 	if len(params.MuscleGroupNames) > 0 {
 		subQuery.Where("mg.name IN (?)", bun.In(params.MuscleGroupNames))
 	}
 
-	query := r.GetDB().NewSelect().
+	query := r.Conn(ctx).NewSelect().
 		Model(&models).
-		Relation("MuscleGroups").
-		Limit(limit).
-		Offset(offset)
+		Where("?TableAlias.user_id IS NULL")
+
+	expansions := params.Expansions(exercise.DefaultExpansions...)
+
+	if slices.Contains(expansions, exercise.ExpandMuscleGroups) {
+		query.Relation("MuscleGroups")
+	}
+
+	if slices.Contains(expansions, exercise.ExpandAliases) {
+		query.Relation("Aliases")
+	}
+
+	orders := applySort(query, params.SortOrders())
+	applyFields(query, params.FieldList(), orders)
 
 	if len(params.MuscleGroupNames) > 0 {
 		query.Where("id IN (?)", subQuery)
 	}
 
 	if params.Name != "" {
-		query.Where("name ILIKE ?", "%"+params.Name+"%")
+		aliasQuery := r.Conn(ctx).NewSelect().
+			Model((*exercise.AliasModel)(nil)).
+			Column("exercise_id").
+			Where("name ILIKE ?", "%"+params.Name+"%")
+
+		query.Where("name ILIKE ? OR id IN (?)", "%"+params.Name+"%", aliasQuery)
 	}
 
-	err := query.Scan(ctx)
+	info, err := paginate(ctx, query, params.ListQueryParams, orders, &models)
 	if err != nil {
-		return nil, 0, err
+		return nil, info, err
 	}
 
-	total, err := query.Count(ctx)
-
-	return models, total, err
+	return models, info, nil
 }
 
-func (r *ExerciseRepository) Update(ctx context.Context, id uuid.UUID, model *exercise.Model) error {
-	_, err := r.GetDB().NewUpdate().Model(model).Where("id = ?", id).Exec(ctx)
-	return err
+// Update saves the exercise and increments its version, unless the version
+// was changed since the model was read.
+func (r *ExerciseRepository) Update(ctx context.Context, id uuid.UUID, model *exercise.Model, columns ...string) error {
+	version := model.Version
+	model.Version++
+
+	query := r.Conn(ctx).NewUpdate().Model(model).Where("id = ? AND version = ?", id, version)
+	result, err := updateColumns(query, columns, "version").Exec(ctx)
+	if err != nil {
+		model.Version = version
+		return translateUniqueViolation(err, exercisesNameIndex, customExercisesNameIndex)
+	}
+
+	if err := checkVersion(result); err != nil {
+		model.Version = version
+		return err
+	}
+
+	return nil
 }
 
 func (r *ExerciseRepository) UpdateExerciseMuscleGroupAssociations(ctx context.Context, exerciseID uuid.UUID, associations []*exercise.ExerciseMuscleGroupModel) error {
-	_, err := r.GetDB().NewDelete().Model(&exercise.ExerciseMuscleGroupModel{}).Where("exercise_id = ?", exerciseID).Exec(ctx)
+	_, err := r.Conn(ctx).NewDelete().Model(&exercise.ExerciseMuscleGroupModel{}).Where("exercise_id = ?", exerciseID).Exec(ctx)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.GetDB().NewInsert().Model(&associations).Exec(ctx)
+	if len(associations) == 0 {
+		return nil
+	}
+
+	_, err = r.Conn(ctx).NewInsert().Model(&associations).Exec(ctx)
 	return err
 }
 
+// GetUsage counts the references to the exercise, including the ones from
+// workouts in the trash, which would be lost with it when the trash is purged.
+// The exercise is locked, so inside a transaction no reference can be added
+// until it ends.
+func (r *ExerciseRepository) GetUsage(ctx context.Context, id uuid.UUID) (*exercise.Usage, error) {
+	usage := &exercise.Usage{}
+
+	err := r.Conn(ctx).NewSelect().
+		Model((*exercise.Model)(nil)).
+		Column("id").
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx, &id)
+	if err != nil {
+		return nil, translateNotFound(err, "Exercise")
+	}
+
+	err = r.Conn(ctx).NewSelect().
+		ColumnExpr("COUNT(DISTINCT we.workout_id)").
+		TableExpr("workout_exercises AS we").
+		Where("we.exercise_id = ?", id).
+		Scan(ctx, &usage.Workouts)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.ProgressLogs, err = r.Conn(ctx).NewSelect().
+		Model((*exerciseprogress.Model)(nil)).
+		Where("exercise_id = ?", id).
+		Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (r *ExerciseRepository) ReplaceReferences(ctx context.Context, id uuid.UUID, replacementID uuid.UUID) (*exercise.MovedReferences, error) {
+	moved := &exercise.MovedReferences{}
+
+	result, err := r.Conn(ctx).NewUpdate().
+		Model((*workoutexercise.Model)(nil)).
+		Set("exercise_id = ?", replacementID).
+		Where("exercise_id = ?", id).
+		WhereAllWithDeleted().
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if moved.WorkoutExercises, err = countRowsAffected(result); err != nil {
+		return nil, err
+	}
+
+	result, err = r.Conn(ctx).NewUpdate().
+		Model((*exerciseprogress.Model)(nil)).
+		Set("exercise_id = ?", replacementID).
+		Where("exercise_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if moved.ProgressLogs, err = countRowsAffected(result); err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+func (r *ExerciseRepository) MoveMuscleGroupAssociations(ctx context.Context, id uuid.UUID, targetID uuid.UUID) (int, error) {
+	associations := r.Conn(ctx).NewSelect().
+		Model((*exercise.ExerciseMuscleGroupModel)(nil)).
+		ColumnExpr("?::uuid AS exercise_id", targetID).
+		Column("muscle_group_id").
+		Where("exercise_id = ?", id)
+
+	result, err := r.Conn(ctx).NewInsert().
+		Model((*exercise.ExerciseMuscleGroupModel)(nil)).
+		Column("exercise_id", "muscle_group_id").
+		TableExpr("(?) AS associations", associations).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = r.Conn(ctx).NewDelete().
+		Model((*exercise.ExerciseMuscleGroupModel)(nil)).
+		Where("exercise_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return countRowsAffected(result)
+}
+
+func (r *ExerciseRepository) CreateAliases(ctx context.Context, id uuid.UUID, names []string) (int, error) {
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	aliases := make([]*exercise.AliasModel, len(names))
+	for index, name := range names {
+		aliases[index] = &exercise.AliasModel{
+			ExerciseID: id,
+			Name:       name,
+		}
+	}
+
+	result, err := r.Conn(ctx).NewInsert().Model(&aliases).On("CONFLICT DO NOTHING").Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return countRowsAffected(result)
+}
+
 func (r *ExerciseRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.GetDB().NewDelete().Model(&exercise.Model{}).Where("id = ?", id).Exec(ctx)
-	return err
+	result, err := r.Conn(ctx).NewDelete().Model(&exercise.Model{}).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Exercise")
+}
+
+func (r *ExerciseRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewUpdate().
+		Model((*exercise.Model)(nil)).
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ? AND user_id IS NULL", id).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return translateUniqueViolation(err, exercisesNameIndex)
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Exercise")
 }
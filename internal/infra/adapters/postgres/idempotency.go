@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/idempotency"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	IdempotencyRepository struct {
+		repo.BaseRepository
+	}
+)
+
+func NewIdempotencyRepository(db *bun.DB) IdempotencyRepository {
+	repo := IdempotencyRepository{}
+	repo.SetDB(db)
+
+	return repo
+}
+
+// Reserve inserts the key, replacing an expired one or one whose request
+// outlived its lock, so only one of the requests racing for the same key is
+// reported as stored.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, model *idempotency.Model) (bool, error) {
+	result, err := r.Conn(ctx).NewInsert().
+		Model(model).
+		On("CONFLICT (user_id, key) DO UPDATE").
+		Set("fingerprint = EXCLUDED.fingerprint").
+		Set("status_code = NULL").
+		Set("content_type = NULL").
+		Set("body = NULL").
+		Set("created_at = EXCLUDED.created_at").
+		Set("expires_at = EXCLUDED.expires_at").
+		Set("locked_until = EXCLUDED.locked_until").
+		Where("(idempotency_key.expires_at <= now() OR (idempotency_key.status_code IS NULL AND idempotency_key.locked_until <= now()))").
+		Returning("NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := countRowsAffected(result)
+	return rowsAffected > 0, err
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, userID uuid.UUID, key string) (*idempotency.Model, error) {
+	model := &idempotency.Model{}
+	err := r.Conn(ctx).NewSelect().
+		Model(model).
+		Where("user_id = ? AND key = ?", userID, key).
+		Scan(ctx)
+	return model, translateNotFound(err, "Idempotency key")
+}
+
+// Complete stores the response and lifts the lock. The reservation is told
+// apart by its created_at, so a request that outlived its lock does not
+// overwrite the key reserved by its retry.
+func (r *IdempotencyRepository) Complete(ctx context.Context, model *idempotency.Model) error {
+	model.LockedUntil = nil
+
+	_, err := r.Conn(ctx).NewUpdate().
+		Model(model).
+		Column("status_code", "content_type", "body", "locked_until").
+		WherePK().
+		Where("created_at = ?", model.CreatedAt).
+		Exec(ctx)
+	return err
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, model *idempotency.Model) error {
+	_, err := r.Conn(ctx).NewDelete().
+		Model(model).
+		WherePK().
+		Where("created_at = ?", model.CreatedAt).
+		Exec(ctx)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*idempotency.Model)(nil)).
+		Where("expires_at <= ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return countRowsAffected(result)
+}
@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -23,40 +26,167 @@ func NewMuscleGroupRepository(db *bun.DB) MuscleGroupRepository {
 }
 
 func (r *MuscleGroupRepository) Create(ctx context.Context, model *musclegroup.Model) error {
-	_, err := r.GetDB().NewInsert().Model(model).Exec(ctx)
-	return err
+	_, err := r.Conn(ctx).NewInsert().Model(model).Exec(ctx)
+	return translateUniqueViolation(err, muscleGroupsNameIndex)
 }
 
-func (r *MuscleGroupRepository) GetPaginated(ctx context.Context, params musclegroup.ListMuscleGroupsQueryParams) ([]*musclegroup.Model, int, error) {
+func (r *MuscleGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*musclegroup.Model, error) {
+	model := &musclegroup.Model{}
+	err := r.Conn(ctx).NewSelect().Model(model).Relation("Aliases").Where("id = ?", id).Scan(ctx)
+	return model, translateNotFound(err, "Muscle group")
+}
+
+func (r *MuscleGroupRepository) GetPaginated(ctx context.Context, params musclegroup.ListMuscleGroupsQueryParams) ([]*musclegroup.Model, base.PageInfo, error) {
 	var models []*musclegroup.Model
-	limit := params.PerPage
-	offset := (params.Page - 1) * params.PerPage
 
-	query := r.GetDB().NewSelect().
-		Model(&models).
-		Limit(limit).
-		Offset(offset)
+	query := r.Conn(ctx).NewSelect().
+		Model(&models)
+
+	orders := applySort(query, params.SortOrders())
 
 	if params.Name != "" {
-		query.Where("name ILIKE ?", "%"+params.Name+"%")
+		aliasQuery := r.Conn(ctx).NewSelect().
+			Model((*musclegroup.AliasModel)(nil)).
+			Column("muscle_group_id").
+			Where("name ILIKE ?", "%"+params.Name+"%")
+
+		query.Where("name ILIKE ? OR id IN (?)", "%"+params.Name+"%", aliasQuery)
+	}
+
+	info, err := paginate(ctx, query, params.ListQueryParams, orders, &models)
+	if err != nil {
+		return nil, info, err
 	}
 
-	err := query.Scan(ctx)
+	return models, info, nil
+}
+
+// Update saves the muscle group and increments its version, unless the
+// version was changed since the model was read.
+func (r *MuscleGroupRepository) Update(ctx context.Context, id uuid.UUID, model *musclegroup.Model, columns ...string) error {
+	version := model.Version
+	model.Version++
+
+	query := r.Conn(ctx).NewUpdate().Model(model).Where("id = ? AND version = ?", id, version)
+	result, err := updateColumns(query, columns, "version").Exec(ctx)
 	if err != nil {
-		return nil, 0, err
+		model.Version = version
+		return translateUniqueViolation(err, muscleGroupsNameIndex)
 	}
 
-	total, err := r.GetDB().NewSelect().Model(&musclegroup.Model{}).Count(ctx)
+	if err := checkVersion(result); err != nil {
+		model.Version = version
+		return err
+	}
 
-	return models, total, err
+	return nil
 }
 
-func (r *MuscleGroupRepository) Update(ctx context.Context, id uuid.UUID, model *musclegroup.Model) error {
-	_, err := r.GetDB().NewUpdate().Model(model).Where("id = ?", id).Exec(ctx)
-	return err
+// GetUsage counts the references to the muscle group, including the ones from
+// exercises and workouts in the trash, which would be lost with it when the
+// trash is purged. The muscle group is locked, so inside a transaction no
+// reference can be added until it ends.
+func (r *MuscleGroupRepository) GetUsage(ctx context.Context, id uuid.UUID) (*musclegroup.Usage, error) {
+	usage := &musclegroup.Usage{}
+
+	err := r.Conn(ctx).NewSelect().
+		Model((*musclegroup.Model)(nil)).
+		Column("id").
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx, &id)
+	if err != nil {
+		return nil, translateNotFound(err, "Muscle group")
+	}
+
+	err = r.Conn(ctx).NewSelect().
+		ColumnExpr("COUNT(DISTINCT emg.exercise_id)").
+		ColumnExpr("COUNT(DISTINCT we.workout_id)").
+		TableExpr("exercise_muscle_groups AS emg").
+		Join("LEFT JOIN workout_exercises AS we ON we.exercise_id = emg.exercise_id").
+		Where("emg.muscle_group_id = ?", id).
+		Scan(ctx, &usage.Exercises, &usage.Workouts)
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func (r *MuscleGroupRepository) ReplaceReferences(ctx context.Context, id uuid.UUID, replacementID uuid.UUID) (*musclegroup.MovedReferences, error) {
+	replacements := r.Conn(ctx).NewSelect().
+		Model((*exercise.ExerciseMuscleGroupModel)(nil)).
+		Column("exercise_id").
+		ColumnExpr("?::uuid AS muscle_group_id", replacementID).
+		Where("muscle_group_id = ?", id)
+
+	_, err := r.Conn(ctx).NewInsert().
+		Model((*exercise.ExerciseMuscleGroupModel)(nil)).
+		Column("exercise_id", "muscle_group_id").
+		TableExpr("(?) AS replacements", replacements).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*exercise.ExerciseMuscleGroupModel)(nil)).
+		Where("muscle_group_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, err := countRowsAffected(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &musclegroup.MovedReferences{Exercises: exercises}, nil
+}
+
+func (r *MuscleGroupRepository) CreateAliases(ctx context.Context, id uuid.UUID, names []string) (int, error) {
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	aliases := make([]*musclegroup.AliasModel, len(names))
+	for index, name := range names {
+		aliases[index] = &musclegroup.AliasModel{
+			MuscleGroupID: id,
+			Name:          name,
+		}
+	}
+
+	result, err := r.Conn(ctx).NewInsert().Model(&aliases).On("CONFLICT DO NOTHING").Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return countRowsAffected(result)
 }
 
 func (r *MuscleGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.GetDB().NewDelete().Model(&musclegroup.Model{}).Where("id = ?", id).Exec(ctx)
-	return err
+	result, err := r.Conn(ctx).NewDelete().Model(&musclegroup.Model{}).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Muscle group")
+}
+
+func (r *MuscleGroupRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewUpdate().
+		Model((*musclegroup.Model)(nil)).
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return translateUniqueViolation(err, muscleGroupsNameIndex)
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Muscle group")
 }
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	SyncRepository struct {
+		repo.BaseRepository
+	}
+
+	// changeFilter restricts a change set to the records changed since a
+	// time, to some records, or both. Tombstones are only read for
+	// restricted change sets, since a full one replaces the client copy.
+	changeFilter struct {
+		since *time.Time
+		ids   []uuid.UUID
+	}
+)
+
+func NewSyncRepository(db *bun.DB) SyncRepository {
+	repo := SyncRepository{}
+	repo.SetDB(db)
+
+	return repo
+}
+
+func (r *SyncRepository) GetChanges(ctx context.Context, userID uuid.UUID, since *time.Time) (*offlinesync.ChangeSet, error) {
+	return r.getChangeSet(ctx, userID, changeFilter{since: since})
+}
+
+func (r *SyncRepository) GetRecords(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (*offlinesync.ChangeSet, error) {
+	if len(ids) == 0 {
+		return &offlinesync.ChangeSet{}, nil
+	}
+
+	return r.getChangeSet(ctx, userID, changeFilter{ids: ids})
+}
+
+func (r *SyncRepository) GetWorkout(ctx context.Context, id uuid.UUID) (*workout.Model, error) {
+	model := &workout.Model{}
+	err := r.Conn(ctx).NewSelect().Model(model).WhereAllWithDeleted().Where("id = ?", id).Scan(ctx)
+	return model, translateNotFound(err, "Workout")
+}
+
+func (r *SyncRepository) GetSession(ctx context.Context, id uuid.UUID) (*workouthistory.Model, error) {
+	model := &workouthistory.Model{}
+	err := r.Conn(ctx).NewSelect().Model(model).WhereAllWithDeleted().Where("id = ?", id).Scan(ctx)
+	return model, translateNotFound(err, "Session")
+}
+
+func (r *SyncRepository) GetExercise(ctx context.Context, id uuid.UUID) (*exercise.Model, error) {
+	model := &exercise.Model{}
+	err := r.Conn(ctx).NewSelect().Model(model).WhereAllWithDeleted().Where("id = ?", id).Scan(ctx)
+	return model, translateNotFound(err, "Exercise")
+}
+
+// CreateWorkout, CreateSession and CreateExercise insert records created
+// offline with the id the client gave them, in place of the one generated on
+// insert.
+func (r *SyncRepository) CreateWorkout(ctx context.Context, model *workout.Model) error {
+	return r.insertWithID(ctx, model, &model.ID)
+}
+
+func (r *SyncRepository) CreateSession(ctx context.Context, model *workouthistory.Model) error {
+	return r.insertWithID(ctx, model, &model.ID)
+}
+
+func (r *SyncRepository) CreateExercise(ctx context.Context, model *exercise.Model) error {
+	return translateUniqueViolation(r.insertWithID(ctx, model, &model.ID), customExercisesNameIndex)
+}
+
+func (r *SyncRepository) insertWithID(ctx context.Context, model any, id *uuid.UUID) error {
+	clientID := *id
+
+	_, err := r.Conn(ctx).NewInsert().Model(model).Value("id", "?", clientID).Exec(ctx)
+	*id = clientID
+
+	return err
+}
+
+func (r *SyncRepository) getChangeSet(ctx context.Context, userID uuid.UUID, filter changeFilter) (*offlinesync.ChangeSet, error) {
+	set := &offlinesync.ChangeSet{}
+
+	err := filter.apply(r.Conn(ctx).NewSelect().
+		Model(&set.Workouts).
+		Relation("WorkoutExercises.Exercise").
+		Relation("WorkoutExercises.Group").
+		Relation("WorkoutExercises.SetPrescriptions", orderSetPrescriptions).
+		Where("?TableAlias.user_id = ?", userID).
+		OrderExpr("?TableAlias.updated_at"), "updated_at").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, model := range set.Workouts {
+		model.Arrange()
+	}
+
+	err = filter.apply(r.Conn(ctx).NewSelect().
+		Model(&set.Sessions).
+		Where("?TableAlias.user_id = ?", userID).
+		OrderExpr("?TableAlias.updated_at"), "updated_at").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = filter.apply(r.Conn(ctx).NewSelect().
+		Model(&set.Exercises).
+		Relation("MuscleGroups").
+		Relation("Aliases").
+		Where("?TableAlias.user_id IS NULL OR ?TableAlias.user_id = ?", userID).
+		OrderExpr("?TableAlias.updated_at"), "updated_at").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.since == nil && filter.ids == nil {
+		return set, nil
+	}
+
+	set.Tombstones, err = r.getTombstones(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (r *SyncRepository) getTombstones(ctx context.Context, userID uuid.UUID, filter changeFilter) ([]*offlinesync.Tombstone, error) {
+	var tombstones []*offlinesync.Tombstone
+
+	workouts := filter.apply(r.Conn(ctx).NewSelect().
+		Model((*workout.Model)(nil)).
+		ColumnExpr("id, deleted_at").
+		ColumnExpr("?::text AS type", offlinesync.TypeWorkout).
+		Where("user_id = ?", userID).
+		WhereDeleted(), "deleted_at")
+
+	sessions := filter.apply(r.Conn(ctx).NewSelect().
+		Model((*workouthistory.Model)(nil)).
+		ColumnExpr("id, deleted_at").
+		ColumnExpr("?::text AS type", offlinesync.TypeSession).
+		Where("user_id = ?", userID).
+		WhereDeleted(), "deleted_at")
+
+	exercises := filter.apply(r.Conn(ctx).NewSelect().
+		Model((*exercise.Model)(nil)).
+		ColumnExpr("id, deleted_at").
+		ColumnExpr("?::text AS type", offlinesync.TypeExercise).
+		Where("user_id IS NULL OR user_id = ?", userID).
+		WhereDeleted(), "deleted_at")
+
+	union := workouts.UnionAll(sessions).UnionAll(exercises)
+
+	err := r.Conn(ctx).NewSelect().
+		TableExpr("(?) AS tombstones", union).
+		Order("deleted_at").
+		Scan(ctx, &tombstones)
+
+	return tombstones, err
+}
+
+// apply restricts query by the filter, reading the change time of its
+// records from column.
+func (f changeFilter) apply(query *bun.SelectQuery, column string) *bun.SelectQuery {
+	if f.since != nil {
+		query.Where("?TableAlias.? > ?", bun.Ident(column), *f.since)
+	}
+
+	if f.ids != nil {
+		query.Where("?TableAlias.id IN (?)", bun.In(f.ids))
+	}
+
+	return query
+}
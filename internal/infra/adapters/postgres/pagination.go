@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/uptrace/bun"
+)
+
+// paginate scans the page of query selected by params into models. Pages
+// after a cursor start right after the row it was taken from, other pages
+// at their offset; one extra row is read to tell whether a next page
+// exists. orders must be the ones returned by applySort for query.
+func paginate[T any](
+	ctx context.Context,
+	query *bun.SelectQuery,
+	params base.ListQueryParams,
+	orders []base.SortOrder,
+	models *[]*T,
+) (base.PageInfo, error) {
+	var info base.PageInfo
+
+	if !params.SkipCount {
+		total, err := query.Count(ctx)
+		if err != nil {
+			return info, err
+		}
+
+		info.Total = &total
+	}
+
+	sort := base.FormatSort(orders)
+
+	if params.Cursor != "" {
+		cursor, err := base.DecodeCursor(params.Cursor)
+		if err != nil || cursor.Sort != sort || len(cursor.Values) != len(orders) {
+			return info, domainerr.InvalidQueryParams(&response.ErrorDetails{base.ErrorCursorIsInvalid})
+		}
+
+		expr, args := keysetExpr(orders, cursor.Values)
+		query.Where(expr, args...)
+	} else {
+		query.Offset((params.Page - 1) * params.PerPage)
+	}
+
+	if err := query.Limit(params.PerPage + 1).Scan(ctx); err != nil {
+		return info, err
+	}
+
+	if len(*models) <= params.PerPage {
+		return info, nil
+	}
+
+	*models = (*models)[:params.PerPage]
+
+	nextCursor, err := base.EncodeCursor(base.Cursor{
+		Sort:   sort,
+		Values: sortKey(query.DB(), (*models)[params.PerPage-1], orders),
+	})
+	info.NextCursor = nextCursor
+
+	return info, err
+}
+
+// keysetExpr selects the rows sorted after the row with the given sort key:
+// rows equal on the first fields and after it on the next one. NULLs sort
+// as Postgres does by default, last in ascending and first in descending
+// order.
+func keysetExpr(orders []base.SortOrder, values []any) (string, []any) {
+	var (
+		alternatives []string
+		args         []any
+	)
+
+	for i, order := range orders {
+		var conditions []string
+
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				conditions = append(conditions, "? IS NULL")
+				args = append(args, bun.Ident(orders[j].Field))
+			} else {
+				conditions = append(conditions, "? = ?")
+				args = append(args, bun.Ident(orders[j].Field), values[j])
+			}
+		}
+
+		switch {
+		case values[i] == nil && order.Descending:
+			conditions = append(conditions, "? IS NOT NULL")
+			args = append(args, bun.Ident(order.Field))
+		case values[i] == nil:
+			// Nothing sorts after NULL in ascending order.
+			continue
+		case order.Descending:
+			conditions = append(conditions, "? < ?")
+			args = append(args, bun.Ident(order.Field), values[i])
+		default:
+			conditions = append(conditions, "(? > ? OR ? IS NULL)")
+			args = append(args, bun.Ident(order.Field), values[i], bun.Ident(order.Field))
+		}
+
+		alternatives = append(alternatives, "("+strings.Join(conditions, " AND ")+")")
+	}
+
+	if len(alternatives) == 0 {
+		return "FALSE", nil
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// sortKey reads the values of the sort fields of model.
+func sortKey(db *bun.DB, model any, orders []base.SortOrder) []any {
+	strct := reflect.Indirect(reflect.ValueOf(model))
+	table := db.Table(strct.Type())
+
+	values := make([]any, len(orders))
+	for i, order := range orders {
+		value := table.LookupField(order.Field).Value(strct)
+		if value.Kind() == reflect.Pointer && value.IsNil() {
+			continue
+		}
+
+		values[i] = value.Interface()
+	}
+
+	return values
+}
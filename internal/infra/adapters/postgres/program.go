@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exerciseprogress"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	ProgramRepository struct {
+		repo.BaseRepository
+	}
+
+	oneRepMaxRow struct {
+		ExerciseID uuid.UUID `bun:"exercise_id"`
+		OneRepMax  float64   `bun:"one_rep_max"`
+	}
+)
+
+func NewProgramRepository(db *bun.DB) ProgramRepository {
+	repo := ProgramRepository{}
+	repo.SetDB(db)
+
+	return repo
+}
+
+func (r *ProgramRepository) Create(ctx context.Context, model *program.Model) error {
+	_, err := r.Conn(ctx).NewInsert().Model(model).Exec(ctx)
+	return err
+}
+
+func (r *ProgramRepository) CreateWeeks(ctx context.Context, weeks []*program.WeekModel) error {
+	_, err := r.Conn(ctx).NewInsert().Model(&weeks).Exec(ctx)
+	return err
+}
+
+func (r *ProgramRepository) CreateDays(ctx context.Context, days []*program.DayModel) error {
+	if len(days) == 0 {
+		return nil
+	}
+
+	_, err := r.Conn(ctx).NewInsert().Model(&days).Exec(ctx)
+	return err
+}
+
+// GetByID returns a program of the user with its weeks and days in order.
+func (r *ProgramRepository) GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*program.Model, error) {
+	model := &program.Model{}
+	err := r.Conn(ctx).NewSelect().
+		Model(model).
+		Relation("Weeks", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("program_week.week_number ASC")
+		}).
+		Relation("Weeks.Days", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("program_day.day_number ASC")
+		}).
+		Relation("Weeks.Days.Workout").
+		Where("program.id = ? AND program.user_id = ?", id, userID).
+		Scan(ctx)
+	return model, translateNotFound(err, "Program")
+}
+
+func (r *ProgramRepository) GetPaginated(ctx context.Context, userID uuid.UUID, params program.ListProgramsQueryParams) ([]*program.Model, int, error) {
+	var models []*program.Model
+	limit := params.PerPage
+	offset := (params.Page - 1) * params.PerPage
+
+	query := r.Conn(ctx).NewSelect().
+		Model(&models).
+		Where("user_id = ?", userID).
+		Limit(limit).
+		Offset(offset)
+
+	applySort(query, params.SortOrders(), base.SortOrder{Field: "created_at", Descending: true})
+
+	err := query.Scan(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := query.Count(ctx)
+
+	return models, total, err
+}
+
+func (r *ProgramRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*program.Model)(nil)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Program")
+}
+
+func (r *ProgramRepository) CreateEnrollment(ctx context.Context, model *program.EnrollmentModel) error {
+	_, err := r.Conn(ctx).NewInsert().Model(model).Exec(ctx)
+	return err
+}
+
+// GetActiveEnrollment returns the enrollment the user currently follows,
+// with its program, weeks and days. Enrollments in deleted programs are
+// ignored.
+func (r *ProgramRepository) GetActiveEnrollment(ctx context.Context, userID uuid.UUID) (*program.EnrollmentModel, error) {
+	model := &program.EnrollmentModel{}
+	err := r.Conn(ctx).NewSelect().
+		Model(model).
+		Relation("Program").
+		Relation("Program.Weeks").
+		Relation("Program.Weeks.Days").
+		Where("program_enrollment.user_id = ?", userID).
+		Where("program.id IS NOT NULL").
+		Scan(ctx)
+	return model, translateNotFound(err, "Program enrollment")
+}
+
+// EndEnrollments soft deletes the active enrollments of the user.
+func (r *ProgramRepository) EndEnrollments(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.Conn(ctx).NewDelete().
+		Model((*program.EnrollmentModel)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+	return err
+}
+
+// GetEstimatedOneRepMaxes returns, for each exercise with progress records,
+// the best one rep max estimated with the Epley formula.
+func (r *ProgramRepository) GetEstimatedOneRepMaxes(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	oneRepMaxes := make(map[uuid.UUID]float64)
+	if len(exerciseIDs) == 0 {
+		return oneRepMaxes, nil
+	}
+
+	var rows []oneRepMaxRow
+	err := r.Conn(ctx).NewSelect().
+		Model((*exerciseprogress.Model)(nil)).
+		Column("exercise_id").
+		ColumnExpr("MAX(CASE WHEN COALESCE(repetitions, 1) <= 1 THEN weight ELSE weight * (1 + repetitions / 30.0) END) AS one_rep_max").
+		Where("user_id = ?", userID).
+		Where("exercise_id IN (?)", bun.In(exerciseIDs)).
+		Where("weight > 0").
+		Group("exercise_id").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		oneRepMaxes[row.ExerciseID] = row.OneRepMax
+	}
+
+	return oneRepMaxes, nil
+}
@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"slices"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/uptrace/bun"
+)
+
+// applyFields limits query to the requested fields, plus the primary key
+// relations are loaded by and the sort fields cursors are taken from. All
+// columns are selected when no fields are requested.
+func applyFields(query *bun.SelectQuery, fields []string, orders []base.SortOrder) {
+	if len(fields) == 0 {
+		return
+	}
+
+	columns := slices.Clone(fields)
+	for _, order := range orders {
+		if !slices.Contains(columns, order.Field) {
+			columns = append(columns, order.Field)
+		}
+	}
+
+	query.Column(columns...)
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/uptrace/bun"
+)
+
+func checkRowsAffected(result sql.Result) error {
+	rowsAffected, err := countRowsAffected(result)
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func countRowsAffected(result sql.Result) (int, error) {
+	rowsAffected, err := result.RowsAffected()
+	return int(rowsAffected), err
+}
+
+// checkVersion reports repo.ErrVersionConflict when an update guarded by the
+// version it was based on matched no row.
+func checkVersion(result sql.Result) error {
+	err := checkRowsAffected(result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return repo.ErrVersionConflict
+	}
+
+	return err
+}
+
+// updateColumns restricts an update to columns, plus updated_at and the
+// tracking columns that change on every update. Without columns, every
+// column of the model is saved.
+func updateColumns(query *bun.UpdateQuery, columns []string, tracking ...string) *bun.UpdateQuery {
+	if len(columns) == 0 {
+		return query
+	}
+
+	for _, column := range append([]string{"updated_at"}, tracking...) {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+
+	return query.Column(columns...)
+}
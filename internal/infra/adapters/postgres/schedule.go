@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	ScheduleRepository struct {
+		repo.BaseRepository
+	}
+)
+
+func NewScheduleRepository(db *bun.DB) ScheduleRepository {
+	repo := ScheduleRepository{}
+	repo.SetDB(db)
+
+	return repo
+}
+
+func (r *ScheduleRepository) Create(ctx context.Context, models []*schedule.Model) error {
+	_, err := r.Conn(ctx).NewInsert().Model(&models).Exec(ctx)
+	return err
+}
+
+func (r *ScheduleRepository) GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*schedule.Model, error) {
+	model := &schedule.Model{}
+	err := r.Conn(ctx).NewSelect().
+		Model(model).
+		Relation("Session").
+		Where("scheduled_workout.id = ? AND scheduled_workout.user_id = ?", id, userID).
+		Scan(ctx)
+	return model, translateNotFound(err, "Scheduled workout")
+}
+
+// GetByPeriod returns the scheduled workouts between from and to, both
+// included, with their workout template and session. Schedules of deleted
+// templates are left out.
+func (r *ScheduleRepository) GetByPeriod(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time) ([]*schedule.Model, error) {
+	var models []*schedule.Model
+
+	err := r.Conn(ctx).NewSelect().
+		Model(&models).
+		Relation("Workout").
+		Relation("Session").
+		Where("scheduled_workout.user_id = ?", userID).
+		Where("scheduled_workout.scheduled_for BETWEEN ?::date AND ?::date",
+			from.Format(validation.DateLayout), to.Format(validation.DateLayout)).
+		Where("workout.id IS NOT NULL").
+		Order("scheduled_workout.scheduled_for ASC", "scheduled_workout.created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (r *ScheduleRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*schedule.Model)(nil)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Scheduled workout")
+}
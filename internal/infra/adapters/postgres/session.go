@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	SessionRepository struct {
+		repo.BaseRepository
+	}
+)
+
+func NewSessionRepository(db *bun.DB) SessionRepository {
+	repo := SessionRepository{}
+	repo.SetDB(db)
+
+	return repo
+}
+
+func (r *SessionRepository) Create(ctx context.Context, model *workouthistory.Model) error {
+	_, err := r.Conn(ctx).NewInsert().Model(model).Exec(ctx)
+	return translateUniqueViolation(err, sessionsScheduledWorkoutIndex)
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*workouthistory.Model, error) {
+	model := &workouthistory.Model{}
+	err := r.Conn(ctx).NewSelect().Model(model).Where("id = ? AND user_id = ?", id, userID).Scan(ctx)
+	return model, translateNotFound(err, "Session")
+}
+
+func (r *SessionRepository) GetPaginated(ctx context.Context, userID uuid.UUID, params workouthistory.ListSessionsQueryParams) ([]*workouthistory.Model, base.PageInfo, error) {
+	var models []*workouthistory.Model
+
+	query := r.Conn(ctx).NewSelect().
+		Model(&models).
+		Where("user_id = ?", userID)
+
+	orders := applySort(query, params.SortOrders(), base.SortOrder{Field: "started_at", Descending: true})
+
+	info, err := paginate(ctx, query, params.ListQueryParams, orders, &models)
+	if err != nil {
+		return nil, info, err
+	}
+
+	return models, info, nil
+}
+
+func (r *SessionRepository) Update(ctx context.Context, model *workouthistory.Model) error {
+	_, err := r.Conn(ctx).NewUpdate().Model(model).WherePK().Exec(ctx)
+	return err
+}
+
+func (r *SessionRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*workouthistory.Model)(nil)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Session")
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/uptrace/bun"
+)
+
+// tieBreaker ends every sort, so rows with equal sort keys keep the same
+// order between queries and keyset cursors point at a single row.
+var tieBreaker = base.SortOrder{Field: "id"}
+
+// applySort orders query by the requested sort orders, or by fallback when
+// none was requested, and returns the orders it applied. Sort fields must
+// have been checked against the whitelist of the list before.
+func applySort(query *bun.SelectQuery, orders []base.SortOrder, fallback ...base.SortOrder) []base.SortOrder {
+	if len(orders) == 0 {
+		orders = fallback
+	}
+
+	orders = append(orders[:len(orders):len(orders)], tieBreaker)
+
+	for _, order := range orders {
+		direction := "ASC"
+		if order.Descending {
+			direction = "DESC"
+		}
+
+		query.OrderExpr("? "+direction, bun.Ident(order.Field))
+	}
+
+	return orders
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/trash"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	TrashRepository struct {
+		repo.BaseRepository
+	}
+)
+
+func NewTrashRepository(db *bun.DB) TrashRepository {
+	repo := TrashRepository{}
+	repo.SetDB(db)
+
+	return repo
+}
+
+// GetPaginated lists the deleted workouts of the user and, withCatalog, the
+// deleted exercises and muscle groups of the global catalog.
+func (r *TrashRepository) GetPaginated(ctx context.Context, userID uuid.UUID, withCatalog bool, params trash.ListTrashQueryParams) ([]*trash.Item, int, error) {
+	var items []*trash.Item
+	limit := params.PerPage
+	offset := (params.Page - 1) * params.PerPage
+
+	union := r.Conn(ctx).NewSelect().
+		Model((*workout.Model)(nil)).
+		ColumnExpr("id, name, deleted_at").
+		ColumnExpr("?::text AS type", trash.ItemTypeWorkout).
+		Where("user_id = ?", userID).
+		WhereDeleted()
+
+	if withCatalog {
+		exercises := r.Conn(ctx).NewSelect().
+			Model((*exercise.Model)(nil)).
+			ColumnExpr("id, name, deleted_at").
+			ColumnExpr("?::text AS type", trash.ItemTypeExercise).
+			Where("user_id IS NULL").
+			WhereDeleted()
+
+		muscleGroups := r.Conn(ctx).NewSelect().
+			Model((*musclegroup.Model)(nil)).
+			ColumnExpr("id, name, deleted_at").
+			ColumnExpr("?::text AS type", trash.ItemTypeMuscleGroup).
+			WhereDeleted()
+
+		union = union.UnionAll(exercises).UnionAll(muscleGroups)
+	}
+
+	query := r.Conn(ctx).NewSelect().
+		TableExpr("(?) AS trash", union).
+		Limit(limit).
+		Offset(offset)
+
+	applySort(query, params.SortOrders(), base.SortOrder{Field: "deleted_at", Descending: true})
+
+	if params.Type != "" {
+		query.Where("type = ?", params.Type)
+	}
+
+	err := query.Scan(ctx, &items)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := query.Count(ctx)
+
+	return items, total, err
+}
+
+func (r *TrashRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	models := []any{
+		(*workouthistory.Model)(nil),
+		(*schedule.Model)(nil),
+		(*program.Model)(nil),
+		(*workout.Model)(nil),
+		(*exercise.Model)(nil),
+		(*musclegroup.Model)(nil),
+	}
+
+	purged := 0
+
+	for _, model := range models {
+		result, err := r.Conn(ctx).NewDelete().
+			Model(model).
+			Where("deleted_at < ?", deletedBefore).
+			ForceDelete().
+			Exec(ctx)
+		if err != nil {
+			return purged, err
+		}
+
+		rowsAffected, err := countRowsAffected(result)
+		if err != nil {
+			return purged, err
+		}
+
+		purged += rowsAffected
+	}
+
+	return purged, nil
+}
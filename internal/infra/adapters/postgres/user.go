@@ -19,11 +19,11 @@ func NewUserRepository(db *bun.DB) UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, model user.Model) error {
 	_, err := r.db.NewInsert().Model(&model).Exec(ctx)
-	return err
+	return translateUniqueViolation(err, usersEmailIndex)
 }
 
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.Model, error) {
 	var model user.Model
 	err := r.db.NewSelect().Model(&model).Where("email = ?", email).Scan(ctx)
-	return &model, err
+	return &model, translateNotFound(err, "User")
 }
@@ -2,8 +2,11 @@ package postgres
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
 	"github.com/google/uuid"
@@ -24,61 +27,301 @@ func NewWorkoutRepository(db *bun.DB) WorkoutRepository {
 }
 
 func (r *WorkoutRepository) Create(ctx context.Context, model *workout.Model) error {
-	_, err := r.GetDB().NewInsert().Model(model).Exec(ctx)
+	_, err := r.Conn(ctx).NewInsert().Model(model).Exec(ctx)
 	return err
 }
 
 func (r *WorkoutRepository) CreateWorkoutExercises(ctx context.Context, exercises []*workoutexercise.Model) error {
-	_, err := r.GetDB().NewInsert().Model(&exercises).Exec(ctx)
+	_, err := r.Conn(ctx).NewInsert().Model(&exercises).Exec(ctx)
 	return err
 }
 
+func (r *WorkoutRepository) CreateWorkoutExerciseGroups(ctx context.Context, groups []*workoutexercise.GroupModel) error {
+	_, err := r.Conn(ctx).NewInsert().Model(&groups).Exec(ctx)
+	return err
+}
+
+func (r *WorkoutRepository) CreateWorkoutExerciseSets(ctx context.Context, sets []*workoutexercise.SetModel) error {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	_, err := r.Conn(ctx).NewInsert().Model(&sets).Exec(ctx)
+	return err
+}
+
+// ReplaceWorkoutExerciseSets swaps the set prescriptions of a workout exercise
+// for new ones.
+func (r *WorkoutRepository) ReplaceWorkoutExerciseSets(ctx context.Context, workoutExerciseID uuid.UUID, sets []*workoutexercise.SetModel) error {
+	_, err := r.Conn(ctx).NewDelete().
+		Model((*workoutexercise.SetModel)(nil)).
+		Where("workout_exercise_id = ?", workoutExerciseID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, set := range sets {
+		set.WorkoutExerciseID = workoutExerciseID
+	}
+
+	return r.CreateWorkoutExerciseSets(ctx, sets)
+}
+
 func (r *WorkoutRepository) GetByID(ctx context.Context, id uuid.UUID) (*workout.Model, error) {
 	model := &workout.Model{}
-	err := r.GetDB().NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
-	return model, err
+	err := r.Conn(ctx).NewSelect().Model(model).Where("id = ?", id).Scan(ctx)
+	return model, translateNotFound(err, "Workout")
 }
 
 func (r *WorkoutRepository) GetByIDWithRelations(ctx context.Context, id uuid.UUID) (*workout.Model, error) {
 	model := &workout.Model{}
-	err := r.GetDB().NewSelect().Model(model).Relation("WorkoutExercises.Exercise").Where("id = ?", id).Scan(ctx)
-	return model, err
+	err := r.Conn(ctx).NewSelect().
+		Model(model).
+		Relation("WorkoutExercises.Exercise").
+		Relation("WorkoutExercises.Group").
+		Relation("WorkoutExercises.SetPrescriptions", orderSetPrescriptions).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, translateNotFound(err, "Workout")
+	}
+
+	model.Arrange()
+
+	return model, nil
 }
 
 func (r *WorkoutRepository) GetWorkoutExercise(ctx context.Context, workoutID uuid.UUID, workoutExerciseID uuid.UUID) (*workoutexercise.Model, error) {
 	model := &workoutexercise.Model{}
-	err := r.GetDB().NewSelect().Model(model).Where("workout_id = ? AND id = ?", workoutID, workoutExerciseID).Scan(ctx)
-	return model, err
+	err := r.Conn(ctx).NewSelect().
+		Model(model).
+		Relation("SetPrescriptions", orderSetPrescriptions).
+		Where("workout_id = ? AND id = ?", workoutID, workoutExerciseID).
+		Scan(ctx)
+	return model, translateNotFound(err, "Workout exercise")
 }
 
-func (r *WorkoutRepository) GetPaginated(ctx context.Context, userID uuid.UUID, params workout.ListWorkoutsQueryParams) ([]*workout.Model, int, error) {
+func (r *WorkoutRepository) GetPaginated(ctx context.Context, userID uuid.UUID, params workout.ListWorkoutsQueryParams) ([]*workout.Model, base.PageInfo, error) {
 	var models []*workout.Model
-	limit := params.PerPage
-	offset := (params.Page - 1) * params.PerPage
 
-	query := r.GetDB().NewSelect().
+	query := r.Conn(ctx).NewSelect().
 		Model(&models).
-		Relation("WorkoutExercises.Exercise").
+		Where("user_id = ?", userID)
+
+	expansions := params.Expansions(workout.Expansions...)
+
+	if slices.Contains(expansions, workout.ExpandExercises) || slices.Contains(expansions, workout.ExpandExerciseDetails) {
+		query.
+			Relation("WorkoutExercises.Group").
+			Relation("WorkoutExercises.SetPrescriptions", orderSetPrescriptions)
+	}
+
+	if slices.Contains(expansions, workout.ExpandExerciseDetails) {
+		query.Relation("WorkoutExercises.Exercise")
+	}
+
+	if params.Name != "" {
+		query.Where("name ILIKE ?", "%"+params.Name+"%")
+	}
+
+	if params.ExerciseID != nil {
+		exerciseQuery := r.Conn(ctx).NewSelect().
+			Model((*workoutexercise.Model)(nil)).
+			Column("workout_id").
+			Where("exercise_id = ?", *params.ExerciseID)
+
+		query.Where("id IN (?)", exerciseQuery)
+	}
+
+	if params.MuscleGroupID != nil {
+		muscleGroupQuery := r.Conn(ctx).NewSelect().
+			Model((*workoutexercise.Model)(nil)).
+			Column("workout_exercise.workout_id").
+			Join("JOIN exercise_muscle_groups AS emg ON emg.exercise_id = workout_exercise.exercise_id").
+			Where("emg.muscle_group_id = ?", *params.MuscleGroupID)
+
+		query.Where("id IN (?)", muscleGroupQuery)
+	}
+
+	if params.CreatedFrom != "" {
+		query.Where("created_at >= ?::date", params.CreatedFrom)
+	}
+
+	if params.CreatedTo != "" {
+		query.Where("created_at < ?::date + 1", params.CreatedTo)
+	}
+
+	if params.UpdatedFrom != "" {
+		query.Where("updated_at >= ?::date", params.UpdatedFrom)
+	}
+
+	if params.UpdatedTo != "" {
+		query.Where("updated_at < ?::date + 1", params.UpdatedTo)
+	}
+
+	orders := applySort(query, params.SortOrders())
+	applyFields(query, params.FieldList(), orders)
+
+	info, err := paginate(ctx, query, params.ListQueryParams, orders, &models)
+	if err != nil {
+		return nil, info, err
+	}
+
+	for _, model := range models {
+		model.Arrange()
+	}
+
+	return models, info, nil
+}
+
+func orderSetPrescriptions(query *bun.SelectQuery) *bun.SelectQuery {
+	return query.Order("workout_exercise_set.position ASC")
+}
+
+// CountByUser returns how many of the given workouts belong to the user.
+func (r *WorkoutRepository) CountByUser(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return r.Conn(ctx).NewSelect().
+		Model((*workout.Model)(nil)).
 		Where("user_id = ?", userID).
-		Limit(limit).
-		Offset(offset)
+		Where("id IN (?)", bun.In(ids)).
+		Count(ctx)
+}
+
+// UpdateWorkout saves the workout and increments its version, unless the
+// version was changed since the model was read.
+func (r *WorkoutRepository) UpdateWorkout(ctx context.Context, id uuid.UUID, model *workout.Model, columns ...string) error {
+	version := model.Version
+	model.Version++
 
-	err := query.Scan(ctx)
+	query := r.Conn(ctx).NewUpdate().Model(model).Where("id = ? AND version = ?", id, version)
+	result, err := updateColumns(query, columns, "version").Exec(ctx)
 	if err != nil {
-		return nil, 0, nil
+		model.Version = version
+		return err
 	}
 
-	total, err := query.Count(ctx)
+	if err := checkVersion(result); err != nil {
+		model.Version = version
+		return err
+	}
 
-	return models, total, err
+	return nil
 }
 
-func (r *WorkoutRepository) UpdateWorkout(ctx context.Context, id uuid.UUID, model *workout.Model) error {
-	_, err := r.GetDB().NewUpdate().Model(model).Where("id = ?", id).Exec(ctx)
+// IncrementVersion marks the workout as changed when its exercises change.
+func (r *WorkoutRepository) IncrementVersion(ctx context.Context, id uuid.UUID) error {
+	_, err := r.Conn(ctx).NewUpdate().
+		Model((*workout.Model)(nil)).
+		Set("version = version + 1").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
 	return err
 }
 
-func (r *WorkoutRepository) UpdateWorkoutExercise(ctx context.Context, workoutID uuid.UUID, workoutExerciseID uuid.UUID, model *workoutexercise.Model) error {
-	_, err := r.GetDB().NewUpdate().Model(model).Where("workout_id = ? AND id = ?", workoutID, workoutExerciseID).Exec(ctx)
+func (r *WorkoutRepository) UpdateWorkoutExercise(ctx context.Context, workoutID uuid.UUID, workoutExerciseID uuid.UUID, model *workoutexercise.Model, columns ...string) error {
+	query := r.Conn(ctx).NewUpdate().Model(model).Where("workout_id = ? AND id = ?", workoutID, workoutExerciseID)
+	_, err := updateColumns(query, columns).Exec(ctx)
+	return err
+}
+
+// ReorderWorkoutExercises moves every exercise to the position of its ID in
+// workoutExerciseIDs.
+func (r *WorkoutRepository) ReorderWorkoutExercises(ctx context.Context, workoutID uuid.UUID, workoutExerciseIDs []uuid.UUID) error {
+	for position, workoutExerciseID := range workoutExerciseIDs {
+		result, err := r.Conn(ctx).NewUpdate().
+			Model((*workoutexercise.Model)(nil)).
+			Set("position = ?", position).
+			Set("updated_at = ?", time.Now()).
+			Where("workout_id = ? AND id = ?", workoutID, workoutExerciseID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := checkRowsAffected(result); err != nil {
+			return translateNotFound(err, "Workout exercise")
+		}
+	}
+
+	return nil
+}
+
+func (r *WorkoutRepository) Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*workout.Model)(nil)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Workout")
+}
+
+func (r *WorkoutRepository) DeleteWorkoutExercise(ctx context.Context, workoutID uuid.UUID, workoutExerciseID uuid.UUID) error {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*workoutexercise.Model)(nil)).
+		Where("workout_id = ? AND id = ?", workoutID, workoutExerciseID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Workout exercise")
+}
+
+func (r *WorkoutRepository) Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) error {
+	result, err := r.Conn(ctx).NewUpdate().
+		Model((*workout.Model)(nil)).
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ? AND user_id = ?", id, userID).
+		WhereDeleted().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Workout")
+}
+
+func (r *WorkoutRepository) CreateShareLink(ctx context.Context, link *workout.ShareLinkModel) error {
+	_, err := r.Conn(ctx).NewInsert().Model(link).Exec(ctx)
 	return err
 }
+
+func (r *WorkoutRepository) GetShareLinks(ctx context.Context, workoutID uuid.UUID) ([]*workout.ShareLinkModel, error) {
+	var models []*workout.ShareLinkModel
+	err := r.Conn(ctx).NewSelect().
+		Model(&models).
+		Where("workout_id = ?", workoutID).
+		Order("created_at DESC").
+		Scan(ctx)
+
+	return models, err
+}
+
+func (r *WorkoutRepository) GetShareLinkByToken(ctx context.Context, token string) (*workout.ShareLinkModel, error) {
+	model := &workout.ShareLinkModel{}
+	err := r.Conn(ctx).NewSelect().Model(model).Where("token = ?", token).Scan(ctx)
+	return model, translateNotFound(err, "Share link")
+}
+
+// DeleteShareLink revokes a share link of a workout.
+func (r *WorkoutRepository) DeleteShareLink(ctx context.Context, workoutID uuid.UUID, linkID uuid.UUID) error {
+	result, err := r.Conn(ctx).NewDelete().
+		Model((*workout.ShareLinkModel)(nil)).
+		Where("workout_id = ? AND id = ?", workoutID, linkID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return translateNotFound(checkRowsAffected(result), "Share link")
+}
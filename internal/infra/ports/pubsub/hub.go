@@ -0,0 +1,15 @@
+package pubsub
+
+import "context"
+
+type (
+	// Hub delivers the messages published to a topic to the current
+	// subscribers of that topic. Messages are not stored, so subscribers only
+	// get the ones published while they are subscribed.
+	Hub interface {
+		Publish(ctx context.Context, topic string, message []byte) error
+		// Subscribe returns the messages published to topic until ctx is
+		// done, when the channel is closed.
+		Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+	}
+)
@@ -0,0 +1,12 @@
+package repo
+
+import "errors"
+
+var (
+	// ErrUniqueViolation is returned when a write conflicts with a unique index
+	ErrUniqueViolation = errors.New("unique violation")
+
+	// ErrVersionConflict is returned when an update is based on a version
+	// that is no longer the current one
+	ErrVersionConflict = errors.New("version conflict")
+)
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
 	"github.com/google/uuid"
 )
@@ -13,10 +14,18 @@ type (
 
 		Create(ctx context.Context, model *exercise.Model) error
 		CreateExerciseMuscleGroupAssociations(ctx context.Context, associations []*exercise.ExerciseMuscleGroupModel) error
+		// GetByID and GetPaginated read the global catalog, leaving out
+		// the exercises users created for themselves.
 		GetByID(ctx context.Context, id uuid.UUID) (*exercise.Model, error)
-		GetPaginated(ctx context.Context, params exercise.ListExercisesQueryParams) ([]*exercise.Model, int, error)
-		Update(ctx context.Context, id uuid.UUID, model *exercise.Model) error
+		GetForUser(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*exercise.Model, error)
+		GetPaginated(ctx context.Context, params exercise.ListExercisesQueryParams) ([]*exercise.Model, base.PageInfo, error)
+		Update(ctx context.Context, id uuid.UUID, model *exercise.Model, columns ...string) error
 		UpdateExerciseMuscleGroupAssociations(ctx context.Context, id uuid.UUID, associations []*exercise.ExerciseMuscleGroupModel) error
+		GetUsage(ctx context.Context, id uuid.UUID) (*exercise.Usage, error)
+		ReplaceReferences(ctx context.Context, id uuid.UUID, replacementID uuid.UUID) (*exercise.MovedReferences, error)
+		MoveMuscleGroupAssociations(ctx context.Context, id uuid.UUID, targetID uuid.UUID) (int, error)
+		CreateAliases(ctx context.Context, id uuid.UUID, names []string) (int, error)
 		Delete(ctx context.Context, id uuid.UUID) error
+		Restore(ctx context.Context, id uuid.UUID) error
 	}
 )
@@ -0,0 +1,28 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/idempotency"
+	"github.com/google/uuid"
+)
+
+type (
+	IdempotencyRepository interface {
+		Repository
+
+		// Reserve stores the key unless the user already holds it and it
+		// has neither expired nor, while in progress, outlived its lock,
+		// reporting whether it was stored.
+		Reserve(ctx context.Context, model *idempotency.Model) (bool, error)
+		Get(ctx context.Context, userID uuid.UUID, key string) (*idempotency.Model, error)
+		// Complete and Delete only act on the reservation held by model,
+		// leaving the key alone if another request reserved it since.
+		Complete(ctx context.Context, model *idempotency.Model) error
+		Delete(ctx context.Context, model *idempotency.Model) error
+		// DeleteExpired removes the keys that expired before the given time,
+		// returning how many were removed.
+		DeleteExpired(ctx context.Context, before time.Time) (int, error)
+	}
+)
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
 	"github.com/google/uuid"
 )
@@ -12,8 +13,13 @@ type (
 		Repository
 
 		Create(ctx context.Context, model *musclegroup.Model) error
-		GetPaginated(ctx context.Context, params musclegroup.ListMuscleGroupsQueryParams) ([]*musclegroup.Model, int, error)
-		Update(ctx context.Context, id uuid.UUID, model *musclegroup.Model) error
+		GetByID(ctx context.Context, id uuid.UUID) (*musclegroup.Model, error)
+		GetPaginated(ctx context.Context, params musclegroup.ListMuscleGroupsQueryParams) ([]*musclegroup.Model, base.PageInfo, error)
+		Update(ctx context.Context, id uuid.UUID, model *musclegroup.Model, columns ...string) error
+		GetUsage(ctx context.Context, id uuid.UUID) (*musclegroup.Usage, error)
+		ReplaceReferences(ctx context.Context, id uuid.UUID, replacementID uuid.UUID) (*musclegroup.MovedReferences, error)
+		CreateAliases(ctx context.Context, id uuid.UUID, names []string) (int, error)
 		Delete(ctx context.Context, id uuid.UUID) error
+		Restore(ctx context.Context, id uuid.UUID) error
 	}
 )
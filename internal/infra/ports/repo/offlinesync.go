@@ -0,0 +1,31 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+)
+
+type (
+	// SyncRepository reads the change feed of offline clients and the
+	// records they push changes to, deleted ones included, and creates the
+	// records they push with the ids they gave them. The feed holds the
+	// global exercise catalog and the user's own exercises.
+	SyncRepository interface {
+		Repository
+
+		GetChanges(ctx context.Context, userID uuid.UUID, since *time.Time) (*offlinesync.ChangeSet, error)
+		GetRecords(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (*offlinesync.ChangeSet, error)
+		GetWorkout(ctx context.Context, id uuid.UUID) (*workout.Model, error)
+		GetSession(ctx context.Context, id uuid.UUID) (*workouthistory.Model, error)
+		GetExercise(ctx context.Context, id uuid.UUID) (*exercise.Model, error)
+		CreateWorkout(ctx context.Context, model *workout.Model) error
+		CreateSession(ctx context.Context, model *workouthistory.Model) error
+		CreateExercise(ctx context.Context, model *exercise.Model) error
+	}
+)
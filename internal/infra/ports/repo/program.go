@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/google/uuid"
+)
+
+type (
+	ProgramRepository interface {
+		Repository
+
+		Create(ctx context.Context, model *program.Model) error
+		CreateWeeks(ctx context.Context, weeks []*program.WeekModel) error
+		CreateDays(ctx context.Context, days []*program.DayModel) error
+		GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*program.Model, error)
+		GetPaginated(ctx context.Context, userID uuid.UUID, params program.ListProgramsQueryParams) ([]*program.Model, int, error)
+		Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+		CreateEnrollment(ctx context.Context, model *program.EnrollmentModel) error
+		GetActiveEnrollment(ctx context.Context, userID uuid.UUID) (*program.EnrollmentModel, error)
+		EndEnrollments(ctx context.Context, userID uuid.UUID) error
+		GetEstimatedOneRepMaxes(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]float64, error)
+	}
+)
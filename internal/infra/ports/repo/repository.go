@@ -31,10 +31,33 @@ func (r *BaseRepository) GetDB() *bun.DB {
 	return r.db
 }
 
+// Conn returns the transaction bound to the context by ExecTx, falling back
+// to the database connection when the call is not part of a transaction.
+func (r *BaseRepository) Conn(ctx context.Context) bun.IDB {
+	if tx, ok := ctx.Value(txKey).(*bun.Tx); ok {
+		return tx
+	}
+
+	return r.db
+}
+
+// BindTx hands the transaction bound to ctx, if any, to set, so a context
+// carrying values of its own, such as the request context of a sub request,
+// joins the transaction.
+func BindTx(ctx context.Context, set func(key any, value any)) {
+	if tx, ok := ctx.Value(txKey).(*bun.Tx); ok {
+		set(txKey, tx)
+	}
+}
+
 func (r *BaseRepository) ExecTx(
 	ctx context.Context,
 	txFn func(txCtx context.Context) error,
 ) (err error) {
+	if _, ok := ctx.Value(txKey).(*bun.Tx); ok {
+		return txFn(ctx)
+	}
+
 	err = r.db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
 		ctxTx := context.WithValue(ctx, txKey, &tx)
 
@@ -0,0 +1,20 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/google/uuid"
+)
+
+type (
+	ScheduleRepository interface {
+		Repository
+
+		Create(ctx context.Context, models []*schedule.Model) error
+		GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*schedule.Model, error)
+		GetByPeriod(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time) ([]*schedule.Model, error)
+		Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	}
+)
@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+)
+
+type (
+	SessionRepository interface {
+		Repository
+
+		Create(ctx context.Context, model *workouthistory.Model) error
+		GetByID(ctx context.Context, userID uuid.UUID, id uuid.UUID) (*workouthistory.Model, error)
+		GetPaginated(ctx context.Context, userID uuid.UUID, params workouthistory.ListSessionsQueryParams) ([]*workouthistory.Model, base.PageInfo, error)
+		Update(ctx context.Context, model *workouthistory.Model) error
+		Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+	}
+)
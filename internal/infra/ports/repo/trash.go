@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/trash"
+	"github.com/google/uuid"
+)
+
+type (
+	TrashRepository interface {
+		Repository
+
+		GetPaginated(ctx context.Context, userID uuid.UUID, withCatalog bool, params trash.ListTrashQueryParams) ([]*trash.Item, int, error)
+		Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+	}
+)
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
 	"github.com/google/uuid"
@@ -14,11 +15,24 @@ type (
 
 		Create(ctx context.Context, workout *workout.Model) error
 		CreateWorkoutExercises(ctx context.Context, exercises []*workoutexercise.Model) error
+		CreateWorkoutExerciseGroups(ctx context.Context, groups []*workoutexercise.GroupModel) error
+		CreateWorkoutExerciseSets(ctx context.Context, sets []*workoutexercise.SetModel) error
+		ReplaceWorkoutExerciseSets(ctx context.Context, workoutExerciseID uuid.UUID, sets []*workoutexercise.SetModel) error
 		GetByID(ctx context.Context, id uuid.UUID) (*workout.Model, error)
 		GetByIDWithRelations(ctx context.Context, id uuid.UUID) (*workout.Model, error)
 		GetWorkoutExercise(ctx context.Context, id uuid.UUID, workoutExerciseID uuid.UUID) (*workoutexercise.Model, error)
-		GetPaginated(ctx context.Context, userID uuid.UUID, params workout.ListWorkoutsQueryParams) ([]*workout.Model, int, error)
-		UpdateWorkout(ctx context.Context, id uuid.UUID, workout *workout.Model) error
-		UpdateWorkoutExercise(ctx context.Context, id uuid.UUID, workoutExerciseID uuid.UUID, exercise *workoutexercise.Model) error
+		GetPaginated(ctx context.Context, userID uuid.UUID, params workout.ListWorkoutsQueryParams) ([]*workout.Model, base.PageInfo, error)
+		CountByUser(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (int, error)
+		UpdateWorkout(ctx context.Context, id uuid.UUID, workout *workout.Model, columns ...string) error
+		IncrementVersion(ctx context.Context, id uuid.UUID) error
+		UpdateWorkoutExercise(ctx context.Context, id uuid.UUID, workoutExerciseID uuid.UUID, exercise *workoutexercise.Model, columns ...string) error
+		ReorderWorkoutExercises(ctx context.Context, id uuid.UUID, workoutExerciseIDs []uuid.UUID) error
+		Delete(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+		DeleteWorkoutExercise(ctx context.Context, id uuid.UUID, workoutExerciseID uuid.UUID) error
+		Restore(ctx context.Context, userID uuid.UUID, id uuid.UUID) error
+		CreateShareLink(ctx context.Context, link *workout.ShareLinkModel) error
+		GetShareLinks(ctx context.Context, id uuid.UUID) ([]*workout.ShareLinkModel, error)
+		GetShareLinkByToken(ctx context.Context, token string) (*workout.ShareLinkModel, error)
+		DeleteShareLink(ctx context.Context, id uuid.UUID, linkID uuid.UUID) error
 	}
 )
@@ -0,0 +1,166 @@
+package domainerr
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Kind classifies a domain error. Each kind maps to one HTTP status.
+type Kind string
+
+const (
+	KindNotFound           Kind = "not_found"
+	KindConflict           Kind = "conflict"
+	KindForbidden          Kind = "forbidden"
+	KindUnauthorized       Kind = "unauthorized"
+	KindValidation         Kind = "validation"
+	KindMalformed          Kind = "malformed"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindUnsupportedMedia   Kind = "unsupported_media"
+	KindGone               Kind = "gone"
+)
+
+type (
+	// Error is an error whose message and details are safe to show to
+	// clients. The wrapped cause is kept for errors.Is/As and logging only.
+	Error struct {
+		Kind    Kind
+		Message string
+		Details *response.ErrorDetails
+		Meta    any
+
+		cause error
+	}
+)
+
+// Sentinels to match any error of a kind with errors.Is.
+var (
+	ErrNotFound           = &Error{Kind: KindNotFound}
+	ErrConflict           = &Error{Kind: KindConflict}
+	ErrForbidden          = &Error{Kind: KindForbidden}
+	ErrUnauthorized       = &Error{Kind: KindUnauthorized}
+	ErrValidation         = &Error{Kind: KindValidation}
+	ErrMalformed          = &Error{Kind: KindMalformed}
+	ErrPreconditionFailed = &Error{Kind: KindPreconditionFailed}
+	ErrUnsupportedMedia   = &Error{Kind: KindUnsupportedMedia}
+	ErrGone               = &Error{Kind: KindGone}
+)
+
+func New(kind Kind, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(KindForbidden, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+func Validation(message string, details *response.ErrorDetails) *Error {
+	return New(KindValidation, message).WithDetails(details)
+}
+
+func Malformed(message string, details *response.ErrorDetails) *Error {
+	return New(KindMalformed, message).WithDetails(details)
+}
+
+func PreconditionFailed(message string) *Error {
+	return New(KindPreconditionFailed, message)
+}
+
+func UnsupportedMedia(message string) *Error {
+	return New(KindUnsupportedMedia, message)
+}
+
+func Gone(message string) *Error {
+	return New(KindGone, message)
+}
+
+func InvalidRequestBody(details *response.ErrorDetails) *Error {
+	return Validation("Invalid request body.", details)
+}
+
+func InvalidQueryParams(details *response.ErrorDetails) *Error {
+	return Validation("Invalid query parameters.", details)
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is the sentinel of the same kind.
+func (e *Error) Is(target error) bool {
+	sentinel, ok := target.(*Error)
+	return ok && sentinel.Message == "" && sentinel.Kind == e.Kind
+}
+
+// WithDetails returns a copy of the error carrying field level details.
+func (e *Error) WithDetails(details *response.ErrorDetails) *Error {
+	copied := *e
+	copied.Details = details
+
+	return &copied
+}
+
+// WithMeta returns a copy of the error carrying extra client facing data.
+func (e *Error) WithMeta(meta any) *Error {
+	copied := *e
+	copied.Meta = meta
+
+	return &copied
+}
+
+// Wrap returns a copy of the error that records cause.
+func (e *Error) Wrap(cause error) *Error {
+	copied := *e
+	copied.cause = cause
+
+	return &copied
+}
+
+// Status returns the HTTP status code for the error kind.
+func (e *Error) Status() int {
+	switch e.Kind {
+	case KindNotFound:
+		return fiber.StatusNotFound
+	case KindConflict:
+		return fiber.StatusConflict
+	case KindForbidden:
+		return fiber.StatusForbidden
+	case KindUnauthorized:
+		return fiber.StatusUnauthorized
+	case KindValidation:
+		return fiber.StatusBadRequest
+	case KindMalformed:
+		return fiber.StatusUnprocessableEntity
+	case KindPreconditionFailed:
+		return fiber.StatusPreconditionFailed
+	case KindUnsupportedMedia:
+		return fiber.StatusUnsupportedMediaType
+	case KindGone:
+		return fiber.StatusGone
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
@@ -0,0 +1,82 @@
+package domainerr_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      *domainerr.Error
+		expected int
+	}{
+		{name: "not found", err: domainerr.NotFound("Workout not found"), expected: http.StatusNotFound},
+		{name: "conflict", err: domainerr.Conflict("Name already in use"), expected: http.StatusConflict},
+		{name: "forbidden", err: domainerr.Forbidden("Forbidden"), expected: http.StatusForbidden},
+		{name: "unauthorized", err: domainerr.Unauthorized("Unauthorized"), expected: http.StatusUnauthorized},
+		{name: "validation", err: domainerr.InvalidRequestBody(nil), expected: http.StatusBadRequest},
+		{name: "malformed", err: domainerr.Malformed("Invalid request body.", nil), expected: http.StatusUnprocessableEntity},
+		{name: "precondition failed", err: domainerr.PreconditionFailed("Version mismatch"), expected: http.StatusPreconditionFailed},
+		{name: "unsupported media", err: domainerr.UnsupportedMedia("Unsupported content type"), expected: http.StatusUnsupportedMediaType},
+		{name: "gone", err: domainerr.Gone("Change token expired"), expected: http.StatusGone},
+		{name: "unknown kind", err: domainerr.New("unknown", "Boom"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.err.Status())
+		})
+	}
+}
+
+func TestErrorMatching(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should match the sentinel of the same kind through wrapping", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("get workout: %w", domainerr.NotFound("Workout not found").Wrap(sql.ErrNoRows))
+
+		assert.True(t, errors.Is(err, domainerr.ErrNotFound))
+		assert.False(t, errors.Is(err, domainerr.ErrConflict))
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+	})
+
+	t.Run("should not match other errors of the same kind", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, errors.Is(domainerr.NotFound("Workout not found"), domainerr.NotFound("Exercise not found")))
+	})
+
+	t.Run("should keep the cause out of the client message", func(t *testing.T) {
+		t.Parallel()
+
+		err := domainerr.NotFound("Workout not found").Wrap(sql.ErrNoRows)
+
+		var domainErr *domainerr.Error
+		assert.True(t, errors.As(err, &domainErr))
+		assert.Equal(t, "Workout not found", domainErr.Message)
+		assert.Equal(t, "Workout not found: "+sql.ErrNoRows.Error(), err.Error())
+	})
+
+	t.Run("should not change the original when copying", func(t *testing.T) {
+		t.Parallel()
+
+		original := domainerr.Conflict("Exercise is in use")
+		withMeta := original.WithMeta(map[string]int{"workouts": 1})
+
+		assert.Nil(t, original.Meta)
+		assert.Equal(t, map[string]int{"workouts": 1}, withMeta.Meta)
+	})
+}
@@ -0,0 +1,13 @@
+package base
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+var (
+	// ErrorCursorIsInvalid is the error message for cursors that were not returned for the same list and sort
+	ErrorCursorIsInvalid response.ErrorDetail = validation.NewErrorDetail("cursor", validation.CodeInvalid, "Cursor is invalid")
+	// ErrorPageWithCursor is the error message for lists paged both by page number and cursor
+	ErrorPageWithCursor response.ErrorDetail = validation.NewErrorDetail("page", validation.CodeInvalid, "Page must not be used with a cursor")
+)
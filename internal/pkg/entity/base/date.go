@@ -0,0 +1,10 @@
+package base
+
+import "time"
+
+// Today returns the current calendar day in location, at midnight UTC so it
+// compares with DATE columns read from the database.
+func Today(location *time.Location) time.Time {
+	year, month, day := time.Now().In(location).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
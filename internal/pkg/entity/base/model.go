@@ -13,7 +13,7 @@ type (
 		ID        uuid.UUID  `json:"id" bun:"id,pk"`
 		CreatedAt time.Time  `json:"created_at" bun:"created_at"`
 		UpdatedAt time.Time  `json:"updated_at" bun:"updated_at"`
-		DeletedAt *time.Time `json:"deleted_at" bun:"deleted_at"`
+		DeletedAt *time.Time `json:"deleted_at" bun:"deleted_at,soft_delete,nullzero"`
 	}
 )
 
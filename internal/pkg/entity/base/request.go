@@ -1,22 +1,268 @@
 package base
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// DefaultPerPage is the page size used when none is requested
+	DefaultPerPage = 10
+
+	// MaxPerPage is the largest page size a client may request
+	MaxPerPage = 100
+)
+
 type (
+	// ListQueryParams holds the pagination and sorting of list endpoints.
+	// Sort is a comma separated list of fields, each prefixed with "-" for
+	// descending order, e.g. "-created_at,name". Lists that support it can
+	// be paged by the next_cursor of the previous page instead of Page, and
+	// SkipCount leaves out the total count.
 	ListQueryParams struct {
-		Page    int `json:"page"`
-		PerPage int `json:"per_page"`
+		Page      int    `json:"page" query:"page"`
+		PerPage   int    `json:"per_page" query:"per_page"`
+		Sort      string `json:"sort" query:"sort"`
+		Cursor    string `json:"cursor" query:"cursor"`
+		SkipCount bool   `json:"skip_count" query:"skip_count"`
+	}
+
+	// SortOrder is a field a list is sorted by.
+	SortOrder struct {
+		Field      string
+		Descending bool
+	}
+
+	// Cursor is the sort key of the last row of a page. The next page starts
+	// right after it, so rows inserted meanwhile don't shift it.
+	Cursor struct {
+		Sort   string `json:"s"` // Sort the key was taken with, tie breaker included
+		Values []any  `json:"v"` // Sort key of the row, one value per sort field
+	}
+
+	// Projection selects what read endpoints return. Fields is a comma
+	// separated list of the columns to return, all of them when empty.
+	// Expand is a comma separated list of the relations to load; the
+	// default relations of the resource are loaded when it is not given and
+	// none when it is given empty.
+	Projection struct {
+		Fields string  `json:"fields" query:"fields"`
+		Expand *string `json:"expand" query:"expand"`
+	}
+
+	// PageInfo is the pagination of a page read from a list.
+	PageInfo struct {
+		Total      *int   // Total number of items, nil when the count was skipped
+		NextCursor string // Cursor of the next page, empty on the last page
 	}
 )
 
+func (p *ListQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+	p.Rules(v)
+	p.SortRules(v)
+
+	return v.Errors()
+}
+
+// Rules adds the pagination rules to v, so list params embedding
+// ListQueryParams can validate their own fields alongside them.
+func (p *ListQueryParams) Rules(v *validation.Validator) {
+	v.Int("page", p.Page).Min(0)
+	v.Int("per_page", p.PerPage).Min(0).Max(MaxPerPage)
+}
+
 func (p *ListQueryParams) ValidateAndSetDefaults() {
 	if p.PerPage == 0 || p.PerPage < 0 {
-		p.PerPage = 10
+		p.PerPage = DefaultPerPage
 	}
 
 	if p.Page == 0 || p.Page < 0 {
 		p.Page = 1
 	}
 
-	if p.PerPage > 100 || p.PerPage < 0 {
-		p.PerPage = 100
+	if p.PerPage > MaxPerPage || p.PerPage < 0 {
+		p.PerPage = MaxPerPage
+	}
+}
+
+// CursorRules checks the cursor of lists that can be paged by cursor. Whether
+// it matches the sort of the list is only known when reading the page.
+func (p *ListQueryParams) CursorRules(v *validation.Validator) {
+	if p.Cursor == "" {
+		return
+	}
+
+	_, err := DecodeCursor(p.Cursor)
+	v.Check(err == nil, ErrorCursorIsInvalid)
+	v.Check(p.Page <= 1, ErrorPageWithCursor)
+}
+
+// Pagination builds the pagination metadata of a page read with p.
+func (p *ListQueryParams) Pagination(info PageInfo) response.Pagination {
+	pagination := response.Pagination{
+		Page:       p.Page,
+		PerPage:    p.PerPage,
+		TotalItems: info.Total,
+		NextCursor: info.NextCursor,
+	}
+
+	if p.Cursor != "" {
+		pagination.Page = 0
+	}
+
+	return pagination
+}
+
+// SortRules checks that the list is only sorted by the allowed fields. Lists
+// that don't call it with their own fields can't be sorted.
+func (p *ListQueryParams) SortRules(v *validation.Validator, allowed ...string) {
+	for _, order := range p.SortOrders() {
+		if !slices.Contains(allowed, order.Field) {
+			message := "Sort is not supported"
+			if len(allowed) > 0 {
+				message = fmt.Sprintf("Sort must only use: %s", strings.Join(allowed, ", "))
+			}
+
+			v.Add("sort", validation.CodeInvalidChoice, message)
+			return
+		}
+	}
+}
+
+// SortOrders parses Sort. It must only be trusted after SortRules passed.
+func (p *ListQueryParams) SortOrders() []SortOrder {
+	var orders []SortOrder
+
+	for _, field := range splitList(p.Sort) {
+		descending := strings.HasPrefix(field, "-")
+		orders = append(orders, SortOrder{
+			Field:      strings.TrimPrefix(field, "-"),
+			Descending: descending,
+		})
+	}
+
+	return orders
+}
+
+// FormatSort is the inverse of SortOrders.
+func FormatSort(orders []SortOrder) string {
+	fields := make([]string, len(orders))
+	for i, order := range orders {
+		fields[i] = order.Field
+		if order.Descending {
+			fields[i] = "-" + order.Field
+		}
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// EncodeCursor encodes a cursor into the opaque string returned to clients.
+func EncodeCursor(cursor Cursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes a cursor returned by EncodeCursor.
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, err
+	}
+
+	return cursor, nil
+}
+
+// ProjectionRules checks that only the allowed fields and relations are
+// requested.
+func (p *Projection) ProjectionRules(v *validation.Validator, fields []string, relations []string) {
+	choiceRule(v, "fields", "Fields", p.FieldList(), fields)
+	choiceRule(v, "expand", "Expand", p.Expansions(), relations)
+}
+
+// FieldList parses Fields. It must only be trusted after ProjectionRules
+// passed.
+func (p *Projection) FieldList() []string {
+	return splitList(p.Fields)
+}
+
+// Expansions parses Expand, returning defaults when it was not given. It
+// must only be trusted after ProjectionRules passed.
+func (p *Projection) Expansions(defaults ...string) []string {
+	if p.Expand == nil {
+		return defaults
 	}
+
+	return splitList(*p.Expand)
+}
+
+// Project drops from the JSON of responses the fields that could have been
+// requested but were not, so columns left out of the query don't show up
+// with zero values. The id, which identifies the item and is always
+// selected, relations and other keys are kept. Responses are returned as
+// they are when no fields were requested.
+func (p *Projection) Project(responses any, fields []string) (any, error) {
+	requested := p.FieldList()
+	if len(requested) == 0 {
+		return responses, nil
+	}
+
+	data, err := json.Marshal(responses)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		for _, field := range fields {
+			if field != "id" && !slices.Contains(requested, field) {
+				delete(item, field)
+			}
+		}
+	}
+
+	return items, nil
+}
+
+func choiceRule(v *validation.Validator, field string, label string, values []string, allowed []string) {
+	for _, value := range values {
+		if !slices.Contains(allowed, value) {
+			v.Add(field, validation.CodeInvalidChoice, fmt.Sprintf("%s must only use: %s", label, strings.Join(allowed, ", ")))
+			return
+		}
+	}
+}
+
+// splitList splits a comma separated query parameter, skipping blank items.
+func splitList(value string) []string {
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
 }
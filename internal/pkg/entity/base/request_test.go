@@ -0,0 +1,153 @@
+package base_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortOrders(t *testing.T) {
+	t.Parallel()
+
+	params := base.ListQueryParams{Sort: "-created_at, name,"}
+
+	orders := params.SortOrders()
+	assert.Equal(t, []base.SortOrder{
+		{Field: "created_at", Descending: true},
+		{Field: "name"},
+	}, orders)
+	assert.Equal(t, "-created_at,name", base.FormatSort(orders))
+}
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should decode an encoded cursor", func(t *testing.T) {
+		t.Parallel()
+
+		cursor := base.Cursor{Sort: "-started_at,id", Values: []any{"2025-02-19T10:00:00Z", nil}}
+
+		encoded, err := base.EncodeCursor(cursor)
+		assert.Nil(t, err)
+
+		decoded, err := base.DecodeCursor(encoded)
+		assert.Nil(t, err)
+		assert.Equal(t, &cursor, decoded)
+	})
+
+	t.Run("should not decode a tampered cursor", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := base.DecodeCursor("not a cursor")
+		assert.NotNil(t, err)
+	})
+}
+
+func TestListQueryParamsCursorRules(t *testing.T) {
+	t.Parallel()
+
+	cursor, _ := base.EncodeCursor(base.Cursor{Sort: "id", Values: []any{"7c9e6679-7425-40de-944b-e07fc1f90ae7"}})
+
+	tests := []struct {
+		name     string
+		params   base.ListQueryParams
+		expected *response.ErrorDetails
+	}{
+		{
+			name:     "no cursor",
+			params:   base.ListQueryParams{Page: 3},
+			expected: nil,
+		},
+		{
+			name:     "valid cursor",
+			params:   base.ListQueryParams{Cursor: cursor},
+			expected: nil,
+		},
+		{
+			name:     "invalid cursor with page",
+			params:   base.ListQueryParams{Page: 2, Cursor: "eyJz"},
+			expected: &response.ErrorDetails{base.ErrorCursorIsInvalid, base.ErrorPageWithCursor},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			v := validation.New()
+			tt.params.CursorRules(v)
+			assert.Equal(t, tt.expected, v.Errors())
+		})
+	}
+}
+
+func TestListQueryParamsPagination(t *testing.T) {
+	t.Parallel()
+
+	total := 11
+
+	t.Run("should count the pages", func(t *testing.T) {
+		t.Parallel()
+
+		params := base.ListQueryParams{Page: 2, PerPage: 5}
+
+		pagination := response.NewPaginationResponse(nil, params.Pagination(base.PageInfo{Total: &total})).Pagination
+		assert.Equal(t, 2, pagination.Page)
+		assert.Equal(t, 11, *pagination.TotalItems)
+		assert.Equal(t, 3, *pagination.TotalPages)
+	})
+
+	t.Run("should leave out the page and count when paging by cursor", func(t *testing.T) {
+		t.Parallel()
+
+		params := base.ListQueryParams{Page: 1, PerPage: 5, Cursor: "cursor", SkipCount: true}
+
+		pagination := response.NewPaginationResponse(nil, params.Pagination(base.PageInfo{NextCursor: "next"})).Pagination
+		assert.Equal(t, 0, pagination.Page)
+		assert.Nil(t, pagination.TotalItems)
+		assert.Nil(t, pagination.TotalPages)
+		assert.Equal(t, "next", pagination.NextCursor)
+	})
+}
+
+func TestProjectionExpansions(t *testing.T) {
+	t.Parallel()
+
+	none, some := "", "aliases, muscle_groups"
+
+	assert.Equal(t, []string{"muscle_groups"}, (&base.Projection{}).Expansions("muscle_groups"))
+	assert.Empty(t, (&base.Projection{Expand: &none}).Expansions("muscle_groups"))
+	assert.Equal(t, []string{"aliases", "muscle_groups"}, (&base.Projection{Expand: &some}).Expansions("muscle_groups"))
+}
+
+func TestProjectionProject(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		ID        int      `json:"id"`
+		Name      string   `json:"name"`
+		CreatedAt string   `json:"created_at"`
+		Tags      []string `json:"tags"`
+	}
+	items := []item{{ID: 1, Name: "Push", Tags: []string{"a"}}}
+	fields := []string{"id", "name", "created_at"}
+
+	t.Run("should return the responses as they are without fields", func(t *testing.T) {
+		projected, err := (&base.Projection{}).Project(items, fields)
+		assert.NoError(t, err)
+		assert.Equal(t, items, projected)
+	})
+
+	t.Run("should drop the fields that were not requested", func(t *testing.T) {
+		projected, err := (&base.Projection{Fields: "name"}).Project(items, fields)
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(projected)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":1,"name":"Push","tags":["a"]}]`, string(data))
+	})
+}
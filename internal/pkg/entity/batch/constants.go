@@ -0,0 +1,24 @@
+package batch
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// MaxOperations is the largest number of operations sent in one batch
+	MaxOperations = 100
+)
+
+// Methods are the HTTP methods operations can use.
+var Methods = []string{fiber.MethodGet, fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete}
+
+var (
+	// ErrorOperationsIsRequired is the error message for batches without operations
+	ErrorOperationsIsRequired response.ErrorDetail = validation.NewErrorDetail("operations", validation.CodeRequired, "At least one operation is required")
+	// ErrorOperationsIsTooLong is the error message for batches of more than MaxOperations operations
+	ErrorOperationsIsTooLong response.ErrorDetail = validation.NewErrorDetail("operations", validation.CodeTooLarge, "At most 100 operations can be sent at once")
+	// ErrorPathIsNotAbsolute is the error message for operation paths that do not start with a slash
+	ErrorPathIsNotAbsolute response.ErrorDetail = validation.NewErrorDetail("path", validation.CodeInvalid, "Path must start with /")
+)
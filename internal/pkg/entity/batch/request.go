@@ -0,0 +1,43 @@
+package batch
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+type (
+	// BatchRequest is an ordered list of API requests. In atomic mode they
+	// run in one database transaction, so either every operation is applied
+	// or none is.
+	BatchRequest struct {
+		Atomic     bool        `json:"atomic"`
+		Operations []Operation `json:"operations"`
+	}
+
+	// Operation is a request to the API, sent with the credentials of the
+	// batch. Path includes the query string, e.g. /v1/workouts?limit=5, and
+	// Headers override the ones set by default, e.g. Content-Type.
+	Operation struct {
+		Method  string            `json:"method"`
+		Path    string            `json:"path"`
+		Headers map[string]string `json:"headers"`
+		Body    json.RawMessage   `json:"body"`
+	}
+)
+
+func (r *BatchRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.Check(len(r.Operations) > 0, ErrorOperationsIsRequired)
+	v.Check(len(r.Operations) <= MaxOperations, ErrorOperationsIsTooLong)
+	validation.Each(v, "operations", r.Operations, func(v *validation.Validator, operation Operation) {
+		v.String("method", operation.Method).Required().OneOf(Methods...)
+		v.String("path", operation.Path).Required()
+		v.Check(operation.Path == "" || strings.HasPrefix(operation.Path, "/"), ErrorPathIsNotAbsolute)
+	})
+
+	return v.Errors()
+}
@@ -0,0 +1,81 @@
+package batch_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/batch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	pathIsNotAbsolute := batch.ErrorPathIsNotAbsolute
+	pathIsNotAbsolute.Field = "operations[1].path"
+
+	tests := []struct {
+		name     string
+		request  batch.BatchRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: batch.BatchRequest{
+				Atomic: true,
+				Operations: []batch.Operation{
+					{Method: "POST", Path: "/v1/workouts", Body: []byte(`{"name":"Push Day"}`)},
+					{Method: "GET", Path: "/v1/workouts?limit=5"},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:     "missing operations",
+			request:  batch.BatchRequest{},
+			expected: &response.ErrorDetails{batch.ErrorOperationsIsRequired},
+		},
+		{
+			name: "too many operations",
+			request: batch.BatchRequest{
+				Operations: make([]batch.Operation, batch.MaxOperations+1),
+			},
+			expected: func() *response.ErrorDetails {
+				details := response.ErrorDetails{batch.ErrorOperationsIsTooLong}
+				for index := range batch.MaxOperations + 1 {
+					prefix := fmt.Sprintf("operations[%d].", index)
+					details = append(details,
+						validation.NewErrorDetail(prefix+"method", validation.CodeRequired, "Method is required"),
+						validation.NewErrorDetail(prefix+"path", validation.CodeRequired, "Path is required"),
+					)
+				}
+
+				return &details
+			}(),
+		},
+		{
+			name: "invalid operations",
+			request: batch.BatchRequest{
+				Operations: []batch.Operation{
+					{Method: "TRACE", Path: "/v1/workouts"},
+					{Method: "GET", Path: "v1/workouts"},
+				},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("operations[0].method", validation.CodeInvalidChoice, "Method must be one of: GET, POST, PUT, PATCH, DELETE"),
+				pathIsNotAbsolute,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package batch
+
+import "encoding/json"
+
+type (
+	// BatchResponse holds the result of every operation, in the order they
+	// were sent. RolledBack reports that an atomic batch failed, in which
+	// case the results of the operations before the failure were undone.
+	BatchResponse struct {
+		RolledBack bool              `json:"rolled_back"`
+		Results    []OperationResult `json:"results"`
+	}
+
+	// OperationResult is the response of an operation. A JSON body is
+	// embedded as is, any other body as a string.
+	OperationResult struct {
+		Status  int               `json:"status"`
+		Headers map[string]string `json:"headers"`
+		Body    json.RawMessage   `json:"body"`
+	}
+)
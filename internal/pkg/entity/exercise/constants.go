@@ -0,0 +1,50 @@
+package exercise
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// NameMaxLength is the maximum number of characters allowed in an exercise name
+	NameMaxLength = 255
+)
+
+var (
+	// ErrorNameIsRequired is the error message for name is required
+	ErrorNameIsRequired response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeRequired, "Name is required")
+	// ErrorNameIsTooLong is the error message for names longer than NameMaxLength
+	ErrorNameIsTooLong response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeTooLong, "Name must have at most 255 characters")
+	// ErrorNameAlreadyExists is the error message for a name already used by another exercise
+	ErrorNameAlreadyExists response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeAlreadyExists, "An exercise with this name already exists")
+	// ErrorReplaceWithNotFound is the error message for a replacement exercise that does not exist
+	ErrorReplaceWithNotFound response.ErrorDetail = validation.NewErrorDetail("replace_with", validation.CodeNotFound, "Replacement exercise not found")
+	// ErrorReplaceWithIsSame is the error message for replacing an exercise with itself
+	ErrorReplaceWithIsSame response.ErrorDetail = validation.NewErrorDetail("replace_with", validation.CodeInvalid, "An exercise cannot replace itself")
+	// ErrorSourceIDsIsRequired is the error message for source ids is required
+	ErrorSourceIDsIsRequired response.ErrorDetail = validation.NewErrorDetail("source_ids", validation.CodeRequired, "At least one source exercise is required")
+	// ErrorSourceIDsContainsTarget is the error message for merging an exercise into itself
+	ErrorSourceIDsContainsTarget response.ErrorDetail = validation.NewErrorDetail("source_ids", validation.CodeInvalid, "An exercise cannot be merged into itself")
+	// ErrorSourceIDsNotFound is the error message for source exercises that do not exist
+	ErrorSourceIDsNotFound response.ErrorDetail = validation.NewErrorDetail("source_ids", validation.CodeNotFound, "One or more source exercises were not found")
+)
+
+// SortFields are the fields lists of exercises can be sorted by.
+var SortFields = []string{"name", "created_at", "updated_at"}
+
+const (
+	// ExpandMuscleGroups loads the muscle groups of exercises
+	ExpandMuscleGroups = "muscle_groups"
+	// ExpandAliases loads the aliases of exercises
+	ExpandAliases = "aliases"
+)
+
+// Fields are the fields lists of exercises can be limited to.
+var Fields = []string{"id", "name", "description", "created_at", "updated_at"}
+
+// Expansions are the relations lists of exercises can load.
+var Expansions = []string{ExpandMuscleGroups, ExpandAliases}
+
+// DefaultExpansions are the relations lists of exercises load when expand
+// is not given.
+var DefaultExpansions = []string{ExpandMuscleGroups}
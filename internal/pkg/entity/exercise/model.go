@@ -3,6 +3,7 @@ package exercise
 import (
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
 
@@ -10,8 +11,17 @@ type (
 	Model struct {
 		bun.BaseModel `bun:"table:exercises"`
 		base.Model
+		UserID       *uuid.UUID          `bun:"user_id"` // Owner of an exercise created through sync, nil for the global catalog
 		Name         string              `bun:"name"`
 		Description  string              `bun:"description"`
+		Version      int                 `bun:"version,nullzero,notnull,default:1"` // Incremented on every update
 		MuscleGroups []musclegroup.Model `bun:"m2m:exercise_muscle_groups,join:Exercise=MuscleGroup"`
+		Aliases      []*AliasModel       `bun:"rel:has-many,join:id=exercise_id"`
 	}
 )
+
+// IsOwnedBy reports whether the exercise was created by the user, rather
+// than being part of the global catalog or of another user's exercises.
+func (m *Model) IsOwnedBy(userID uuid.UUID) bool {
+	return m.UserID != nil && *m.UserID == userID
+}
@@ -2,12 +2,15 @@ package exercise
 
 import (
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/google/uuid"
 )
 
 type (
 	ListExercisesQueryParams struct {
 		base.ListQueryParams
+		base.Projection
 		Name             string   `query:"name"`
 		MuscleGroupNames []string `query:"muscle_group_names"`
 	}
@@ -23,4 +26,63 @@ type (
 		Description    string      `json:"description"`
 		MuscleGroupIDs []uuid.UUID `json:"muscle_group_ids"`
 	}
+
+	MergeExercisesRequest struct {
+		SourceIDs []uuid.UUID `json:"source_ids"`
+	}
 )
+
+// NewUpdateExerciseRequest returns the request that would leave an exercise
+// as it is, to which merge patches are applied.
+func NewUpdateExerciseRequest(model *Model) UpdateExerciseRequest {
+	request := UpdateExerciseRequest{
+		Name:           model.Name,
+		Description:    model.Description,
+		MuscleGroupIDs: make([]uuid.UUID, len(model.MuscleGroups)),
+	}
+
+	for index, muscleGroup := range model.MuscleGroups {
+		request.MuscleGroupIDs[index] = muscleGroup.ID
+	}
+
+	return request
+}
+
+func (r *CreateExerciseRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+	exerciseRules(v, r.Name, r.MuscleGroupIDs)
+
+	return v.Errors()
+}
+
+func (r *UpdateExerciseRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+	exerciseRules(v, r.Name, r.MuscleGroupIDs)
+
+	return v.Errors()
+}
+
+func (r *MergeExercisesRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.Check(len(r.SourceIDs) > 0, ErrorSourceIDsIsRequired)
+	v.UUIDs("source_ids", r.SourceIDs).Unique()
+
+	return v.Errors()
+}
+
+func exerciseRules(v *validation.Validator, name string, muscleGroupIDs []uuid.UUID) {
+	v.String("name", name).Required().MaxLength(NameMaxLength)
+	v.UUIDs("muscle_group_ids", muscleGroupIDs).Unique()
+}
+
+func (p *ListExercisesQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	p.Rules(v)
+	p.SortRules(v, SortFields...)
+	p.CursorRules(v)
+	p.ProjectionRules(v, Fields, Expansions)
+
+	return v.Errors()
+}
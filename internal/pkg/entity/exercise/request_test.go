@@ -0,0 +1,89 @@
+package exercise_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateExerciseRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		request  exercise.CreateExerciseRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: exercise.CreateExerciseRequest{
+				Name: "Bench Press",
+			},
+			expected: nil,
+		},
+		{
+			name:     "missing name",
+			request:  exercise.CreateExerciseRequest{},
+			expected: &response.ErrorDetails{exercise.ErrorNameIsRequired},
+		},
+		{
+			name: "blank name",
+			request: exercise.CreateExerciseRequest{
+				Name: "   ",
+			},
+			expected: &response.ErrorDetails{exercise.ErrorNameIsRequired},
+		},
+		{
+			name: "name too long",
+			request: exercise.CreateExerciseRequest{
+				Name: strings.Repeat("a", exercise.NameMaxLength+1),
+			},
+			expected: &response.ErrorDetails{exercise.ErrorNameIsTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestMergeExercisesRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		request  exercise.MergeExercisesRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: exercise.MergeExercisesRequest{
+				SourceIDs: []uuid.UUID{uuid.New()},
+			},
+			expected: nil,
+		},
+		{
+			name:     "missing source ids",
+			request:  exercise.MergeExercisesRequest{},
+			expected: &response.ErrorDetails{exercise.ErrorSourceIDsIsRequired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
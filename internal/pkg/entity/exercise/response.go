@@ -0,0 +1,75 @@
+package exercise
+
+import (
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/google/uuid"
+)
+
+type (
+	ExerciseResponse struct {
+		ID           uuid.UUID                         `json:"id"`
+		UserID       *uuid.UUID                        `json:"user_id,omitempty"` // Owner of a custom exercise
+		Name         string                            `json:"name"`
+		Description  string                            `json:"description"`
+		MuscleGroups []musclegroup.MuscleGroupResponse `json:"muscle_groups,omitempty"` // When loaded
+		Aliases      []string                          `json:"aliases,omitempty"`       // Former names of merged exercises, when loaded
+		CreatedAt    time.Time                         `json:"created_at"`
+		UpdatedAt    time.Time                         `json:"updated_at"`
+	}
+
+	Usage struct {
+		Workouts     int `json:"workouts"`      // Number of workouts that include the exercise
+		ProgressLogs int `json:"progress_logs"` // Number of progress logs recorded for the exercise
+	}
+
+	MovedReferences struct {
+		WorkoutExercises int `json:"workout_exercises"` // Workout exercises repointed to the target
+		ProgressLogs     int `json:"progress_logs"`     // Progress logs repointed to the target
+		MuscleGroups     int `json:"muscle_groups"`     // Muscle group associations added to the target
+		Aliases          int `json:"aliases"`           // Aliases recorded on the target
+	}
+
+	MergeExercisesResponse struct {
+		Target    ExerciseResponse `json:"target"`
+		MergedIDs []uuid.UUID      `json:"merged_ids"`
+		Moved     MovedReferences  `json:"moved"`
+	}
+)
+
+func NewExerciseResponse(model *Model) ExerciseResponse {
+	muscleGroups := make([]musclegroup.MuscleGroupResponse, len(model.MuscleGroups))
+	for index := range model.MuscleGroups {
+		muscleGroups[index] = musclegroup.NewMuscleGroupResponse(&model.MuscleGroups[index])
+	}
+
+	aliases := make([]string, len(model.Aliases))
+	for index, alias := range model.Aliases {
+		aliases[index] = alias.Name
+	}
+
+	return ExerciseResponse{
+		ID:           model.ID,
+		UserID:       model.UserID,
+		Name:         model.Name,
+		Description:  model.Description,
+		MuscleGroups: muscleGroups,
+		Aliases:      aliases,
+		CreatedAt:    model.CreatedAt,
+		UpdatedAt:    model.UpdatedAt,
+	}
+}
+
+func NewExerciseResponses(models []*Model) []ExerciseResponse {
+	responses := make([]ExerciseResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewExerciseResponse(model)
+	}
+
+	return responses
+}
+
+func (u *Usage) IsInUse() bool {
+	return u.Workouts > 0 || u.ProgressLogs > 0
+}
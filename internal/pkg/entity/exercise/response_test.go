@@ -0,0 +1,67 @@
+package exercise_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExerciseResponseContract(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 2, 19, 10, 0, 0, 0, time.UTC)
+	deletedAt := createdAt.Add(time.Hour)
+
+	model := &exercise.Model{
+		Model: base.Model{
+			ID:        uuid.MustParse("6f1c1f5e-3a4c-4c61-9f0a-0b6f0d7c1a01"),
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			DeletedAt: &deletedAt,
+		},
+		Name:        "Bench press",
+		Description: "Flat barbell bench press",
+		MuscleGroups: []musclegroup.Model{{
+			Model: base.Model{ID: uuid.MustParse("6f1c1f5e-3a4c-4c61-9f0a-0b6f0d7c1a02"), CreatedAt: createdAt, UpdatedAt: createdAt},
+			Name:  "Chest",
+		}},
+		Aliases: []*exercise.AliasModel{{Name: "Supino"}},
+	}
+
+	data, err := json.Marshal(exercise.NewExerciseResponse(model))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"id": "6f1c1f5e-3a4c-4c61-9f0a-0b6f0d7c1a01",
+		"name": "Bench press",
+		"description": "Flat barbell bench press",
+		"muscle_groups": [{
+			"id": "6f1c1f5e-3a4c-4c61-9f0a-0b6f0d7c1a02",
+			"name": "Chest",
+			"created_at": "2025-02-19T10:00:00Z",
+			"updated_at": "2025-02-19T10:00:00Z"
+		}],
+		"aliases": ["Supino"],
+		"created_at": "2025-02-19T10:00:00Z",
+		"updated_at": "2025-02-19T10:00:00Z"
+	}`, string(data))
+
+	t.Run("should leave out relations that were not loaded", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(exercise.NewExerciseResponse(&exercise.Model{Model: base.Model{ID: model.ID}, Name: "Squat"}))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"id": "6f1c1f5e-3a4c-4c61-9f0a-0b6f0d7c1a01",
+			"name": "Squat",
+			"description": "",
+			"created_at": "0001-01-01T00:00:00Z",
+			"updated_at": "0001-01-01T00:00:00Z"
+		}`, string(data))
+	})
+}
@@ -0,0 +1,25 @@
+package idempotency
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// Header is the request header carrying the idempotency key
+	Header = "Idempotency-Key"
+
+	// ReplayedHeader marks responses replayed from a stored response
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// KeyMaxLength is the maximum number of characters allowed in a key
+	KeyMaxLength = 255
+)
+
+var (
+	// ErrorKeyIsTooLong is the error message for keys longer than KeyMaxLength
+	ErrorKeyIsTooLong response.ErrorDetail = validation.NewErrorDetail(Header, validation.CodeTooLong, "Idempotency key must be at most 255 characters")
+
+	// ErrorKeyWasReused is the error message for keys sent again with a different request
+	ErrorKeyWasReused response.ErrorDetail = validation.NewErrorDetail(Header, validation.CodeInvalid, "Idempotency key was already used with a different request")
+)
@@ -0,0 +1,39 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	// Model is the first response to a request sent with an idempotency key.
+	// It is reserved, without a response, while the first request runs.
+	Model struct {
+		bun.BaseModel `bun:"table:idempotency_keys,alias:idempotency_key"`
+		UserID        uuid.UUID  `bun:"user_id,pk"`
+		Key           string     `bun:"key,pk"`
+		Fingerprint   string     `bun:"fingerprint"` // Hash of the request the key was first used with
+		StatusCode    *int       `bun:"status_code"`
+		ContentType   *string    `bun:"content_type"`
+		Body          []byte     `bun:"body"`
+		CreatedAt     time.Time  `bun:"created_at"`
+		ExpiresAt     time.Time  `bun:"expires_at"`
+		LockedUntil   *time.Time `bun:"locked_until"` // When a reservation lapses if the first request never answers
+	}
+)
+
+func (m *Model) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// IsCompleted reports whether the response of the first request is stored.
+func (m *Model) IsCompleted() bool {
+	return m.StatusCode != nil
+}
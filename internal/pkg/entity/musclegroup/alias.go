@@ -0,0 +1,29 @@
+package musclegroup
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	AliasModel struct {
+		bun.BaseModel `bun:"muscle_group_aliases"`
+		ID            uuid.UUID `json:"id" bun:"id,pk"`
+		MuscleGroupID uuid.UUID `json:"muscle_group_id" bun:"muscle_group_id"`
+		Name          string    `json:"name" bun:"name"`
+		CreatedAt     time.Time `json:"created_at" bun:"created_at"`
+	}
+)
+
+func (m *AliasModel) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.ID = uuid.New()
+		m.CreatedAt = time.Now()
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package musclegroup
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// NameMaxLength is the maximum number of characters allowed in a muscle group name
+	NameMaxLength = 255
+)
+
+var (
+	// ErrorNameIsRequired is the error message for name is required
+	ErrorNameIsRequired response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeRequired, "Name is required")
+	// ErrorNameIsTooLong is the error message for names longer than NameMaxLength
+	ErrorNameIsTooLong response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeTooLong, "Name must have at most 255 characters")
+	// ErrorNameAlreadyExists is the error message for a name already used by another muscle group
+	ErrorNameAlreadyExists response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeAlreadyExists, "A muscle group with this name already exists")
+	// ErrorReplaceWithNotFound is the error message for a replacement muscle group that does not exist
+	ErrorReplaceWithNotFound response.ErrorDetail = validation.NewErrorDetail("replace_with", validation.CodeNotFound, "Replacement muscle group not found")
+	// ErrorReplaceWithIsSame is the error message for replacing a muscle group with itself
+	ErrorReplaceWithIsSame response.ErrorDetail = validation.NewErrorDetail("replace_with", validation.CodeInvalid, "A muscle group cannot replace itself")
+	// ErrorSourceIDsIsRequired is the error message for source ids is required
+	ErrorSourceIDsIsRequired response.ErrorDetail = validation.NewErrorDetail("source_ids", validation.CodeRequired, "At least one source muscle group is required")
+	// ErrorSourceIDsContainsTarget is the error message for merging a muscle group into itself
+	ErrorSourceIDsContainsTarget response.ErrorDetail = validation.NewErrorDetail("source_ids", validation.CodeInvalid, "A muscle group cannot be merged into itself")
+	// ErrorSourceIDsNotFound is the error message for source muscle groups that do not exist
+	ErrorSourceIDsNotFound response.ErrorDetail = validation.NewErrorDetail("source_ids", validation.CodeNotFound, "One or more source muscle groups were not found")
+)
+
+// SortFields are the fields lists of muscle groups can be sorted by.
+var SortFields = []string{"name", "created_at", "updated_at"}
@@ -9,6 +9,8 @@ type (
 	Model struct {
 		bun.BaseModel `bun:"muscle_groups"`
 		base.Model
-		Name string `bun:"name"`
+		Name    string        `bun:"name"`
+		Version int           `bun:"version,nullzero,notnull,default:1"` // Incremented on every update
+		Aliases []*AliasModel `bun:"rel:has-many,join:id=muscle_group_id"`
 	}
 )
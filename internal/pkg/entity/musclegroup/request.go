@@ -1,6 +1,11 @@
 package musclegroup
 
-import "github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
 
 type (
 	CreateMuscleGroupRequest struct {
@@ -15,4 +20,51 @@ type (
 		base.ListQueryParams
 		Name string `query:"name"`
 	}
+
+	MergeMuscleGroupsRequest struct {
+		SourceIDs []uuid.UUID `json:"source_ids"`
+	}
 )
+
+// NewUpdateMuscleGroupRequest returns the request that would leave a muscle
+// group as it is, to which merge patches are applied.
+func NewUpdateMuscleGroupRequest(model *Model) UpdateMuscleGroupRequest {
+	return UpdateMuscleGroupRequest{Name: model.Name}
+}
+
+func (r *CreateMuscleGroupRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+	nameRules(v, r.Name)
+
+	return v.Errors()
+}
+
+func (r *UpdateMuscleGroupRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+	nameRules(v, r.Name)
+
+	return v.Errors()
+}
+
+func (r *MergeMuscleGroupsRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.Check(len(r.SourceIDs) > 0, ErrorSourceIDsIsRequired)
+	v.UUIDs("source_ids", r.SourceIDs).Unique()
+
+	return v.Errors()
+}
+
+func nameRules(v *validation.Validator, name string) {
+	v.String("name", name).Required().MaxLength(NameMaxLength)
+}
+
+func (p *ListMuscleGroupsQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	p.Rules(v)
+	p.SortRules(v, SortFields...)
+	p.CursorRules(v)
+
+	return v.Errors()
+}
@@ -0,0 +1,49 @@
+package musclegroup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMuscleGroupRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		request  musclegroup.CreateMuscleGroupRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: musclegroup.CreateMuscleGroupRequest{
+				Name: "Chest",
+			},
+			expected: nil,
+		},
+		{
+			name:     "missing name",
+			request:  musclegroup.CreateMuscleGroupRequest{},
+			expected: &response.ErrorDetails{musclegroup.ErrorNameIsRequired},
+		},
+		{
+			name: "name too long",
+			request: musclegroup.CreateMuscleGroupRequest{
+				Name: strings.Repeat("a", musclegroup.NameMaxLength+1),
+			},
+			expected: &response.ErrorDetails{musclegroup.ErrorNameIsTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package musclegroup
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	MuscleGroupResponse struct {
+		ID        uuid.UUID `json:"id"`
+		Name      string    `json:"name"`
+		Aliases   []string  `json:"aliases,omitempty"` // Former names of merged muscle groups, when loaded
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	Usage struct {
+		Exercises int `json:"exercises"` // Number of exercises associated with the muscle group
+		Workouts  int `json:"workouts"`  // Number of workouts that include those exercises
+	}
+
+	MovedReferences struct {
+		Exercises int `json:"exercises"` // Exercise associations repointed to the target
+		Aliases   int `json:"aliases"`   // Aliases recorded on the target
+	}
+
+	MergeMuscleGroupsResponse struct {
+		Target    MuscleGroupResponse `json:"target"`
+		MergedIDs []uuid.UUID         `json:"merged_ids"`
+		Moved     MovedReferences     `json:"moved"`
+	}
+)
+
+func NewMuscleGroupResponse(model *Model) MuscleGroupResponse {
+	aliases := make([]string, len(model.Aliases))
+	for index, alias := range model.Aliases {
+		aliases[index] = alias.Name
+	}
+
+	return MuscleGroupResponse{
+		ID:        model.ID,
+		Name:      model.Name,
+		Aliases:   aliases,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+func NewMuscleGroupResponses(models []*Model) []MuscleGroupResponse {
+	responses := make([]MuscleGroupResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewMuscleGroupResponse(model)
+	}
+
+	return responses
+}
+
+func (u *Usage) IsInUse() bool {
+	return u.Exercises > 0 || u.Workouts > 0
+}
@@ -0,0 +1,43 @@
+package offlinesync
+
+import (
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// TypeWorkout identifies a change to a workout
+	TypeWorkout = "workout"
+	// TypeSession identifies a change to a workout session
+	TypeSession = "session"
+	// TypeExercise identifies a change to an exercise
+	TypeExercise = "exercise"
+
+	// MaxChanges is the largest number of changes of each type pushed at once
+	MaxChanges = 500
+
+	// ChangeTokenOverlap is how far before the read a change token starts.
+	// Transactions still running during the read commit changes stamped
+	// before it, so the next pull sends the changes of this window again
+	// rather than missing them.
+	ChangeTokenOverlap = time.Minute
+)
+
+var (
+	// ErrorChangeTokenIsInvalid is the error message for change tokens that were not issued by the server
+	ErrorChangeTokenIsInvalid response.ErrorDetail = validation.NewErrorDetail("since", validation.CodeInvalid, "Change token is invalid")
+	// ErrorChangeTokenIsExpired is the error message for change tokens older than the trash retention, whose deletions may have been purged
+	ErrorChangeTokenIsExpired response.ErrorDetail = validation.NewErrorDetail("since", validation.CodeExpired, "Change token is expired, pull the full feed again")
+	// ErrorWorkoutsIsTooLong is the error message for pushes of more than MaxChanges workouts
+	ErrorWorkoutsIsTooLong response.ErrorDetail = validation.NewErrorDetail("workouts", validation.CodeTooLarge, "At most 500 workouts can be pushed at once")
+	// ErrorSessionsIsTooLong is the error message for pushes of more than MaxChanges sessions
+	ErrorSessionsIsTooLong response.ErrorDetail = validation.NewErrorDetail("sessions", validation.CodeTooLarge, "At most 500 sessions can be pushed at once")
+	// ErrorExercisesIsTooLong is the error message for pushes of more than MaxChanges exercises
+	ErrorExercisesIsTooLong response.ErrorDetail = validation.NewErrorDetail("exercises", validation.CodeTooLarge, "At most 500 exercises can be pushed at once")
+	// ErrorStartedAtIsRequired is the error message for sessions pushed without a start time
+	ErrorStartedAtIsRequired response.ErrorDetail = validation.NewErrorDetail("started_at", validation.CodeRequired, "Started at is required")
+	// ErrorCompletedBeforeStarted is the error message for sessions completed before they started
+	ErrorCompletedBeforeStarted response.ErrorDetail = validation.NewErrorDetail("completed_at", validation.CodeInvalid, "Completed at must not be before started at")
+)
@@ -0,0 +1,81 @@
+package offlinesync
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+)
+
+type (
+	// ChangeSet holds the records changed since a change token, with the
+	// ones deleted since then as tombstones.
+	ChangeSet struct {
+		Workouts   []*workout.Model
+		Sessions   []*workouthistory.Model
+		Exercises  []*exercise.Model
+		Tombstones []*Tombstone
+	}
+
+	// Tombstone records the deletion of a record, read from its soft-deleted
+	// row.
+	Tombstone struct {
+		Type      string    `json:"type" bun:"type"`
+		ID        uuid.UUID `json:"id" bun:"id"`
+		DeletedAt time.Time `json:"deleted_at" bun:"deleted_at"`
+	}
+
+	// ChangeToken marks the point of the change feed a client has read up
+	// to.
+	ChangeToken struct {
+		ReadAt time.Time `json:"read_at"`
+	}
+)
+
+// NewChangeToken returns the token of a feed read at readAt.
+func NewChangeToken(readAt time.Time) ChangeToken {
+	return ChangeToken{ReadAt: readAt.Add(-ChangeTokenOverlap)}
+}
+
+// Expired reports whether the trash may have been purged since the token
+// was read, taking the tombstones of the deletions made since with it.
+func (t ChangeToken) Expired(now time.Time, retention time.Duration) bool {
+	return t.ReadAt.Before(now.Add(-retention))
+}
+
+// EncodeChangeToken encodes a change token into the opaque string returned
+// to clients.
+func EncodeChangeToken(token ChangeToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeChangeToken decodes a change token returned by EncodeChangeToken.
+func DecodeChangeToken(value string) (*ChangeToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &ChangeToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// SameVersion reports whether the updated_at a client based a change on is
+// the one stored. Timestamps are compared at the microsecond precision of
+// the database.
+func SameVersion(base *time.Time, updatedAt time.Time) bool {
+	return base != nil && base.Round(time.Microsecond).Equal(updatedAt.Round(time.Microsecond))
+}
@@ -0,0 +1,46 @@
+package offlinesync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeTokenExpired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 2, 24, 9, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		token    offlinesync.ChangeToken
+		expected bool
+	}{
+		{
+			name:     "token read within the retention",
+			token:    offlinesync.NewChangeToken(now.Add(-time.Hour)),
+			expected: false,
+		},
+		{
+			name:     "token read as the retention ends",
+			token:    offlinesync.ChangeToken{ReadAt: now.Add(-retention)},
+			expected: false,
+		},
+		{
+			name:     "token read before the retention",
+			token:    offlinesync.NewChangeToken(now.Add(-retention)),
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.token.Expired(now, retention))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package offlinesync
+
+import (
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	// PullQueryParams reads the change feed. Without Since the feed holds
+	// every record, otherwise only the ones changed since that token.
+	PullQueryParams struct {
+		Since string `query:"since"`
+	}
+
+	// PushRequest sends the changes a client made offline. Records are
+	// created with the id the client gave them, and UpdatedAt is the
+	// updated_at of the copy a change was made to, omitted for records
+	// created offline.
+	PushRequest struct {
+		Workouts  []WorkoutChange  `json:"workouts"`
+		Sessions  []SessionChange  `json:"sessions"`
+		Exercises []ExerciseChange `json:"exercises"`
+	}
+
+	// WorkoutChange creates, renames or deletes a workout. Its exercises are
+	// edited through the workout routes.
+	WorkoutChange struct {
+		ID        uuid.UUID  `json:"id"`
+		Name      string     `json:"name"`
+		UpdatedAt *time.Time `json:"updated_at"`
+		Deleted   bool       `json:"deleted"`
+	}
+
+	SessionChange struct {
+		ID          uuid.UUID  `json:"id"`
+		WorkoutID   uuid.UUID  `json:"workout_id"`
+		StartedAt   time.Time  `json:"started_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+		Notes       *string    `json:"notes"`
+		UpdatedAt   *time.Time `json:"updated_at"`
+		Deleted     bool       `json:"deleted"`
+	}
+
+	ExerciseChange struct {
+		ID          uuid.UUID  `json:"id"`
+		Name        string     `json:"name"`
+		Description string     `json:"description"`
+		UpdatedAt   *time.Time `json:"updated_at"`
+		Deleted     bool       `json:"deleted"`
+	}
+)
+
+func (p *PullQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	if p.Since != "" {
+		token, err := DecodeChangeToken(p.Since)
+		v.Check(err == nil && !token.ReadAt.IsZero(), ErrorChangeTokenIsInvalid)
+	}
+
+	return v.Errors()
+}
+
+// SinceToken returns the change token the feed is read from, nil for a full
+// feed.
+func (p *PullQueryParams) SinceToken() *ChangeToken {
+	if p.Since == "" {
+		return nil
+	}
+
+	token, err := DecodeChangeToken(p.Since)
+	if err != nil {
+		return nil
+	}
+
+	return token
+}
+
+func (r *PushRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.Check(len(r.Workouts) <= MaxChanges, ErrorWorkoutsIsTooLong)
+	v.Check(len(r.Sessions) <= MaxChanges, ErrorSessionsIsTooLong)
+	v.Check(len(r.Exercises) <= MaxChanges, ErrorExercisesIsTooLong)
+
+	v.UUIDs("workouts", changeIDs(r.Workouts, func(change WorkoutChange) uuid.UUID { return change.ID })).Unique()
+	validation.Each(v, "workouts", r.Workouts, func(v *validation.Validator, change WorkoutChange) {
+		v.UUID("id", change.ID).Required()
+		if !change.Deleted {
+			v.String("name", change.Name).Required().MaxLength(workout.NameMaxLength)
+		}
+	})
+
+	v.UUIDs("sessions", changeIDs(r.Sessions, func(change SessionChange) uuid.UUID { return change.ID })).Unique()
+	validation.Each(v, "sessions", r.Sessions, func(v *validation.Validator, change SessionChange) {
+		v.UUID("id", change.ID).Required()
+		if !change.Deleted {
+			v.UUID("workout_id", change.WorkoutID).Required()
+			v.Check(!change.StartedAt.IsZero(), ErrorStartedAtIsRequired)
+			v.Check(change.CompletedAt == nil || !change.CompletedAt.Before(change.StartedAt), ErrorCompletedBeforeStarted)
+			v.OptionalString("notes", change.Notes).MaxLength(workouthistory.NotesMaxLength)
+		}
+	})
+
+	v.UUIDs("exercises", changeIDs(r.Exercises, func(change ExerciseChange) uuid.UUID { return change.ID })).Unique()
+	validation.Each(v, "exercises", r.Exercises, func(v *validation.Validator, change ExerciseChange) {
+		v.UUID("id", change.ID).Required()
+		if !change.Deleted {
+			v.String("name", change.Name).Required().MaxLength(exercise.NameMaxLength)
+		}
+	})
+
+	return v.Errors()
+}
+
+// changeIDs returns the ids of the records changes apply to, so a record is
+// changed at most once per push.
+func changeIDs[T any](changes []T, id func(change T) uuid.UUID) []uuid.UUID {
+	ids := make([]uuid.UUID, len(changes))
+	for index, change := range changes {
+		ids[index] = id(change)
+	}
+
+	return ids
+}
@@ -0,0 +1,139 @@
+package offlinesync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullQueryParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	changeToken := offlinesync.ChangeToken{ReadAt: time.Date(2025, 2, 24, 9, 0, 0, 0, time.UTC)}
+	token, err := offlinesync.EncodeChangeToken(changeToken)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name     string
+		params   offlinesync.PullQueryParams
+		since    *offlinesync.ChangeToken
+		expected *response.ErrorDetails
+	}{
+		{
+			name:   "full feed",
+			params: offlinesync.PullQueryParams{},
+		},
+		{
+			name:   "feed since a change token",
+			params: offlinesync.PullQueryParams{Since: token},
+			since:  &changeToken,
+		},
+		{
+			name:     "token not issued by the server",
+			params:   offlinesync.PullQueryParams{Since: "2025-02-24"},
+			expected: &response.ErrorDetails{offlinesync.ErrorChangeTokenIsInvalid},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.params.Validate())
+			if test.expected == nil {
+				assert.Equal(t, test.since, test.params.SinceToken())
+			}
+		})
+	}
+}
+
+func TestPushRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	startedAt := time.Date(2025, 2, 24, 9, 0, 0, 0, time.UTC)
+	completedBefore := startedAt.Add(-time.Minute)
+
+	completedBeforeStarted := offlinesync.ErrorCompletedBeforeStarted
+	completedBeforeStarted.Field = "sessions[0].completed_at"
+
+	startedAtIsRequired := offlinesync.ErrorStartedAtIsRequired
+	startedAtIsRequired.Field = "sessions[0].started_at"
+
+	tests := []struct {
+		name     string
+		request  offlinesync.PushRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: offlinesync.PushRequest{
+				Workouts:  []offlinesync.WorkoutChange{{ID: id, Name: "Push Day"}},
+				Sessions:  []offlinesync.SessionChange{{ID: uuid.New(), WorkoutID: id, StartedAt: startedAt}},
+				Exercises: []offlinesync.ExerciseChange{{ID: uuid.New(), Deleted: true}},
+			},
+		},
+		{
+			name: "duplicate changes to a record",
+			request: offlinesync.PushRequest{
+				Workouts: []offlinesync.WorkoutChange{{ID: id, Name: "Push Day"}, {ID: id, Deleted: true}},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("workouts", validation.CodeDuplicate, "Workouts must not contain duplicates"),
+			},
+		},
+		{
+			name: "session without a start",
+			request: offlinesync.PushRequest{
+				Sessions: []offlinesync.SessionChange{{ID: uuid.New(), WorkoutID: id}},
+			},
+			expected: &response.ErrorDetails{startedAtIsRequired},
+		},
+		{
+			name: "session completed before it started",
+			request: offlinesync.PushRequest{
+				Sessions: []offlinesync.SessionChange{{ID: uuid.New(), WorkoutID: id, StartedAt: startedAt, CompletedAt: &completedBefore}},
+			},
+			expected: &response.ErrorDetails{completedBeforeStarted},
+		},
+		{
+			name: "too many changes",
+			request: offlinesync.PushRequest{
+				Exercises: func() []offlinesync.ExerciseChange {
+					changes := make([]offlinesync.ExerciseChange, offlinesync.MaxChanges+1)
+					for index := range changes {
+						changes[index] = offlinesync.ExerciseChange{ID: uuid.New(), Deleted: true}
+					}
+
+					return changes
+				}(),
+			},
+			expected: &response.ErrorDetails{offlinesync.ErrorExercisesIsTooLong},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.request.Validate())
+		})
+	}
+}
+
+func TestSameVersion(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Date(2025, 2, 24, 9, 0, 0, 123456000, time.UTC)
+	read := time.Date(2025, 2, 24, 9, 0, 0, 123456400, time.UTC)
+	older := updatedAt.Add(-time.Second)
+
+	assert.True(t, offlinesync.SameVersion(&read, updatedAt))
+	assert.False(t, offlinesync.SameVersion(&older, updatedAt))
+	assert.False(t, offlinesync.SameVersion(nil, updatedAt))
+}
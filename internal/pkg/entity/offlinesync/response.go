@@ -0,0 +1,50 @@
+package offlinesync
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+)
+
+type (
+	// Changes are records to store on the client, replacing its copies, and
+	// deletions to apply to it.
+	Changes struct {
+		Workouts  []workout.WorkoutResponse        `json:"workouts"`
+		Sessions  []workouthistory.SessionResponse `json:"sessions"`
+		Exercises []exercise.ExerciseResponse      `json:"exercises"`
+		Deleted   []*Tombstone                     `json:"deleted"`
+	}
+
+	PullResponse struct {
+		Changes
+		ChangeToken string `json:"change_token"` // Sent as since by the next pull
+	}
+
+	PushResponse struct {
+		Applied   []Ref   `json:"applied"`
+		Conflicts []Ref   `json:"conflicts"` // Changes based on an outdated copy, which were not applied
+		Current   Changes `json:"current"`   // Server copies of the conflicting records
+	}
+
+	// Ref identifies a pushed record.
+	Ref struct {
+		Type string    `json:"type"`
+		ID   uuid.UUID `json:"id"`
+	}
+)
+
+func NewChanges(set *ChangeSet) Changes {
+	tombstones := set.Tombstones
+	if tombstones == nil {
+		tombstones = []*Tombstone{}
+	}
+
+	return Changes{
+		Workouts:  workout.NewWorkoutResponses(set.Workouts),
+		Sessions:  workouthistory.NewSessionResponses(set.Sessions),
+		Exercises: exercise.NewExerciseResponses(set.Exercises),
+		Deleted:   tombstones,
+	}
+}
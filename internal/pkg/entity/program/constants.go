@@ -0,0 +1,51 @@
+package program
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// NameMaxLength is the maximum number of characters allowed in a program name
+	NameMaxLength = 255
+
+	// DescriptionMaxLength is the maximum number of characters allowed in a program description
+	DescriptionMaxLength = 2000
+
+	// MaxWeeks is the longest program, in weeks, that can be created
+	MaxWeeks = 52
+
+	// DaysPerWeek is the number of days in a program week
+	DaysPerWeek = 7
+
+	// DefaultTrainingMaxPercentage is the share of the estimated one rep max
+	// used as training max when a program does not set one
+	DefaultTrainingMaxPercentage = 90.0
+
+	// MinTrainingMaxPercentage is the lowest training max percentage allowed
+	MinTrainingMaxPercentage = 50.0
+
+	// MaxTrainingMaxPercentage is the highest training max percentage allowed
+	MaxTrainingMaxPercentage = 100.0
+
+	// MaxIntensityPercentage is the highest load, as a percentage of the
+	// training max, a week can prescribe
+	MaxIntensityPercentage = 150.0
+
+	// WeightIncrement is the step prescribed weights are rounded to
+	WeightIncrement = 2.5
+)
+
+var (
+	// ErrorWeeksIsRequired is the error message for programs without weeks
+	ErrorWeeksIsRequired response.ErrorDetail = validation.NewErrorDetail("weeks", validation.CodeRequired, "At least one week is required")
+	// ErrorWeeksContainsDuplicates is the error message for programs repeating a week number
+	ErrorWeeksContainsDuplicates response.ErrorDetail = validation.NewErrorDetail("weeks", validation.CodeDuplicate, "Week numbers must not repeat")
+	// ErrorDaysContainsDuplicates is the error message for weeks repeating a day number
+	ErrorDaysContainsDuplicates response.ErrorDetail = validation.NewErrorDetail("days", validation.CodeDuplicate, "Day numbers must not repeat within a week")
+	// ErrorWorkoutsNotFound is the error message for days mapped to workouts the user does not own
+	ErrorWorkoutsNotFound response.ErrorDetail = validation.NewErrorDetail("weeks", validation.CodeNotFound, "One or more workouts were not found")
+)
+
+// SortFields are the fields lists of programs can be sorted by.
+var SortFields = []string{"name", "created_at", "updated_at"}
@@ -0,0 +1,121 @@
+package program
+
+import (
+	"context"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	// Model is a multi-week training program. Each week prescribes the load
+	// as a percentage of the training max and maps days to workouts.
+	Model struct {
+		bun.BaseModel `bun:"table:programs,alias:program"`
+		base.Model
+		UserID                uuid.UUID    `bun:"user_id"`
+		Name                  string       `bun:"name"`
+		Description           *string      `bun:"description"`
+		TrainingMaxPercentage float64      `bun:"training_max_percentage"`
+		Weeks                 []*WeekModel `bun:"rel:has-many,join:id=program_id"`
+	}
+
+	WeekModel struct {
+		bun.BaseModel       `bun:"table:program_weeks,alias:program_week"`
+		ID                  uuid.UUID   `bun:"id,pk"`
+		ProgramID           uuid.UUID   `bun:"program_id"`
+		WeekNumber          int         `bun:"week_number"`
+		IntensityPercentage float64     `bun:"intensity_percentage"`
+		Sets                *int        `bun:"sets"`
+		Repetitions         *int        `bun:"repetitions"`
+		Notes               *string     `bun:"notes"`
+		Days                []*DayModel `bun:"rel:has-many,join:id=program_week_id"`
+	}
+
+	DayModel struct {
+		bun.BaseModel `bun:"table:program_days,alias:program_day"`
+		ID            uuid.UUID      `bun:"id,pk"`
+		ProgramWeekID uuid.UUID      `bun:"program_week_id"`
+		DayNumber     int            `bun:"day_number"`
+		WorkoutID     uuid.UUID      `bun:"workout_id"`
+		Workout       *workout.Model `bun:"rel:belongs-to,join:workout_id=id"`
+	}
+
+	// EnrollmentModel follows a program from a start date. A user has at
+	// most one active enrollment; ended enrollments are soft deleted.
+	EnrollmentModel struct {
+		bun.BaseModel `bun:"table:program_enrollments,alias:program_enrollment"`
+		base.Model
+		UserID    uuid.UUID `bun:"user_id"`
+		ProgramID uuid.UUID `bun:"program_id"`
+		StartDate time.Time `bun:"start_date,type:date"`
+		Program   *Model    `bun:"rel:belongs-to,join:program_id=id"`
+	}
+)
+
+func (m *WeekModel) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.ID = uuid.New()
+	}
+
+	return nil
+}
+
+func (m *DayModel) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.ID = uuid.New()
+	}
+
+	return nil
+}
+
+// Week returns the week with the given number, or nil when the program has
+// no such week.
+func (m *Model) Week(number int) *WeekModel {
+	for _, week := range m.Weeks {
+		if week.WeekNumber == number {
+			return week
+		}
+	}
+
+	return nil
+}
+
+// Day returns the day with the given number, or nil when it is a rest day.
+func (m *WeekModel) Day(number int) *DayModel {
+	for _, day := range m.Days {
+		if day.DayNumber == number {
+			return day
+		}
+	}
+
+	return nil
+}
+
+// Length returns the number of weeks the program runs for, which is the
+// highest week number.
+func (m *Model) Length() int {
+	length := 0
+	for _, week := range m.Weeks {
+		length = max(length, week.WeekNumber)
+	}
+
+	return length
+}
+
+// Position returns the week and day numbers, starting at 1, that date falls
+// on for an enrollment, or ok false when date is before the start date.
+func (m *EnrollmentModel) Position(date time.Time) (week int, day int, ok bool) {
+	if date.Before(m.StartDate) {
+		return 0, 0, false
+	}
+
+	elapsedDays := int(date.Sub(m.StartDate).Hours() / 24)
+
+	return elapsedDays/DaysPerWeek + 1, elapsedDays%DaysPerWeek + 1, true
+}
@@ -0,0 +1,122 @@
+package program_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrollmentModelPosition(t *testing.T) {
+	t.Parallel()
+
+	startDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	enrollment := program.EnrollmentModel{StartDate: startDate}
+
+	tests := []struct {
+		name         string
+		date         time.Time
+		expectedWeek int
+		expectedDay  int
+		expectedOK   bool
+	}{
+		{name: "before the start date", date: startDate.AddDate(0, 0, -1), expectedOK: false},
+		{name: "start date", date: startDate, expectedWeek: 1, expectedDay: 1, expectedOK: true},
+		{name: "last day of the first week", date: startDate.AddDate(0, 0, 6), expectedWeek: 1, expectedDay: 7, expectedOK: true},
+		{name: "first day of the third week", date: startDate.AddDate(0, 0, 14), expectedWeek: 3, expectedDay: 1, expectedOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			week, day, ok := enrollment.Position(tt.date)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedWeek, week)
+			assert.Equal(t, tt.expectedDay, day)
+		})
+	}
+}
+
+func TestModelWeeks(t *testing.T) {
+	t.Parallel()
+
+	model := program.Model{
+		Weeks: []*program.WeekModel{
+			{WeekNumber: 1, Days: []*program.DayModel{{DayNumber: 1}, {DayNumber: 4}}},
+			{WeekNumber: 3},
+		},
+	}
+
+	assert.Equal(t, 3, model.Length())
+	assert.NotNil(t, model.Week(1).Day(4))
+	assert.Nil(t, model.Week(1).Day(2))
+	assert.Nil(t, model.Week(2))
+}
+
+func TestPrescribe(t *testing.T) {
+	t.Parallel()
+
+	benchPressID, rowID := uuid.New(), uuid.New()
+	templateWeight := 40.0
+	templateRepetitions := 10
+	weekSets := 5
+	weekRepetitions := 3
+
+	workoutExercises := []*workoutexercise.Model{
+		{
+			ExerciseID:  benchPressID,
+			Exercise:    &exercise.Model{Name: "Bench press"},
+			Sets:        3,
+			Repetitions: &templateRepetitions,
+			Weight:      &templateWeight,
+			RestTime:    120,
+		},
+		{
+			ExerciseID:  rowID,
+			Sets:        4,
+			Repetitions: &templateRepetitions,
+			Weight:      &templateWeight,
+			RestTime:    90,
+		},
+	}
+
+	t.Run("should keep the workout prescription when the week does not override it", func(t *testing.T) {
+		t.Parallel()
+
+		week := &program.WeekModel{IntensityPercentage: 85}
+		exercises := program.Prescribe(week, 90, workoutExercises, map[uuid.UUID]float64{})
+
+		assert.Len(t, exercises, 2)
+		assert.Equal(t, "Bench press", exercises[0].ExerciseName)
+		assert.Equal(t, 3, exercises[0].Sets)
+		assert.Equal(t, &templateRepetitions, exercises[0].Repetitions)
+		assert.Equal(t, &templateWeight, exercises[0].Weight)
+		assert.Nil(t, exercises[0].TrainingMax)
+	})
+
+	t.Run("should derive weights from the one rep max and apply week overrides", func(t *testing.T) {
+		t.Parallel()
+
+		week := &program.WeekModel{IntensityPercentage: 85, Sets: &weekSets, Repetitions: &weekRepetitions}
+		exercises := program.Prescribe(week, 90, workoutExercises, map[uuid.UUID]float64{benchPressID: 100})
+
+		assert.Equal(t, 5, exercises[0].Sets)
+		assert.Equal(t, &weekRepetitions, exercises[0].Repetitions)
+		assert.Equal(t, 90.0, *exercises[0].TrainingMax)
+		assert.Equal(t, 77.5, *exercises[0].Weight)
+		assert.Equal(t, &templateWeight, exercises[1].Weight)
+	})
+}
+
+func TestTrainingMaxAndPrescribedWeight(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 112.5, program.TrainingMax(125, 90))
+	assert.Equal(t, 72.5, program.PrescribedWeight(112.5, 65))
+	assert.Equal(t, 0.0, program.PrescribedWeight(112.5, 0))
+}
@@ -0,0 +1,146 @@
+package program
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	CreateProgramRequest struct {
+		Name                  string        `json:"name"`
+		Description           *string       `json:"description"`
+		TrainingMaxPercentage *float64      `json:"training_max_percentage"`
+		Weeks                 []WeekRequest `json:"weeks"`
+	}
+
+	WeekRequest struct {
+		Week                int          `json:"week"`
+		IntensityPercentage float64      `json:"intensity_percentage"`
+		Sets                *int         `json:"sets"`
+		Repetitions         *int         `json:"repetitions"`
+		Notes               *string      `json:"notes"`
+		Days                []DayRequest `json:"days"`
+	}
+
+	DayRequest struct {
+		Day       int       `json:"day"`
+		WorkoutID uuid.UUID `json:"workout_id"`
+	}
+
+	EnrollRequest struct {
+		StartDate string `json:"start_date"`
+	}
+
+	TodayQueryParams struct {
+		Date string `json:"date" query:"date"`
+	}
+
+	ListProgramsQueryParams struct {
+		base.ListQueryParams
+	}
+)
+
+func (r *CreateProgramRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("name", r.Name).Required().MaxLength(NameMaxLength)
+	v.OptionalString("description", r.Description).MaxLength(DescriptionMaxLength)
+	v.OptionalFloat("training_max_percentage", r.TrainingMaxPercentage).
+		Min(MinTrainingMaxPercentage).
+		Max(MaxTrainingMaxPercentage)
+	v.Check(len(r.Weeks) > 0, ErrorWeeksIsRequired)
+	validation.Each(v, "weeks", r.Weeks, func(v *validation.Validator, week WeekRequest) {
+		v.Int("week", week.Week).Min(1).Max(MaxWeeks)
+		v.Float("intensity_percentage", week.IntensityPercentage).Min(0).Max(MaxIntensityPercentage)
+		v.OptionalInt("sets", week.Sets).Min(1)
+		v.OptionalInt("repetitions", week.Repetitions).Min(1)
+		validation.Each(v, "days", week.Days, func(v *validation.Validator, day DayRequest) {
+			v.Int("day", day.Day).Min(1).Max(DaysPerWeek)
+			v.UUID("workout_id", day.WorkoutID).Required()
+		})
+		v.Check(isUnique(week.Days, func(day DayRequest) int { return day.Day }), ErrorDaysContainsDuplicates)
+	})
+	v.Check(isUnique(r.Weeks, func(week WeekRequest) int { return week.Week }), ErrorWeeksContainsDuplicates)
+
+	return v.Errors()
+}
+
+// WorkoutIDs returns the distinct workouts the program days are mapped to.
+func (r *CreateProgramRequest) WorkoutIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{})
+	var ids []uuid.UUID
+
+	for _, week := range r.Weeks {
+		for _, day := range week.Days {
+			if _, ok := seen[day.WorkoutID]; ok {
+				continue
+			}
+
+			seen[day.WorkoutID] = struct{}{}
+			ids = append(ids, day.WorkoutID)
+		}
+	}
+
+	return ids
+}
+
+func (r *EnrollRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("start_date", r.StartDate).Required().Date()
+
+	return v.Errors()
+}
+
+// ParseStartDate returns the requested start date. It must only be called
+// after Validate succeeded.
+func (r *EnrollRequest) ParseStartDate() time.Time {
+	date, _ := time.Parse(validation.DateLayout, strings.TrimSpace(r.StartDate))
+	return date
+}
+
+func (p *TodayQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("date", p.Date).Date()
+
+	return v.Errors()
+}
+
+// ParseDate returns the requested date, or today when none was requested. It
+// must only be called after Validate succeeded.
+func (p *TodayQueryParams) ParseDate(today time.Time) time.Time {
+	if p.Date == "" {
+		return today
+	}
+
+	date, _ := time.Parse(validation.DateLayout, p.Date)
+	return date
+}
+
+func isUnique[T any](items []T, key func(item T) int) bool {
+	seen := make(map[int]struct{}, len(items))
+	for _, item := range items {
+		if _, ok := seen[key(item)]; ok {
+			return false
+		}
+
+		seen[key(item)] = struct{}{}
+	}
+
+	return true
+}
+
+func (p *ListProgramsQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	p.Rules(v)
+	p.SortRules(v, SortFields...)
+
+	return v.Errors()
+}
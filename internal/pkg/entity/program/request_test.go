@@ -0,0 +1,117 @@
+package program_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateProgramRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	workoutID := uuid.New()
+	trainingMaxPercentage := 40.0
+
+	tests := []struct {
+		name     string
+		request  program.CreateProgramRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: program.CreateProgramRequest{
+				Name: "5/3/1",
+				Weeks: []program.WeekRequest{
+					{Week: 1, IntensityPercentage: 85, Days: []program.DayRequest{{Day: 1, WorkoutID: workoutID}, {Day: 3, WorkoutID: workoutID}}},
+					{Week: 2, IntensityPercentage: 90, Days: []program.DayRequest{{Day: 1, WorkoutID: workoutID}}},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:    "missing fields",
+			request: program.CreateProgramRequest{},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("name", validation.CodeRequired, "Name is required"),
+				program.ErrorWeeksIsRequired,
+			},
+		},
+		{
+			name: "invalid weeks and days",
+			request: program.CreateProgramRequest{
+				Name:                  "5/3/1",
+				TrainingMaxPercentage: &trainingMaxPercentage,
+				Weeks: []program.WeekRequest{
+					{Week: 1, IntensityPercentage: 85, Days: []program.DayRequest{{Day: 8, WorkoutID: workoutID}}},
+					{Week: 1, IntensityPercentage: 90, Days: []program.DayRequest{{Day: 2, WorkoutID: workoutID}, {Day: 2}}},
+				},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("training_max_percentage", validation.CodeTooSmall, "Training max percentage must be at least 50"),
+				validation.NewErrorDetail("weeks[0].days[0].day", validation.CodeTooLarge, "Day must be at most 7"),
+				validation.NewErrorDetail("weeks[1].days[1].workout_id", validation.CodeRequired, "Workout id is required"),
+				validation.NewErrorDetail("weeks[1].days", validation.CodeDuplicate, program.ErrorDaysContainsDuplicates.Message),
+				program.ErrorWeeksContainsDuplicates,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestCreateProgramRequestWorkoutIDs(t *testing.T) {
+	t.Parallel()
+
+	push, pull := uuid.New(), uuid.New()
+	request := program.CreateProgramRequest{
+		Weeks: []program.WeekRequest{
+			{Week: 1, Days: []program.DayRequest{{Day: 1, WorkoutID: push}, {Day: 2, WorkoutID: pull}}},
+			{Week: 2, Days: []program.DayRequest{{Day: 1, WorkoutID: push}}},
+		},
+	}
+
+	assert.Equal(t, []uuid.UUID{push, pull}, request.WorkoutIDs())
+}
+
+func TestTodayQueryParams(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should default to today", func(t *testing.T) {
+		t.Parallel()
+
+		params := program.TodayQueryParams{}
+		assert.Nil(t, params.Validate())
+		assert.Equal(t, today, params.ParseDate(today))
+	})
+
+	t.Run("should parse the requested date", func(t *testing.T) {
+		t.Parallel()
+
+		params := program.TodayQueryParams{Date: "2025-03-12"}
+		assert.Nil(t, params.Validate())
+		assert.Equal(t, today.AddDate(0, 0, 2), params.ParseDate(today))
+	})
+
+	t.Run("should reject invalid dates", func(t *testing.T) {
+		t.Parallel()
+
+		params := program.TodayQueryParams{Date: "12/03/2025"}
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("date", validation.CodeInvalidDate, "Date must be a date in the YYYY-MM-DD format"),
+		}, params.Validate())
+	})
+}
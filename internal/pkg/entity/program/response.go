@@ -0,0 +1,190 @@
+package program
+
+import (
+	"math"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	ProgramResponse struct {
+		ID                    uuid.UUID      `json:"id"`
+		Name                  string         `json:"name"`
+		Description           *string        `json:"description"`
+		TrainingMaxPercentage float64        `json:"training_max_percentage"`
+		Weeks                 []WeekResponse `json:"weeks,omitempty"` // Ordered by week number, when loaded
+		CreatedAt             time.Time      `json:"created_at"`
+		UpdatedAt             time.Time      `json:"updated_at"`
+	}
+
+	WeekResponse struct {
+		WeekNumber          int           `json:"week_number"`
+		IntensityPercentage float64       `json:"intensity_percentage"`
+		Sets                *int          `json:"sets"`        // Overrides the sets of every exercise
+		Repetitions         *int          `json:"repetitions"` // Overrides the repetitions of every exercise
+		Notes               *string       `json:"notes"`
+		Days                []DayResponse `json:"days"`
+	}
+
+	DayResponse struct {
+		DayNumber   int       `json:"day_number"`
+		WorkoutID   uuid.UUID `json:"workout_id"`
+		WorkoutName string    `json:"workout_name,omitempty"` // When loaded
+	}
+
+	EnrollmentResponse struct {
+		ID        uuid.UUID `json:"id"`
+		ProgramID uuid.UUID `json:"program_id"`
+		StartDate string    `json:"start_date"` // Date in the YYYY-MM-DD format
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// TodayResponse is the workout a program prescribes for a date, with
+	// weights resolved from the user's training maxes.
+	TodayResponse struct {
+		Date         string        `json:"date"`
+		ProgramID    uuid.UUID     `json:"program_id"`
+		ProgramName  string        `json:"program_name"`
+		EnrollmentID uuid.UUID     `json:"enrollment_id"`
+		Week         int           `json:"week"`        // Program week, starting at 1
+		Day          int           `json:"day"`         // Day of the program week, starting at 1
+		RestDay      bool          `json:"rest_day"`    // True when no workout is planned for the day
+		Finished     bool          `json:"finished"`    // True when the date is past the last program week
+		NotStarted   bool          `json:"not_started"` // True when the date is before the enrollment start date
+		Workout      *TodayWorkout `json:"workout"`     // Prescribed workout, nil on rest days
+	}
+
+	TodayWorkout struct {
+		ID                  uuid.UUID            `json:"id"`
+		Name                string               `json:"name"`
+		IntensityPercentage float64              `json:"intensity_percentage"` // Load of the week, as a percentage of the training max
+		Notes               *string              `json:"notes"`
+		Exercises           []PrescribedExercise `json:"exercises"`
+	}
+
+	PrescribedExercise struct {
+		WorkoutExerciseID uuid.UUID `json:"workout_exercise_id"`
+		ExerciseID        uuid.UUID `json:"exercise_id"`
+		ExerciseName      string    `json:"exercise_name"`
+		Sets              int       `json:"sets"`
+		Repetitions       *int      `json:"repetitions"`
+		TrainingMax       *float64  `json:"training_max"` // Nil when the user has no record for the exercise
+		Weight            *float64  `json:"weight"`       // Resolved from the training max, or the workout weight without one
+		RestTime          int       `json:"rest_time"`
+	}
+)
+
+func NewProgramResponse(model *Model) ProgramResponse {
+	program := ProgramResponse{
+		ID:                    model.ID,
+		Name:                  model.Name,
+		Description:           model.Description,
+		TrainingMaxPercentage: model.TrainingMaxPercentage,
+		Weeks:                 make([]WeekResponse, len(model.Weeks)),
+		CreatedAt:             model.CreatedAt,
+		UpdatedAt:             model.UpdatedAt,
+	}
+
+	for index, week := range model.Weeks {
+		program.Weeks[index] = WeekResponse{
+			WeekNumber:          week.WeekNumber,
+			IntensityPercentage: week.IntensityPercentage,
+			Sets:                week.Sets,
+			Repetitions:         week.Repetitions,
+			Notes:               week.Notes,
+			Days:                make([]DayResponse, len(week.Days)),
+		}
+
+		for dayIndex, day := range week.Days {
+			program.Weeks[index].Days[dayIndex] = DayResponse{
+				DayNumber: day.DayNumber,
+				WorkoutID: day.WorkoutID,
+			}
+
+			if day.Workout != nil {
+				program.Weeks[index].Days[dayIndex].WorkoutName = day.Workout.Name
+			}
+		}
+	}
+
+	return program
+}
+
+func NewProgramResponses(models []*Model) []ProgramResponse {
+	responses := make([]ProgramResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewProgramResponse(model)
+	}
+
+	return responses
+}
+
+func NewEnrollmentResponse(model *EnrollmentModel) EnrollmentResponse {
+	return EnrollmentResponse{
+		ID:        model.ID,
+		ProgramID: model.ProgramID,
+		StartDate: model.StartDate.Format(validation.DateLayout),
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+// Prescribe applies the load of a week to the exercises of its workout.
+// Weeks may override the sets and repetitions of every exercise, and weights
+// are resolved as the week intensity of the training max derived from the
+// estimated one rep max. Exercises without a record keep the workout weight.
+func Prescribe(week *WeekModel, trainingMaxPercentage float64, workoutExercises []*workoutexercise.Model, oneRepMaxes map[uuid.UUID]float64) []PrescribedExercise {
+	exercises := make([]PrescribedExercise, len(workoutExercises))
+
+	for index, workoutExercise := range workoutExercises {
+		prescribed := PrescribedExercise{
+			WorkoutExerciseID: workoutExercise.ID,
+			ExerciseID:        workoutExercise.ExerciseID,
+			Sets:              workoutExercise.Sets,
+			Repetitions:       workoutExercise.Repetitions,
+			Weight:            workoutExercise.Weight,
+			RestTime:          workoutExercise.RestTime,
+		}
+
+		if workoutExercise.Exercise != nil {
+			prescribed.ExerciseName = workoutExercise.Exercise.Name
+		}
+
+		if week.Sets != nil {
+			prescribed.Sets = *week.Sets
+		}
+
+		if week.Repetitions != nil {
+			prescribed.Repetitions = week.Repetitions
+		}
+
+		if oneRepMax, ok := oneRepMaxes[workoutExercise.ExerciseID]; ok {
+			trainingMax := TrainingMax(oneRepMax, trainingMaxPercentage)
+			weight := PrescribedWeight(trainingMax, week.IntensityPercentage)
+
+			prescribed.TrainingMax = &trainingMax
+			prescribed.Weight = &weight
+		}
+
+		exercises[index] = prescribed
+	}
+
+	return exercises
+}
+
+// TrainingMax returns the training max for an estimated one rep max.
+func TrainingMax(oneRepMax float64, percentage float64) float64 {
+	return roundWeight(oneRepMax * percentage / 100)
+}
+
+// PrescribedWeight returns the weight for a load given as a percentage of
+// the training max, rounded to WeightIncrement.
+func PrescribedWeight(trainingMax float64, intensityPercentage float64) float64 {
+	return roundWeight(trainingMax * intensityPercentage / 100)
+}
+
+func roundWeight(weight float64) float64 {
+	return math.Round(weight/WeightIncrement) * WeightIncrement
+}
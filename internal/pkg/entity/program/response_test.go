@@ -0,0 +1,77 @@
+package program_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramResponseContract(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 2, 19, 10, 0, 0, 0, time.UTC)
+	programID := uuid.MustParse("5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c01")
+	workoutID := uuid.MustParse("5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c02")
+	sets := 5
+
+	model := &program.Model{
+		Model:                 base.Model{ID: programID, CreatedAt: createdAt, UpdatedAt: createdAt},
+		Name:                  "5/3/1",
+		TrainingMaxPercentage: 90,
+		Weeks: []*program.WeekModel{{
+			ProgramID:           programID,
+			WeekNumber:          1,
+			IntensityPercentage: 65,
+			Sets:                &sets,
+			Days: []*program.DayModel{{
+				DayNumber: 1,
+				WorkoutID: workoutID,
+				Workout:   &workout.Model{Name: "Squat Day"},
+			}},
+		}},
+	}
+
+	data, err := json.Marshal(program.NewProgramResponse(model))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"id": "5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c01",
+		"name": "5/3/1",
+		"description": null,
+		"training_max_percentage": 90,
+		"weeks": [{
+			"week_number": 1,
+			"intensity_percentage": 65,
+			"sets": 5,
+			"repetitions": null,
+			"notes": null,
+			"days": [{"day_number": 1, "workout_id": "5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c02", "workout_name": "Squat Day"}]
+		}],
+		"created_at": "2025-02-19T10:00:00Z",
+		"updated_at": "2025-02-19T10:00:00Z"
+	}`, string(data))
+
+	t.Run("should return enrollment start dates without time", func(t *testing.T) {
+		t.Parallel()
+
+		enrollment := &program.EnrollmentModel{
+			Model:     base.Model{ID: uuid.MustParse("5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c03"), CreatedAt: createdAt},
+			ProgramID: programID,
+			StartDate: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		}
+
+		data, err := json.Marshal(program.NewEnrollmentResponse(enrollment))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"id": "5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c03",
+			"program_id": "5a0c6e1b-2d3f-4a5b-8c7d-9e0f1a2b3c01",
+			"start_date": "2025-03-03",
+			"created_at": "2025-02-19T10:00:00Z"
+		}`, string(data))
+	})
+}
@@ -0,0 +1,42 @@
+package schedule
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// StatusPlanned identifies a scheduled workout that has not been started yet
+	StatusPlanned = "planned"
+	// StatusInProgress identifies a scheduled workout with a running session
+	StatusInProgress = "in_progress"
+	// StatusCompleted identifies a scheduled workout whose session was completed
+	StatusCompleted = "completed"
+	// StatusMissed identifies a past scheduled workout that was never started
+	StatusMissed = "missed"
+
+	// MaxDates is the largest number of dates that can be scheduled at once
+	MaxDates = 90
+
+	// DefaultPeriodDays is the number of days listed when no period is requested
+	DefaultPeriodDays = 7
+
+	// MaxPeriodDays is the longest period that can be listed at once
+	MaxPeriodDays = 92
+
+	// NotesMaxLength is the maximum number of characters allowed in schedule notes
+	NotesMaxLength = 2000
+)
+
+var (
+	// ErrorDatesIsRequired is the error message for scheduling without dates
+	ErrorDatesIsRequired response.ErrorDetail = validation.NewErrorDetail("dates", validation.CodeRequired, "At least one date is required")
+	// ErrorDatesIsTooLong is the error message for scheduling more than MaxDates dates
+	ErrorDatesIsTooLong response.ErrorDetail = validation.NewErrorDetail("dates", validation.CodeTooLarge, "At most 90 dates can be scheduled at once")
+	// ErrorDatesContainsDuplicates is the error message for scheduling the same date twice
+	ErrorDatesContainsDuplicates response.ErrorDetail = validation.NewErrorDetail("dates", validation.CodeDuplicate, "Dates must not contain duplicates")
+	// ErrorToIsBeforeFrom is the error message for a period that ends before it starts
+	ErrorToIsBeforeFrom response.ErrorDetail = validation.NewErrorDetail("to", validation.CodeInvalid, "To must not be before from")
+	// ErrorPeriodIsTooLong is the error message for periods longer than MaxPeriodDays
+	ErrorPeriodIsTooLong response.ErrorDetail = validation.NewErrorDetail("to", validation.CodeTooLarge, "Period must span at most 92 days")
+)
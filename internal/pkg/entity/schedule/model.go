@@ -0,0 +1,41 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type (
+	// Model is a workout template planned for a specific date.
+	Model struct {
+		bun.BaseModel `bun:"table:scheduled_workouts,alias:scheduled_workout"`
+		base.Model
+		UserID       uuid.UUID             `bun:"user_id"`
+		WorkoutID    uuid.UUID             `bun:"workout_id"`
+		ScheduledFor time.Time             `bun:"scheduled_for,type:date"`
+		Notes        *string               `bun:"notes"`
+		Status       string                `bun:"-"`
+		Workout      *workout.Model        `bun:"rel:belongs-to,join:workout_id=id"`
+		Session      *workouthistory.Model `bun:"rel:has-one,join:id=scheduled_workout_id"`
+	}
+)
+
+// ResolveStatus sets Status from the session started for the scheduled
+// workout and from how its date compares to today.
+func (m *Model) ResolveStatus(today time.Time) {
+	switch {
+	case m.Session != nil && m.Session.IsCompleted():
+		m.Status = StatusCompleted
+	case m.Session != nil:
+		m.Status = StatusInProgress
+	case m.ScheduledFor.Before(today):
+		m.Status = StatusMissed
+	default:
+		m.Status = StatusPlanned
+	}
+}
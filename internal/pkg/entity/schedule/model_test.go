@@ -0,0 +1,84 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelResolveStatus(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	completedAt := today.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		model    schedule.Model
+		expected string
+	}{
+		{
+			name:     "upcoming workout",
+			model:    schedule.Model{ScheduledFor: today.AddDate(0, 0, 1)},
+			expected: schedule.StatusPlanned,
+		},
+		{
+			name:     "workout planned for today",
+			model:    schedule.Model{ScheduledFor: today},
+			expected: schedule.StatusPlanned,
+		},
+		{
+			name:     "past workout never started",
+			model:    schedule.Model{ScheduledFor: today.AddDate(0, 0, -1)},
+			expected: schedule.StatusMissed,
+		},
+		{
+			name: "past workout still running",
+			model: schedule.Model{
+				ScheduledFor: today.AddDate(0, 0, -1),
+				Session:      &workouthistory.Model{StartedAt: today},
+			},
+			expected: schedule.StatusInProgress,
+		},
+		{
+			name: "completed workout",
+			model: schedule.Model{
+				ScheduledFor: today,
+				Session:      &workouthistory.Model{StartedAt: today, CompletedAt: &completedAt},
+			},
+			expected: schedule.StatusCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.model.ResolveStatus(today)
+			assert.Equal(t, tt.expected, tt.model.Status)
+		})
+	}
+}
+
+func TestNewSummary(t *testing.T) {
+	t.Parallel()
+
+	items := []*schedule.Model{
+		{Status: schedule.StatusCompleted},
+		{Status: schedule.StatusCompleted},
+		{Status: schedule.StatusInProgress},
+		{Status: schedule.StatusMissed},
+		{Status: schedule.StatusPlanned},
+	}
+
+	assert.Equal(t, schedule.Summary{
+		Planned:    5,
+		Completed:  2,
+		InProgress: 1,
+		Missed:     1,
+		Upcoming:   1,
+	}, schedule.NewSummary(items))
+}
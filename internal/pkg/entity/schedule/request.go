@@ -0,0 +1,105 @@
+package schedule
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	ScheduleWorkoutRequest struct {
+		WorkoutID uuid.UUID `json:"workout_id"`
+		Dates     []string  `json:"dates"`
+		Notes     *string   `json:"notes"`
+	}
+
+	ListScheduleQueryParams struct {
+		From string `json:"from" query:"from"`
+		To   string `json:"to" query:"to"`
+	}
+)
+
+func (r *ScheduleWorkoutRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.UUID("workout_id", r.WorkoutID).Required()
+	v.Check(len(r.Dates) > 0, ErrorDatesIsRequired)
+	v.Check(len(r.Dates) <= MaxDates, ErrorDatesIsTooLong)
+	validation.Each(v, "dates", r.Dates, func(v *validation.Validator, date string) {
+		v.String("", date).Required().Date()
+	})
+	v.Check(!hasDuplicates(r.Dates), ErrorDatesContainsDuplicates)
+	v.OptionalString("notes", r.Notes).MaxLength(NotesMaxLength)
+
+	return v.Errors()
+}
+
+// ParseDates returns the requested dates. It must only be called after
+// Validate succeeded.
+func (r *ScheduleWorkoutRequest) ParseDates() []time.Time {
+	dates := make([]time.Time, len(r.Dates))
+	for index, date := range r.Dates {
+		dates[index], _ = time.Parse(validation.DateLayout, strings.TrimSpace(date))
+	}
+
+	return dates
+}
+
+func (p *ListScheduleQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("from", p.From).Date()
+	v.String("to", p.To).Date()
+
+	if v.Errors() == nil && p.From != "" && p.To != "" {
+		from, _ := time.Parse(validation.DateLayout, p.From)
+		to, _ := time.Parse(validation.DateLayout, p.To)
+
+		v.Check(!to.Before(from), ErrorToIsBeforeFrom)
+		v.Check(days(from, to) <= MaxPeriodDays, ErrorPeriodIsTooLong)
+	}
+
+	return v.Errors()
+}
+
+// Period returns the first and last day to list. A missing from defaults to
+// today, or to the week ending on to, and a missing to defaults to the week
+// starting on from. It must only be called after Validate succeeded.
+func (p *ListScheduleQueryParams) Period(today time.Time) (time.Time, time.Time) {
+	from, _ := time.Parse(validation.DateLayout, p.From)
+	to, _ := time.Parse(validation.DateLayout, p.To)
+
+	switch {
+	case p.From == "" && p.To == "":
+		from = today
+		to = from.AddDate(0, 0, DefaultPeriodDays-1)
+	case p.From == "":
+		from = to.AddDate(0, 0, -(DefaultPeriodDays - 1))
+	case p.To == "":
+		to = from.AddDate(0, 0, DefaultPeriodDays-1)
+	}
+
+	return from, to
+}
+
+func hasDuplicates(dates []string) bool {
+	seen := make(map[string]struct{}, len(dates))
+	for _, date := range dates {
+		date = strings.TrimSpace(date)
+		if _, ok := seen[date]; ok {
+			return true
+		}
+
+		seen[date] = struct{}{}
+	}
+
+	return false
+}
+
+// days returns the number of calendar days between from and to, both included.
+func days(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24) + 1
+}
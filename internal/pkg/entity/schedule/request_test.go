@@ -0,0 +1,149 @@
+package schedule_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleWorkoutRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	tooManyDates := make([]string, schedule.MaxDates+1)
+	for index := range tooManyDates {
+		tooManyDates[index] = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, index).Format(validation.DateLayout)
+	}
+
+	tests := []struct {
+		name     string
+		request  schedule.ScheduleWorkoutRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: schedule.ScheduleWorkoutRequest{
+				WorkoutID: uuid.New(),
+				Dates:     []string{"2025-03-03", "2025-03-05"},
+			},
+			expected: nil,
+		},
+		{
+			name:    "missing fields",
+			request: schedule.ScheduleWorkoutRequest{},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("workout_id", validation.CodeRequired, "Workout id is required"),
+				schedule.ErrorDatesIsRequired,
+			},
+		},
+		{
+			name: "invalid and duplicated dates",
+			request: schedule.ScheduleWorkoutRequest{
+				WorkoutID: uuid.New(),
+				Dates:     []string{"2025-03-03", "03/05/2025", " 2025-03-03"},
+				Notes:     func() *string { notes := strings.Repeat("a", schedule.NotesMaxLength+1); return &notes }(),
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("dates[1]", validation.CodeInvalidDate, "Dates must be a date in the YYYY-MM-DD format"),
+				schedule.ErrorDatesContainsDuplicates,
+				validation.NewErrorDetail("notes", validation.CodeTooLong, "Notes must have at most 2000 characters"),
+			},
+		},
+		{
+			name: "too many dates",
+			request: schedule.ScheduleWorkoutRequest{
+				WorkoutID: uuid.New(),
+				Dates:     tooManyDates,
+			},
+			expected: &response.ErrorDetails{schedule.ErrorDatesIsTooLong},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestListScheduleQueryParams(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		params       schedule.ListScheduleQueryParams
+		expected     *response.ErrorDetails
+		expectedFrom string
+		expectedTo   string
+	}{
+		{
+			name:         "default period",
+			params:       schedule.ListScheduleQueryParams{},
+			expectedFrom: "2025-03-10",
+			expectedTo:   "2025-03-16",
+		},
+		{
+			name:         "only from",
+			params:       schedule.ListScheduleQueryParams{From: "2025-03-01"},
+			expectedFrom: "2025-03-01",
+			expectedTo:   "2025-03-07",
+		},
+		{
+			name:         "only to",
+			params:       schedule.ListScheduleQueryParams{To: "2025-03-07"},
+			expectedFrom: "2025-03-01",
+			expectedTo:   "2025-03-07",
+		},
+		{
+			name:         "explicit period",
+			params:       schedule.ListScheduleQueryParams{From: "2025-03-01", To: "2025-03-31"},
+			expectedFrom: "2025-03-01",
+			expectedTo:   "2025-03-31",
+		},
+		{
+			name:     "to before from",
+			params:   schedule.ListScheduleQueryParams{From: "2025-03-10", To: "2025-03-01"},
+			expected: &response.ErrorDetails{schedule.ErrorToIsBeforeFrom},
+		},
+		{
+			name:     "period too long",
+			params:   schedule.ListScheduleQueryParams{From: "2025-01-01", To: "2025-12-31"},
+			expected: &response.ErrorDetails{schedule.ErrorPeriodIsTooLong},
+		},
+		{
+			name:   "invalid dates",
+			params: schedule.ListScheduleQueryParams{From: "yesterday", To: "2025-13-01"},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("from", validation.CodeInvalidDate, "From must be a date in the YYYY-MM-DD format"),
+				validation.NewErrorDetail("to", validation.CodeInvalidDate, "To must be a date in the YYYY-MM-DD format"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.params.Validate()
+			assert.Equal(t, tt.expected, err)
+
+			if err != nil {
+				return
+			}
+
+			from, to := tt.params.Period(today)
+			assert.Equal(t, tt.expectedFrom, from.Format(validation.DateLayout))
+			assert.Equal(t, tt.expectedTo, to.Format(validation.DateLayout))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	ScheduledWorkoutResponse struct {
+		ID           uuid.UUID                       `json:"id"`
+		WorkoutID    uuid.UUID                       `json:"workout_id"`
+		ScheduledFor string                          `json:"scheduled_for"` // Date in the YYYY-MM-DD format
+		Notes        *string                         `json:"notes"`
+		Status       string                          `json:"status,omitempty"`  // Resolved when listing the schedule
+		Workout      *workout.WorkoutResponse        `json:"workout,omitempty"` // When loaded
+		Session      *workouthistory.SessionResponse `json:"session,omitempty"` // Session started from it, if any
+	}
+
+	// Summary compares what was planned in a period with what was done.
+	Summary struct {
+		Planned    int `json:"planned"`     // Number of scheduled workouts in the period
+		Completed  int `json:"completed"`   // Scheduled workouts whose session was completed
+		InProgress int `json:"in_progress"` // Scheduled workouts with a running session
+		Missed     int `json:"missed"`      // Past scheduled workouts that were never started
+		Upcoming   int `json:"upcoming"`    // Scheduled workouts not started yet and not past
+	}
+
+	ListScheduleResponse struct {
+		From    string                     `json:"from"`
+		To      string                     `json:"to"`
+		Items   []ScheduledWorkoutResponse `json:"items"`
+		Summary Summary                    `json:"summary"`
+	}
+)
+
+func NewScheduledWorkoutResponse(model *Model) ScheduledWorkoutResponse {
+	scheduled := ScheduledWorkoutResponse{
+		ID:           model.ID,
+		WorkoutID:    model.WorkoutID,
+		ScheduledFor: model.ScheduledFor.Format(validation.DateLayout),
+		Notes:        model.Notes,
+		Status:       model.Status,
+	}
+
+	if model.Workout != nil {
+		workoutResponse := workout.NewWorkoutResponse(model.Workout)
+		scheduled.Workout = &workoutResponse
+	}
+
+	if model.Session != nil {
+		session := workouthistory.NewSessionResponse(model.Session)
+		scheduled.Session = &session
+	}
+
+	return scheduled
+}
+
+func NewScheduledWorkoutResponses(models []*Model) []ScheduledWorkoutResponse {
+	responses := make([]ScheduledWorkoutResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewScheduledWorkoutResponse(model)
+	}
+
+	return responses
+}
+
+// NewSummary counts the scheduled workouts by status. Statuses must have been
+// resolved before.
+func NewSummary(items []*Model) Summary {
+	summary := Summary{Planned: len(items)}
+
+	for _, item := range items {
+		switch item.Status {
+		case StatusCompleted:
+			summary.Completed++
+		case StatusInProgress:
+			summary.InProgress++
+		case StatusMissed:
+			summary.Missed++
+		case StatusPlanned:
+			summary.Upcoming++
+		}
+	}
+
+	return summary
+}
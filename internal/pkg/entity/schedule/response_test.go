@@ -0,0 +1,51 @@
+package schedule_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledWorkoutResponseContract(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2025, 2, 19, 10, 0, 0, 0, time.UTC)
+
+	model := &schedule.Model{
+		Model:        base.Model{ID: uuid.MustParse("7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a001")},
+		WorkoutID:    uuid.MustParse("7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a002"),
+		ScheduledFor: time.Date(2025, 2, 19, 0, 0, 0, 0, time.UTC),
+		Status:       schedule.StatusInProgress,
+		Session: &workouthistory.Model{
+			ID:        uuid.MustParse("7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a003"),
+			WorkoutID: uuid.MustParse("7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a002"),
+			StartedAt: startedAt,
+			UpdatedAt: startedAt,
+		},
+	}
+
+	data, err := json.Marshal(schedule.NewScheduledWorkoutResponse(model))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"id": "7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a001",
+		"workout_id": "7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a002",
+		"scheduled_for": "2025-02-19",
+		"notes": null,
+		"status": "in_progress",
+		"session": {
+			"id": "7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a003",
+			"workout_id": "7e8f9a0b-1c2d-4e3f-a4b5-c6d7e8f9a002",
+			"scheduled_workout_id": null,
+			"started_at": "2025-02-19T10:00:00Z",
+			"completed_at": null,
+			"notes": null,
+			"updated_at": "2025-02-19T10:00:00Z"
+		}
+	}`, string(data))
+}
@@ -0,0 +1,13 @@
+package trash
+
+const (
+	// ItemTypeExercise identifies a soft-deleted exercise
+	ItemTypeExercise = "exercise"
+	// ItemTypeMuscleGroup identifies a soft-deleted muscle group
+	ItemTypeMuscleGroup = "muscle_group"
+	// ItemTypeWorkout identifies a soft-deleted workout
+	ItemTypeWorkout = "workout"
+)
+
+// SortFields are the fields lists of trashed items can be sorted by.
+var SortFields = []string{"name", "type", "deleted_at"}
@@ -0,0 +1,24 @@
+package trash
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+type (
+	ListTrashQueryParams struct {
+		base.ListQueryParams
+		Type string `query:"type"`
+	}
+)
+
+func (p *ListTrashQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	p.Rules(v)
+	p.SortRules(v, SortFields...)
+	v.String("type", p.Type).OneOf(ItemTypeExercise, ItemTypeMuscleGroup, ItemTypeWorkout)
+
+	return v.Errors()
+}
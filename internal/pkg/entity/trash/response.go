@@ -0,0 +1,16 @@
+package trash
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Item struct {
+		ID        uuid.UUID `json:"id" bun:"id"`
+		Type      string    `json:"type" bun:"type"`
+		Name      string    `json:"name" bun:"name"`
+		DeletedAt time.Time `json:"deleted_at" bun:"deleted_at"`
+	}
+)
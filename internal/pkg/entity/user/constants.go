@@ -2,15 +2,30 @@ package user
 
 import (
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// NameMaxLength is the maximum number of characters allowed in a user name
+	NameMaxLength = 255
+
+	// EmailMaxLength is the maximum number of characters allowed in an email
+	EmailMaxLength = 255
 )
 
 var (
 	// ErrorNameIsRequired is the error message for name is required
-	ErrorNameIsRequired response.ErrorDetail = response.NewErrorDetail("name", "Name is required")
+	ErrorNameIsRequired response.ErrorDetail = validation.NewErrorDetail("name", validation.CodeRequired, "Name is required")
 
 	// ErrorEmailIsRequired is the error message for email is required
-	ErrorEmailIsRequired response.ErrorDetail = response.NewErrorDetail("email", "Email is required")
+	ErrorEmailIsRequired response.ErrorDetail = validation.NewErrorDetail("email", validation.CodeRequired, "Email is required")
+
+	// ErrorEmailIsInvalid is the error message for malformed email addresses
+	ErrorEmailIsInvalid response.ErrorDetail = validation.NewErrorDetail("email", validation.CodeInvalidEmail, "Email must be a valid email address")
+
+	// ErrorEmailAlreadyRegistered is the error message for an email already used by another user
+	ErrorEmailAlreadyRegistered response.ErrorDetail = validation.NewErrorDetail("email", validation.CodeAlreadyExists, "It looks like this email is already registered on our platform")
 
 	// ErrorPasswordIsRequired is the error message for password is required
-	ErrorPasswordIsRequired response.ErrorDetail = response.NewErrorDetail("password", "Password is required")
+	ErrorPasswordIsRequired response.ErrorDetail = validation.NewErrorDetail("password", validation.CodeRequired, "Password is required")
 )
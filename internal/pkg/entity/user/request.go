@@ -1,6 +1,9 @@
 package user
 
-import "github.com/Gabukuro/gymratz-api/internal/pkg/response"
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
 
 type (
 	RegisterUserRequest struct {
@@ -16,41 +19,20 @@ type (
 )
 
 func (r *RegisterUserRequest) Validate() *response.ErrorDetails {
-	var errors response.ErrorDetails
-
-	if r.Name == "" {
-		errors = append(errors, ErrorNameIsRequired)
-	}
+	v := validation.New()
 
-	if r.Email == "" {
-		errors = append(errors, ErrorEmailIsRequired)
-	}
+	v.String("name", r.Name).Required().MaxLength(NameMaxLength)
+	v.String("email", r.Email).Required().MaxLength(EmailMaxLength).Email()
+	v.String("password", r.Password).Required()
 
-	if r.Password == "" {
-		errors = append(errors, ErrorPasswordIsRequired)
-	}
-
-	if len(errors) == 0 {
-		return nil
-	}
-
-	return &errors
+	return v.Errors()
 }
 
 func (l *LoginUserRequest) Validate() *response.ErrorDetails {
-	var errors response.ErrorDetails
-
-	if l.Email == "" {
-		errors = append(errors, ErrorEmailIsRequired)
-	}
+	v := validation.New()
 
-	if l.Password == "" {
-		errors = append(errors, ErrorPasswordIsRequired)
-	}
-
-	if len(errors) == 0 {
-		return nil
-	}
+	v.String("email", l.Email).Required()
+	v.String("password", l.Password).Required()
 
-	return &errors
+	return v.Errors()
 }
@@ -49,6 +49,15 @@ func TestRegisterUserRequestValidate(t *testing.T) {
 			},
 			expected: &response.ErrorDetails{user.ErrorPasswordIsRequired},
 		},
+		{
+			name: "invalid email",
+			request: user.RegisterUserRequest{
+				Name:     "John Doe",
+				Email:    "john.doe",
+				Password: "password123",
+			},
+			expected: &response.ErrorDetails{user.ErrorEmailIsInvalid},
+		},
 		{
 			name:    "missing all fields",
 			request: user.RegisterUserRequest{},
@@ -0,0 +1,52 @@
+package workout
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// NameMaxLength is the maximum number of characters allowed in a workout name
+	NameMaxLength = 255
+
+	// MaxSets is the largest number of sets a workout exercise may prescribe
+	MaxSets = 100
+
+	// ShareTokenBytes is the number of random bytes in a share link token
+	ShareTokenBytes = 32
+
+	// CopySuffix is appended to the name of duplicated workouts without a new name
+	CopySuffix = " (copy)"
+)
+
+var (
+	// ErrorGroupNotFound is the error message for exercises referencing a group that was not sent
+	ErrorGroupNotFound response.ErrorDetail = validation.NewErrorDetail("group", validation.CodeNotFound, "Group must be the index of one of the groups")
+	// ErrorExercisesMismatch is the error message for reorders that do not list every exercise of the workout
+	ErrorExercisesMismatch response.ErrorDetail = validation.NewErrorDetail("workout_exercise_ids", validation.CodeInvalid, "Workout exercise ids must list every exercise of the workout exactly once")
+	// ErrorSetPrescriptionsIsTooLong is the error message for set prescriptions expanding into too many sets
+	ErrorSetPrescriptionsIsTooLong response.ErrorDetail = validation.NewErrorDetail("set_prescriptions", validation.CodeTooLong, "Set prescriptions must expand into at most 100 sets")
+	// ErrorCreatedRangeIsInvalid is the error message for creation date ranges ending before they start
+	ErrorCreatedRangeIsInvalid response.ErrorDetail = validation.NewErrorDetail("created_to", validation.CodeInvalid, "Created to must not be before created from")
+	// ErrorUpdatedRangeIsInvalid is the error message for update date ranges ending before they start
+	ErrorUpdatedRangeIsInvalid response.ErrorDetail = validation.NewErrorDetail("updated_to", validation.CodeInvalid, "Updated to must not be before updated from")
+	// ErrorReorderSplitsGroup is the error message for reorders that move exercises of a group apart
+	ErrorReorderSplitsGroup response.ErrorDetail = validation.NewErrorDetail("workout_exercise_ids", validation.CodeInvalid, "Exercises of a group must stay next to each other")
+)
+
+// SortFields are the fields lists of workouts can be sorted by.
+var SortFields = []string{"name", "created_at", "updated_at"}
+
+const (
+	// ExpandExercises loads the exercises of workouts with their groups and set prescriptions
+	ExpandExercises = "exercises"
+	// ExpandExerciseDetails also loads the exercise each workout exercise refers to
+	ExpandExerciseDetails = "exercises.exercise"
+)
+
+// Fields are the fields lists of workouts can be limited to.
+var Fields = []string{"id", "name", "user_id", "created_at", "updated_at"}
+
+// Expansions are the relations lists of workouts can load, all of them by
+// default.
+var Expansions = []string{ExpandExercises, ExpandExerciseDetails}
@@ -1,6 +1,12 @@
 package workout
 
 import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"hash/fnv"
+	"strconv"
+
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
 	"github.com/google/uuid"
@@ -13,6 +19,60 @@ type (
 		base.Model
 		UserID           uuid.UUID                `bun:"user_id"`
 		Name             string                   `bun:"name"`
+		Version          int                      `bun:"version,nullzero,notnull,default:1"` // Incremented on every change, including changes to the exercises
 		WorkoutExercises []*workoutexercise.Model `bun:"rel:has-many,join:id=workout_id"`
+		Blocks           []*workoutexercise.Block `bun:"-"`
+	}
+
+	// ShareLinkModel lets anyone holding its token preview and import a
+	// workout until the owner revokes it by deleting the link.
+	ShareLinkModel struct {
+		bun.BaseModel `bun:"table:workout_share_links,alias:workout_share_link"`
+		base.Model
+		WorkoutID uuid.UUID `bun:"workout_id"`
+		UserID    uuid.UUID `bun:"user_id"`
+		Token     string    `bun:"token"`
 	}
 )
+
+// Arrange sorts the exercises of the workout by position and groups them
+// into blocks.
+func (m *Model) Arrange() {
+	m.Blocks = workoutexercise.Arrange(m.WorkoutExercises)
+}
+
+// Tag returns the entity tag value of the workout as read with its exercises.
+// Edits to the catalog exercises it embeds don't change the workout version,
+// so the tag adds a digest of their ids and versions to it.
+func (m *Model) Tag() string {
+	digest := fnv.New64a()
+	for _, workoutExercise := range m.WorkoutExercises {
+		digest.Write(workoutExercise.ExerciseID[:])
+
+		if workoutExercise.Exercise != nil {
+			digest.Write(binary.BigEndian.AppendUint64(nil, uint64(workoutExercise.Exercise.Version)))
+		}
+	}
+
+	return strconv.Itoa(m.Version) + "-" + strconv.FormatUint(digest.Sum64(), 36)
+}
+
+// NewShareToken returns an unguessable, URL safe token for a share link.
+func NewShareToken() (string, error) {
+	token := make([]byte, ShareTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// CopyName returns the default name of a copy of the workout.
+func (m *Model) CopyName() string {
+	name := m.Name + CopySuffix
+	if len([]rune(name)) > NameMaxLength {
+		return m.Name
+	}
+
+	return name
+}
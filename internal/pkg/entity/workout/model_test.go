@@ -0,0 +1,62 @@
+package workout_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShareToken(t *testing.T) {
+	t.Parallel()
+
+	first, err := workout.NewShareToken()
+	assert.Nil(t, err)
+
+	second, err := workout.NewShareToken()
+	assert.Nil(t, err)
+
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+	assert.NotContains(t, first, "/")
+	assert.NotContains(t, first, "+")
+}
+
+func TestModelCopyName(t *testing.T) {
+	t.Parallel()
+
+	model := workout.Model{Name: "Push Day"}
+	assert.Equal(t, "Push Day (copy)", model.CopyName())
+
+	longName := strings.Repeat("a", workout.NameMaxLength)
+	model = workout.Model{Name: longName}
+	assert.Equal(t, longName, model.CopyName())
+}
+
+func TestModelTag(t *testing.T) {
+	t.Parallel()
+
+	newWorkout := func(version int, exerciseID uuid.UUID, exerciseVersion int) *workout.Model {
+		return &workout.Model{
+			Version: version,
+			WorkoutExercises: []*workoutexercise.Model{{
+				ExerciseID: exerciseID,
+				Exercise:   &exercise.Model{Model: base.Model{ID: exerciseID}, Version: exerciseVersion},
+			}},
+		}
+	}
+
+	exerciseID := uuid.New()
+	tag := newWorkout(1, exerciseID, 1).Tag()
+
+	assert.Equal(t, tag, newWorkout(1, exerciseID, 1).Tag())
+	assert.True(t, strings.HasPrefix(tag, "1-"))
+	assert.NotEqual(t, tag, newWorkout(2, exerciseID, 1).Tag(), "workout edited")
+	assert.NotEqual(t, tag, newWorkout(1, exerciseID, 2).Tag(), "exercise edited")
+	assert.NotEqual(t, tag, newWorkout(1, uuid.New(), 1).Tag(), "exercise merged into another")
+}
@@ -1,7 +1,12 @@
 package workout
 
 import (
+	"fmt"
+
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
 	"github.com/google/uuid"
 )
 
@@ -10,6 +15,7 @@ type (
 		Name      string            `json:"name"`
 		UserID    uuid.UUID         `json:"user_id"`
 		Exercises []WorkoutExercise `json:"exercises"`
+		Groups    []ExerciseGroup   `json:"groups"`
 	}
 
 	UpdateWorkoutRequest struct {
@@ -18,26 +24,259 @@ type (
 	}
 
 	UpdateWorkoutExerciseRequest struct {
-		Sets        int      `json:"sets"`
-		Repetitions *int     `json:"repetitions"`
-		Weight      *float64 `json:"weight"`
-		Duration    *int     `json:"duration"`
-		RestTime    int      `json:"rest_time"`
-		Notes       *string  `json:"notes"`
+		Sets             int                               `json:"sets"`
+		Repetitions      *int                              `json:"repetitions"`
+		Weight           *float64                          `json:"weight"`
+		Duration         *int                              `json:"duration"`
+		RestTime         int                               `json:"rest_time"`
+		Notes            *string                           `json:"notes"`
+		SetPrescriptions []workoutexercise.SetPrescription `json:"set_prescriptions"`
 	}
 
+	// WorkoutExercise is an exercise of a new workout. Exercises are stored
+	// in the order they are sent, and Group is the index of the group, in
+	// CreateWorkoutRequest.Groups, the exercise belongs to. The load is
+	// prescribed either per set with SetPrescriptions or, as a shorthand for
+	// identical sets, with Sets, Repetitions and Weight.
 	WorkoutExercise struct {
-		ExerciseID  uuid.UUID `json:"exercise_id"`
-		Sets        int       `json:"sets"`
-		Repetitions *int      `json:"repetitions"`
-		Weight      *float64  `json:"weight"`
-		Duration    *int      `json:"duration"`
-		RestTime    int       `json:"rest_time"`
-		Notes       *string   `json:"notes"`
+		ExerciseID       uuid.UUID                         `json:"exercise_id"`
+		Group            *int                              `json:"group"`
+		Sets             int                               `json:"sets"`
+		Repetitions      *int                              `json:"repetitions"`
+		Weight           *float64                          `json:"weight"`
+		Duration         *int                              `json:"duration"`
+		RestTime         int                               `json:"rest_time"`
+		Notes            *string                           `json:"notes"`
+		SetPrescriptions []workoutexercise.SetPrescription `json:"set_prescriptions"`
+	}
+
+	// AddWorkoutExerciseRequest appends an exercise to an existing workout.
+	AddWorkoutExerciseRequest struct {
+		ExerciseID       uuid.UUID                         `json:"exercise_id"`
+		Sets             int                               `json:"sets"`
+		Repetitions      *int                              `json:"repetitions"`
+		Weight           *float64                          `json:"weight"`
+		Duration         *int                              `json:"duration"`
+		RestTime         int                               `json:"rest_time"`
+		Notes            *string                           `json:"notes"`
+		SetPrescriptions []workoutexercise.SetPrescription `json:"set_prescriptions"`
+	}
+
+	// CopyWorkoutRequest names the copy made when duplicating or importing
+	// a workout. Without a name the copy is named after the original.
+	CopyWorkoutRequest struct {
+		Name *string `json:"name"`
+	}
+
+	ExerciseGroup struct {
+		Type    string `json:"type"`
+		TimeCap *int   `json:"time_cap"`
 	}
 
+	ReorderWorkoutExercisesRequest struct {
+		WorkoutExerciseIDs []uuid.UUID `json:"workout_exercise_ids"`
+	}
+
+	// ListWorkoutsQueryParams filters the workouts of a user. Name matches
+	// part of the workout name, and date ranges are inclusive.
 	ListWorkoutsQueryParams struct {
 		base.ListQueryParams
-		Name string `json:"name"`
+		base.Projection
+		Name          string     `json:"name" query:"name"`
+		ExerciseID    *uuid.UUID `json:"exercise_id" query:"exercise_id"`
+		MuscleGroupID *uuid.UUID `json:"muscle_group_id" query:"muscle_group_id"`
+		CreatedFrom   string     `json:"created_from" query:"created_from"`
+		CreatedTo     string     `json:"created_to" query:"created_to"`
+		UpdatedFrom   string     `json:"updated_from" query:"updated_from"`
+		UpdatedTo     string     `json:"updated_to" query:"updated_to"`
 	}
 )
+
+func (r *CreateWorkoutRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("name", r.Name).Required().MaxLength(NameMaxLength)
+	v.UUID("user_id", r.UserID).Required()
+	validation.Each(v, "exercises", r.Exercises, func(v *validation.Validator, exercise WorkoutExercise) {
+		v.UUID("exercise_id", exercise.ExerciseID).Required()
+		v.Check(exercise.Group == nil || (*exercise.Group >= 0 && *exercise.Group < len(r.Groups)), ErrorGroupNotFound)
+		prescriptionRules(v, exercise.Sets, exercise.Repetitions, exercise.Weight, exercise.Duration, exercise.RestTime, exercise.SetPrescriptions)
+	})
+
+	members := r.groupMembers()
+	for index, group := range r.Groups {
+		v.Nested(fmt.Sprintf("groups[%d]", index), func(v *validation.Validator) {
+			groupRules(v, group, members[index])
+		})
+	}
+
+	return v.Errors()
+}
+
+// groupMembers returns, for every group, the positions of its exercises.
+func (r *CreateWorkoutRequest) groupMembers() map[int][]int {
+	members := make(map[int][]int)
+	for position, exercise := range r.Exercises {
+		if exercise.Group != nil {
+			members[*exercise.Group] = append(members[*exercise.Group], position)
+		}
+	}
+
+	return members
+}
+
+func (r *AddWorkoutExerciseRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.UUID("exercise_id", r.ExerciseID).Required()
+	prescriptionRules(v, r.Sets, r.Repetitions, r.Weight, r.Duration, r.RestTime, r.SetPrescriptions)
+
+	return v.Errors()
+}
+
+func (r *CopyWorkoutRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.OptionalString("name", r.Name).Required().MaxLength(NameMaxLength)
+
+	return v.Errors()
+}
+
+func (r *ReorderWorkoutExercisesRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.UUIDs("workout_exercise_ids", r.WorkoutExerciseIDs).Required().Unique()
+
+	return v.Errors()
+}
+
+// NewUpdateWorkoutRequest returns the request that would leave a workout as
+// it is, to which merge patches are applied.
+func NewUpdateWorkoutRequest(model *Model) UpdateWorkoutRequest {
+	return UpdateWorkoutRequest{
+		Name:   model.Name,
+		UserID: model.UserID,
+	}
+}
+
+// NewUpdateWorkoutExerciseRequest returns the request that would leave a
+// workout exercise as it is, to which merge patches are applied. The load is
+// given by its flat summary.
+func NewUpdateWorkoutExerciseRequest(model *workoutexercise.Model) UpdateWorkoutExerciseRequest {
+	return UpdateWorkoutExerciseRequest{
+		Sets:        model.Sets,
+		Repetitions: model.Repetitions,
+		Weight:      model.Weight,
+		Duration:    model.Duration,
+		RestTime:    model.RestTime,
+		Notes:       model.Notes,
+	}
+}
+
+func (r *UpdateWorkoutRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("name", r.Name).Required().MaxLength(NameMaxLength)
+
+	return v.Errors()
+}
+
+func (r *UpdateWorkoutExerciseRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	prescriptionRules(v, r.Sets, r.Repetitions, r.Weight, r.Duration, r.RestTime, r.SetPrescriptions)
+
+	return v.Errors()
+}
+
+// ExpandSets returns the sets prescribed for the exercise.
+func (e *WorkoutExercise) ExpandSets() []*workoutexercise.SetModel {
+	return expandSets(e.Sets, e.Repetitions, e.Weight, e.SetPrescriptions)
+}
+
+// ExpandSets returns the sets prescribed for the exercise.
+func (r *UpdateWorkoutExerciseRequest) ExpandSets() []*workoutexercise.SetModel {
+	return expandSets(r.Sets, r.Repetitions, r.Weight, r.SetPrescriptions)
+}
+
+// ExpandSets returns the sets prescribed for the exercise.
+func (r *AddWorkoutExerciseRequest) ExpandSets() []*workoutexercise.SetModel {
+	return expandSets(r.Sets, r.Repetitions, r.Weight, r.SetPrescriptions)
+}
+
+func expandSets(sets int, repetitions *int, weight *float64, prescriptions []workoutexercise.SetPrescription) []*workoutexercise.SetModel {
+	if len(prescriptions) > 0 {
+		return workoutexercise.ExpandSets(prescriptions)
+	}
+
+	return workoutexercise.UniformSets(sets, repetitions, weight)
+}
+
+// groupRules checks a group and the positions of its exercises
+func groupRules(v *validation.Validator, group ExerciseGroup, positions []int) {
+	v.String("type", group.Type).Required().OneOf(workoutexercise.GroupTypes...)
+	v.OptionalInt("time_cap", group.TimeCap).Min(1).Max(workoutexercise.MaxTimeCap)
+	v.Check(!workoutexercise.IsTimed(group.Type) || group.TimeCap != nil, workoutexercise.ErrorTimeCapIsRequired)
+	v.Check(len(positions) >= workoutexercise.MinGroupSize(group.Type), workoutexercise.ErrorGroupIsTooSmall(group.Type))
+	v.Check(workoutexercise.IsContiguous(positions), workoutexercise.ErrorGroupIsNotContiguous)
+}
+
+// prescriptionRules checks the load prescribed for a workout exercise, either
+// flat or per set
+func prescriptionRules(v *validation.Validator, sets int, repetitions *int, weight *float64, duration *int, restTime int, prescriptions []workoutexercise.SetPrescription) {
+	if len(prescriptions) > 0 {
+		v.Check(sets == 0 && repetitions == nil && weight == nil, workoutexercise.ErrorPrescriptionIsAmbiguous)
+		setPrescriptionsRules(v, prescriptions)
+	} else {
+		v.Int("sets", sets).Min(1).Max(MaxSets)
+		v.OptionalInt("repetitions", repetitions).Min(1)
+		v.OptionalFloat("weight", weight).Min(0)
+	}
+
+	v.OptionalInt("duration", duration).Min(0)
+	v.Int("rest_time", restTime).Min(0)
+}
+
+// setPrescriptionsRules checks the compact per-set prescriptions
+func setPrescriptionsRules(v *validation.Validator, prescriptions []workoutexercise.SetPrescription) {
+	total := 0
+	validation.Each(v, "set_prescriptions", prescriptions, func(v *validation.Validator, prescription workoutexercise.SetPrescription) {
+		total += prescription.SetCount()
+
+		v.Int("count", prescription.Count).Min(0).Max(MaxSets)
+		_, _, ok := workoutexercise.ParseRepetitions(prescription.Reps)
+		v.Check(ok, workoutexercise.ErrorRepsIsInvalid)
+		_, ok = workoutexercise.ParseLoad(prescription.Load)
+		v.Check(ok, workoutexercise.ErrorLoadIsInvalid)
+		if prescription.Tempo != nil {
+			_, ok = workoutexercise.ParseTempo(*prescription.Tempo)
+			v.Check(ok, workoutexercise.ErrorTempoIsInvalid)
+		}
+		v.OptionalInt("rest", prescription.Rest).Min(0)
+	})
+	v.Check(total <= MaxSets, ErrorSetPrescriptionsIsTooLong)
+}
+
+func (p *ListWorkoutsQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	p.Rules(v)
+	p.SortRules(v, SortFields...)
+	p.CursorRules(v)
+	p.ProjectionRules(v, Fields, Expansions)
+	v.String("name", p.Name).MaxLength(NameMaxLength)
+	v.String("created_from", p.CreatedFrom).Date()
+	v.String("created_to", p.CreatedTo).Date()
+	v.String("updated_from", p.UpdatedFrom).Date()
+	v.String("updated_to", p.UpdatedTo).Date()
+	v.Check(isOrdered(p.CreatedFrom, p.CreatedTo), ErrorCreatedRangeIsInvalid)
+	v.Check(isOrdered(p.UpdatedFrom, p.UpdatedTo), ErrorUpdatedRangeIsInvalid)
+
+	return v.Errors()
+}
+
+// isOrdered reports whether a date range does not end before it starts.
+// Dates in the YYYY-MM-DD format compare chronologically as strings.
+func isOrdered(from string, to string) bool {
+	return from == "" || to == "" || from <= to
+}
@@ -0,0 +1,267 @@
+package workout_test
+
+import (
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWorkoutRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	repetitions := 10
+	negativeWeight := -2.5
+	groupZero, groupTwo := 0, 2
+	tempo, invalidTempo := "3-1-X-0", "slow"
+
+	tests := []struct {
+		name     string
+		request  workout.CreateWorkoutRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid request",
+			request: workout.CreateWorkoutRequest{
+				Name:   "Push Day",
+				UserID: uuid.New(),
+				Exercises: []workout.WorkoutExercise{
+					{ExerciseID: uuid.New(), Sets: 3, Repetitions: &repetitions, RestTime: 60},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name:    "missing fields",
+			request: workout.CreateWorkoutRequest{},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("name", validation.CodeRequired, "Name is required"),
+				validation.NewErrorDetail("user_id", validation.CodeRequired, "User id is required"),
+			},
+		},
+		{
+			name: "invalid exercises",
+			request: workout.CreateWorkoutRequest{
+				Name:   "Push Day",
+				UserID: uuid.New(),
+				Exercises: []workout.WorkoutExercise{
+					{ExerciseID: uuid.New(), Sets: 3},
+					{Sets: 0, Weight: &negativeWeight, RestTime: -1},
+				},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("exercises[1].exercise_id", validation.CodeRequired, "Exercise id is required"),
+				validation.NewErrorDetail("exercises[1].sets", validation.CodeTooSmall, "Sets must be at least 1"),
+				validation.NewErrorDetail("exercises[1].weight", validation.CodeTooSmall, "Weight must be at least 0"),
+				validation.NewErrorDetail("exercises[1].rest_time", validation.CodeTooSmall, "Rest time must be at least 0"),
+			},
+		},
+		{
+			name: "valid set prescriptions",
+			request: workout.CreateWorkoutRequest{
+				Name:   "Push Day",
+				UserID: uuid.New(),
+				Exercises: []workout.WorkoutExercise{
+					{ExerciseID: uuid.New(), RestTime: 120, SetPrescriptions: []workoutexercise.SetPrescription{
+						{Reps: "3", Load: "RPE8", Tempo: &tempo},
+						{Count: 3, Reps: "6-8", Load: "80%"},
+					}},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid set prescriptions",
+			request: workout.CreateWorkoutRequest{
+				Name:   "Push Day",
+				UserID: uuid.New(),
+				Exercises: []workout.WorkoutExercise{
+					{ExerciseID: uuid.New(), Sets: 3, SetPrescriptions: []workoutexercise.SetPrescription{
+						{Reps: "8-", Load: "heavy", Tempo: &invalidTempo},
+						{Count: workout.MaxSets, Reps: "5"},
+					}},
+				},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("exercises[0].set_prescriptions", validation.CodeInvalid, workoutexercise.ErrorPrescriptionIsAmbiguous.Message),
+				validation.NewErrorDetail("exercises[0].set_prescriptions[0].reps", validation.CodeInvalid, workoutexercise.ErrorRepsIsInvalid.Message),
+				validation.NewErrorDetail("exercises[0].set_prescriptions[0].load", validation.CodeInvalid, workoutexercise.ErrorLoadIsInvalid.Message),
+				validation.NewErrorDetail("exercises[0].set_prescriptions[0].tempo", validation.CodeInvalid, workoutexercise.ErrorTempoIsInvalid.Message),
+				validation.NewErrorDetail("exercises[0].set_prescriptions", validation.CodeTooLong, workout.ErrorSetPrescriptionsIsTooLong.Message),
+			},
+		},
+		{
+			name: "invalid groups",
+			request: workout.CreateWorkoutRequest{
+				Name:   "Push Day",
+				UserID: uuid.New(),
+				Exercises: []workout.WorkoutExercise{
+					{ExerciseID: uuid.New(), Group: &groupZero, Sets: 3},
+					{ExerciseID: uuid.New(), Sets: 3},
+					{ExerciseID: uuid.New(), Group: &groupZero, Sets: 3},
+					{ExerciseID: uuid.New(), Group: &groupTwo, Sets: 3},
+				},
+				Groups: []workout.ExerciseGroup{
+					{Type: workoutexercise.GroupTypeSuperset},
+					{Type: workoutexercise.GroupTypeAMRAP},
+				},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("exercises[3].group", validation.CodeNotFound, workout.ErrorGroupNotFound.Message),
+				validation.NewErrorDetail("groups[0].exercises", validation.CodeInvalid, workoutexercise.ErrorGroupIsNotContiguous.Message),
+				validation.NewErrorDetail("groups[1].time_cap", validation.CodeRequired, workoutexercise.ErrorTimeCapIsRequired.Message),
+				validation.NewErrorDetail("groups[1].exercises", validation.CodeTooShort, "Exercises must have at least 1 items in amrap groups"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestUpdateWorkoutExerciseRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	zero := 0
+
+	tests := []struct {
+		name     string
+		request  workout.UpdateWorkoutExerciseRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name:     "valid request",
+			request:  workout.UpdateWorkoutExerciseRequest{Sets: 4, RestTime: 90},
+			expected: nil,
+		},
+		{
+			name:    "invalid prescription",
+			request: workout.UpdateWorkoutExerciseRequest{Sets: workout.MaxSets + 1, Repetitions: &zero},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("sets", validation.CodeTooLarge, "Sets must be at most 100"),
+				validation.NewErrorDetail("repetitions", validation.CodeTooSmall, "Repetitions must be at least 1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestAddWorkoutExerciseRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		request  workout.AddWorkoutExerciseRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name:     "valid request",
+			request:  workout.AddWorkoutExerciseRequest{ExerciseID: uuid.New(), Sets: 3, RestTime: 60},
+			expected: nil,
+		},
+		{
+			name:    "missing fields",
+			request: workout.AddWorkoutExerciseRequest{},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("exercise_id", validation.CodeRequired, "Exercise id is required"),
+				validation.NewErrorDetail("sets", validation.CodeTooSmall, "Sets must be at least 1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestListWorkoutsQueryParamsValidate(t *testing.T) {
+	t.Parallel()
+
+	noExpansions, unsupportedExpansions := "", "exercises,user"
+
+	tests := []struct {
+		name     string
+		params   workout.ListWorkoutsQueryParams
+		expected *response.ErrorDetails
+	}{
+		{
+			name: "valid params",
+			params: workout.ListWorkoutsQueryParams{
+				ListQueryParams: base.ListQueryParams{Sort: "-updated_at,name"},
+				Name:            "push",
+				CreatedFrom:     "2025-01-01",
+				CreatedTo:       "2025-01-01",
+			},
+			expected: nil,
+		},
+		{
+			name:   "unsupported sort field",
+			params: workout.ListWorkoutsQueryParams{ListQueryParams: base.ListQueryParams{Sort: "name,-user_id"}},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("sort", validation.CodeInvalidChoice, "Sort must only use: name, created_at, updated_at"),
+			},
+		},
+		{
+			name: "valid projection",
+			params: workout.ListWorkoutsQueryParams{
+				Projection: base.Projection{Fields: "id,name", Expand: &noExpansions},
+			},
+			expected: nil,
+		},
+		{
+			name: "unsupported projection",
+			params: workout.ListWorkoutsQueryParams{
+				Projection: base.Projection{Fields: "name,deleted_at", Expand: &unsupportedExpansions},
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("fields", validation.CodeInvalidChoice, "Fields must only use: id, name, user_id, created_at, updated_at"),
+				validation.NewErrorDetail("expand", validation.CodeInvalidChoice, "Expand must only use: exercises, exercises.exercise"),
+			},
+		},
+		{
+			name: "invalid dates",
+			params: workout.ListWorkoutsQueryParams{
+				CreatedFrom: "2025-02-01",
+				CreatedTo:   "2025-01-31",
+				UpdatedFrom: "yesterday",
+			},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("updated_from", validation.CodeInvalidDate, "Updated from must be a date in the YYYY-MM-DD format"),
+				workout.ErrorCreatedRangeIsInvalid,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.params.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
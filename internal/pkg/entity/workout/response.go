@@ -0,0 +1,82 @@
+package workout
+
+import (
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/google/uuid"
+)
+
+type (
+	WorkoutResponse struct {
+		ID        uuid.UUID                                 `json:"id"`
+		UserID    uuid.UUID                                 `json:"user_id"`
+		Name      string                                    `json:"name"`
+		Exercises []workoutexercise.WorkoutExerciseResponse `json:"exercises,omitempty"` // Ordered by position, when loaded
+		Blocks    []workoutexercise.BlockResponse           `json:"blocks,omitempty"`    // Exercises grouped into steps, when loaded
+		CreatedAt time.Time                                 `json:"created_at"`
+		UpdatedAt time.Time                                 `json:"updated_at"`
+	}
+
+	// SharedWorkoutResponse is the read-only preview of a shared workout. It
+	// leaves out who owns the workout.
+	SharedWorkoutResponse struct {
+		Name      string                                    `json:"name"`
+		Exercises []workoutexercise.WorkoutExerciseResponse `json:"exercises"`
+		Blocks    []workoutexercise.BlockResponse           `json:"blocks"`
+	}
+
+	ShareLinkResponse struct {
+		ID        uuid.UUID `json:"id"`
+		WorkoutID uuid.UUID `json:"workout_id"`
+		Token     string    `json:"token"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+func NewWorkoutResponse(model *Model) WorkoutResponse {
+	return WorkoutResponse{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		Name:      model.Name,
+		Exercises: workoutexercise.NewWorkoutExerciseResponses(model.WorkoutExercises),
+		Blocks:    workoutexercise.NewBlockResponses(model.Blocks),
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+func NewWorkoutResponses(models []*Model) []WorkoutResponse {
+	responses := make([]WorkoutResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewWorkoutResponse(model)
+	}
+
+	return responses
+}
+
+func NewSharedWorkoutResponse(model *Model) SharedWorkoutResponse {
+	return SharedWorkoutResponse{
+		Name:      model.Name,
+		Exercises: workoutexercise.NewWorkoutExerciseResponses(model.WorkoutExercises),
+		Blocks:    workoutexercise.NewBlockResponses(model.Blocks),
+	}
+}
+
+func NewShareLinkResponse(model *ShareLinkModel) ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:        model.ID,
+		WorkoutID: model.WorkoutID,
+		Token:     model.Token,
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+func NewShareLinkResponses(models []*ShareLinkModel) []ShareLinkResponse {
+	responses := make([]ShareLinkResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewShareLinkResponse(model)
+	}
+
+	return responses
+}
@@ -0,0 +1,103 @@
+package workout_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkoutResponseContract(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 2, 19, 10, 0, 0, 0, time.UTC)
+	workoutID := uuid.MustParse("9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a01")
+	groupID := uuid.MustParse("9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a02")
+	repetitions, weight, rest := 8, 80.0, 90
+
+	model := &workout.Model{
+		Model:  base.Model{ID: workoutID, CreatedAt: createdAt, UpdatedAt: createdAt},
+		UserID: uuid.MustParse("9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a03"),
+		Name:   "Push Day",
+		WorkoutExercises: []*workoutexercise.Model{{
+			Model:       base.Model{ID: uuid.MustParse("9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a04"), CreatedAt: createdAt, UpdatedAt: createdAt},
+			WorkoutID:   workoutID,
+			ExerciseID:  uuid.MustParse("9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a05"),
+			Position:    1,
+			GroupID:     &groupID,
+			Sets:        1,
+			Repetitions: &repetitions,
+			Weight:      &weight,
+			RestTime:    rest,
+			Exercise: &exercise.Model{
+				Model: base.Model{ID: uuid.MustParse("9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a05"), CreatedAt: createdAt, UpdatedAt: createdAt},
+				Name:  "Bench press",
+			},
+			Group: &workoutexercise.GroupModel{ID: groupID, WorkoutID: workoutID, Type: workoutexercise.GroupTypeSuperset},
+			SetPrescriptions: []*workoutexercise.SetModel{
+				{Position: 1, MinRepetitions: &repetitions, MaxRepetitions: &repetitions, Weight: &weight, RestTime: &rest},
+			},
+		}},
+	}
+	model.Arrange()
+
+	data, err := json.Marshal(workout.NewWorkoutResponse(model))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a01",
+		"user_id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a03",
+		"name": "Push Day",
+		"exercises": [{
+			"id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a04",
+			"workout_id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a01",
+			"exercise_id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a05",
+			"position": 1,
+			"group_id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a02",
+			"sets": 1,
+			"repetitions": 8,
+			"weight": 80,
+			"duration": null,
+			"rest_time": 90,
+			"notes": null,
+			"exercise": {
+				"id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a05",
+				"name": "Bench press",
+				"description": "",
+				"created_at": "2025-02-19T10:00:00Z",
+				"updated_at": "2025-02-19T10:00:00Z"
+			},
+			"set_prescriptions": [{
+				"position": 1,
+				"min_repetitions": 8,
+				"max_repetitions": 8,
+				"weight": 80,
+				"percentage": null,
+				"rpe": null,
+				"tempo": null,
+				"rest_time": 90
+			}],
+			"created_at": "2025-02-19T10:00:00Z",
+			"updated_at": "2025-02-19T10:00:00Z"
+		}],
+		"blocks": [{
+			"group": {"id": "9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a02", "type": "superset", "time_cap": null},
+			"workout_exercise_ids": ["9b2e0c1e-1d7a-4f0e-8a59-3f1d5c2b7a04"]
+		}],
+		"created_at": "2025-02-19T10:00:00Z",
+		"updated_at": "2025-02-19T10:00:00Z"
+	}`, string(data))
+
+	t.Run("should leave out the owner of shared workouts", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(workout.NewSharedWorkoutResponse(&workout.Model{Name: "Pull Day"}))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"name": "Pull Day", "exercises": [], "blocks": []}`, string(data))
+	})
+}
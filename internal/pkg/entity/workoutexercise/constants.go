@@ -0,0 +1,94 @@
+package workoutexercise
+
+import (
+	"fmt"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// GroupTypeSuperset alternates two exercises without rest between them
+	GroupTypeSuperset = "superset"
+	// GroupTypeGiantSet chains three or more exercises without rest between them
+	GroupTypeGiantSet = "giant_set"
+	// GroupTypeCircuit repeats a sequence of exercises for rounds
+	GroupTypeCircuit = "circuit"
+	// GroupTypeEMOM starts the exercises every minute on the minute until the time cap
+	GroupTypeEMOM = "emom"
+	// GroupTypeAMRAP repeats the exercises for as many rounds as possible within the time cap
+	GroupTypeAMRAP = "amrap"
+
+	// MaxTimeCap is the longest time cap, in seconds, a timed block may have
+	MaxTimeCap = 7200
+
+	// MaxRepetitions is the largest number of repetitions a set may prescribe
+	MaxRepetitions = 1000
+
+	// MaxPercentage is the heaviest load, as a percentage of the one rep max, a set may prescribe
+	MaxPercentage = 120.0
+
+	// MinRPE and MaxRPE bound the rate of perceived exertion a set may target
+	MinRPE = 1.0
+	MaxRPE = 10.0
+)
+
+// GroupTypes lists every supported group type.
+var GroupTypes = []string{
+	GroupTypeSuperset,
+	GroupTypeGiantSet,
+	GroupTypeCircuit,
+	GroupTypeEMOM,
+	GroupTypeAMRAP,
+}
+
+var (
+	// ErrorTimeCapIsRequired is the error message for timed blocks without a time cap
+	ErrorTimeCapIsRequired response.ErrorDetail = validation.NewErrorDetail("time_cap", validation.CodeRequired, "Time cap is required for EMOM and AMRAP blocks")
+	// ErrorGroupIsNotContiguous is the error message for groups whose exercises are not next to each other
+	ErrorGroupIsNotContiguous response.ErrorDetail = validation.NewErrorDetail("exercises", validation.CodeInvalid, "Exercises of a group must be next to each other")
+	// ErrorRepsIsInvalid is the error message for set prescriptions with unreadable repetitions
+	ErrorRepsIsInvalid response.ErrorDetail = validation.NewErrorDetail("reps", validation.CodeInvalid, "Reps must be a number or a range like 8-12")
+	// ErrorLoadIsInvalid is the error message for set prescriptions with an unreadable load
+	ErrorLoadIsInvalid response.ErrorDetail = validation.NewErrorDetail("load", validation.CodeInvalid, "Load must be a weight like 100, a percentage like 75% or an RPE like RPE8")
+	// ErrorTempoIsInvalid is the error message for set prescriptions with an unreadable tempo
+	ErrorTempoIsInvalid response.ErrorDetail = validation.NewErrorDetail("tempo", validation.CodeInvalid, "Tempo must have four phases of digits or X, like 3-1-X-0")
+	// ErrorPrescriptionIsAmbiguous is the error message for exercises mixing the flat and per-set prescriptions
+	ErrorPrescriptionIsAmbiguous response.ErrorDetail = validation.NewErrorDetail("set_prescriptions", validation.CodeInvalid, "Set prescriptions can't be combined with sets, repetitions or weight")
+)
+
+// ErrorGroupIsTooSmall is the error message for groups with fewer exercises
+// than their type needs.
+func ErrorGroupIsTooSmall(groupType string) response.ErrorDetail {
+	return validation.NewErrorDetail("exercises", validation.CodeTooShort,
+		fmt.Sprintf("Exercises must have at least %d items in %s groups", MinGroupSize(groupType), groupType))
+}
+
+// MinGroupSize returns how many exercises a group of the given type needs.
+func MinGroupSize(groupType string) int {
+	switch groupType {
+	case GroupTypeSuperset, GroupTypeCircuit:
+		return 2
+	case GroupTypeGiantSet:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// IsTimed reports whether groups of the given type run against a time cap.
+func IsTimed(groupType string) bool {
+	return groupType == GroupTypeEMOM || groupType == GroupTypeAMRAP
+}
+
+// IsContiguous reports whether sorted positions follow each other without
+// gaps, which keeps the exercises of a group next to each other.
+func IsContiguous(positions []int) bool {
+	for index := 1; index < len(positions); index++ {
+		if positions[index] != positions[index-1]+1 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,6 +1,9 @@
 package workoutexercise
 
 import (
+	"context"
+	"slices"
+
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
 	"github.com/google/uuid"
@@ -11,14 +14,96 @@ type (
 	Model struct {
 		bun.BaseModel `bun:"workout_exercises"`
 		base.Model
-		WorkoutID   uuid.UUID       `bun:"workout_id"`
-		ExerciseID  uuid.UUID       `bun:"exercise_id"`
-		Sets        int             `bun:"sets"`
-		Repetitions *int            `bun:"repetitions"`
-		Weight      *float64        `bun:"weight"`
-		Duration    *int            `bun:"duration"`
-		RestTime    int             `bun:"rest_time"`
-		Notes       *string         `bun:"notes"`
-		Exercise    *exercise.Model `bun:"rel:belongs-to,join:exercise_id=id"`
+		WorkoutID        uuid.UUID       `bun:"workout_id"`
+		ExerciseID       uuid.UUID       `bun:"exercise_id"`
+		Position         int             `bun:"position"`
+		GroupID          *uuid.UUID      `bun:"group_id"`
+		Sets             int             `bun:"sets"`
+		Repetitions      *int            `bun:"repetitions"`
+		Weight           *float64        `bun:"weight"`
+		Duration         *int            `bun:"duration"`
+		RestTime         int             `bun:"rest_time"`
+		Notes            *string         `bun:"notes"`
+		Exercise         *exercise.Model `bun:"rel:belongs-to,join:exercise_id=id"`
+		Group            *GroupModel     `bun:"rel:belongs-to,join:group_id=id"`
+		SetPrescriptions []*SetModel     `bun:"rel:has-many,join:id=workout_exercise_id"`
+	}
+
+	// SetModel is the prescription of a single set of a workout exercise. A
+	// fixed number of repetitions has equal minimum and maximum.
+	SetModel struct {
+		bun.BaseModel     `bun:"table:workout_exercise_sets,alias:workout_exercise_set"`
+		ID                uuid.UUID `bun:"id,pk"`
+		WorkoutExerciseID uuid.UUID `bun:"workout_exercise_id"`
+		Position          int       `bun:"position"`
+		MinRepetitions    *int      `bun:"min_repetitions"`
+		MaxRepetitions    *int      `bun:"max_repetitions"`
+		Weight            *float64  `bun:"weight"`
+		Percentage        *float64  `bun:"percentage"`
+		RPE               *float64  `bun:"rpe"`
+		Tempo             *string   `bun:"tempo"`
+		RestTime          *int      `bun:"rest_time"`
+	}
+
+	// GroupModel links exercises of a workout that are performed together,
+	// such as a superset or an AMRAP block.
+	GroupModel struct {
+		bun.BaseModel `bun:"table:workout_exercise_groups,alias:workout_exercise_group"`
+		ID            uuid.UUID `bun:"id,pk"`
+		WorkoutID     uuid.UUID `bun:"workout_id"`
+		Type          string    `bun:"type"`
+		TimeCap       *int      `bun:"time_cap"`
+	}
+
+	// Block is a step of a workout: either a single exercise or the
+	// exercises of a group, in order.
+	Block struct {
+		Group     *GroupModel
+		Exercises []*Model
 	}
 )
+
+func (m *GroupModel) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+func (m *SetModel) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// Arrange sorts exercises by position and splits them into blocks. Each
+// group becomes a single block placed where its first exercise is.
+func Arrange(exercises []*Model) []*Block {
+	slices.SortStableFunc(exercises, func(a, b *Model) int {
+		return a.Position - b.Position
+	})
+
+	var blocks []*Block
+	groupBlocks := make(map[uuid.UUID]*Block)
+
+	for _, exercise := range exercises {
+		if exercise.GroupID == nil {
+			blocks = append(blocks, &Block{Exercises: []*Model{exercise}})
+			continue
+		}
+
+		if block, ok := groupBlocks[*exercise.GroupID]; ok {
+			block.Exercises = append(block.Exercises, exercise)
+			continue
+		}
+
+		block := &Block{Group: exercise.Group, Exercises: []*Model{exercise}}
+		groupBlocks[*exercise.GroupID] = block
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
@@ -0,0 +1,38 @@
+package workoutexercise_test
+
+import (
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrange(t *testing.T) {
+	t.Parallel()
+
+	superset := &workoutexercise.GroupModel{ID: uuid.New(), Type: workoutexercise.GroupTypeSuperset}
+
+	squat := &workoutexercise.Model{Position: 0}
+	benchPress := &workoutexercise.Model{Position: 1, GroupID: &superset.ID, Group: superset}
+	row := &workoutexercise.Model{Position: 2, GroupID: &superset.ID, Group: superset}
+	plank := &workoutexercise.Model{Position: 3}
+
+	exercises := []*workoutexercise.Model{plank, row, squat, benchPress}
+	blocks := workoutexercise.Arrange(exercises)
+
+	assert.Equal(t, []*workoutexercise.Model{squat, benchPress, row, plank}, exercises)
+	assert.Equal(t, []*workoutexercise.Block{
+		{Exercises: []*workoutexercise.Model{squat}},
+		{Group: superset, Exercises: []*workoutexercise.Model{benchPress, row}},
+		{Exercises: []*workoutexercise.Model{plank}},
+	}, blocks)
+}
+
+func TestIsContiguous(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, workoutexercise.IsContiguous(nil))
+	assert.True(t, workoutexercise.IsContiguous([]int{2, 3, 4}))
+	assert.False(t, workoutexercise.IsContiguous([]int{0, 2}))
+}
@@ -0,0 +1,215 @@
+package workoutexercise
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Notation is a prescription value that clients may send either as a
+	// JSON number or as a string, e.g. 5, "8-12", "75%" or "RPE8".
+	Notation string
+
+	// SetPrescription is the compact request format of one or more identical
+	// sets. Reps is a number or a range such as "8-12". Load is a weight such
+	// as 100 or "100kg", a percentage of the one rep max such as "75%", or an
+	// RPE target such as "RPE8" or "@8". Tempo uses the four phase notation,
+	// e.g. "3-1-X-0", and Rest overrides the rest time of the exercise.
+	SetPrescription struct {
+		Count int      `json:"count"`
+		Reps  Notation `json:"reps"`
+		Load  Notation `json:"load"`
+		Tempo *string  `json:"tempo"`
+		Rest  *int     `json:"rest"`
+	}
+
+	// Load is the target of a set. At most one of its fields is set, and a
+	// set without load is performed with bodyweight.
+	Load struct {
+		Weight     *float64
+		Percentage *float64
+		RPE        *float64
+	}
+)
+
+func (n *Notation) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*n = ""
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+
+		*n = Notation(value)
+		return nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+
+	*n = Notation(number)
+	return nil
+}
+
+// ParseRepetitions parses a number of repetitions or a range of them.
+func ParseRepetitions(notation Notation) (minimum int, maximum int, ok bool) {
+	value := strings.TrimSpace(string(notation))
+
+	lower, upper, isRange := strings.Cut(value, "-")
+	if !isRange {
+		upper = lower
+	}
+
+	minimum, err := strconv.Atoi(strings.TrimSpace(lower))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	maximum, err = strconv.Atoi(strings.TrimSpace(upper))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	if minimum < 1 || maximum < minimum || maximum > MaxRepetitions {
+		return 0, 0, false
+	}
+
+	return minimum, maximum, true
+}
+
+// ParseLoad parses the load of a set. An empty notation is a valid load
+// without target.
+func ParseLoad(notation Notation) (Load, bool) {
+	value := strings.ToLower(strings.ReplaceAll(string(notation), " ", ""))
+
+	switch {
+	case value == "":
+		return Load{}, true
+	case strings.HasSuffix(value, "%"):
+		percentage, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || percentage <= 0 || percentage > MaxPercentage {
+			return Load{}, false
+		}
+
+		return Load{Percentage: &percentage}, true
+	case strings.HasPrefix(value, "rpe"), strings.HasPrefix(value, "@"):
+		rpe, err := strconv.ParseFloat(strings.TrimLeft(strings.TrimPrefix(value, "rpe"), "@"), 64)
+		if err != nil || rpe < MinRPE || rpe > MaxRPE {
+			return Load{}, false
+		}
+
+		return Load{RPE: &rpe}, true
+	default:
+		weight, err := strconv.ParseFloat(strings.TrimSuffix(value, "kg"), 64)
+		if err != nil || weight < 0 {
+			return Load{}, false
+		}
+
+		return Load{Weight: &weight}, true
+	}
+}
+
+// ParseTempo normalizes a tempo to its four phases, so "3-1-x-0" becomes
+// "31X0".
+func ParseTempo(tempo string) (string, bool) {
+	value := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(tempo), "-", ""))
+	if len(value) != 4 {
+		return "", false
+	}
+
+	for _, phase := range value {
+		if (phase < '0' || phase > '9') && phase != 'X' {
+			return "", false
+		}
+	}
+
+	return value, true
+}
+
+// SetCount returns how many sets a prescription expands into.
+func (p SetPrescription) SetCount() int {
+	return max(p.Count, 1)
+}
+
+// ExpandSets turns set prescriptions into ordered sets. It must only be
+// called after the prescriptions were validated.
+func ExpandSets(prescriptions []SetPrescription) []*SetModel {
+	var sets []*SetModel
+
+	for _, prescription := range prescriptions {
+		minimum, maximum, _ := ParseRepetitions(prescription.Reps)
+		load, _ := ParseLoad(prescription.Load)
+
+		var tempo *string
+		if prescription.Tempo != nil {
+			normalized, _ := ParseTempo(*prescription.Tempo)
+			tempo = &normalized
+		}
+
+		for range prescription.SetCount() {
+			sets = append(sets, &SetModel{
+				Position:       len(sets),
+				MinRepetitions: &minimum,
+				MaxRepetitions: &maximum,
+				Weight:         load.Weight,
+				Percentage:     load.Percentage,
+				RPE:            load.RPE,
+				Tempo:          tempo,
+				RestTime:       prescription.Rest,
+			})
+		}
+	}
+
+	return sets
+}
+
+// UniformSets expands the flat prescription of an exercise into identical
+// sets.
+func UniformSets(count int, repetitions *int, weight *float64) []*SetModel {
+	sets := make([]*SetModel, count)
+	for index := range sets {
+		sets[index] = &SetModel{
+			Position:       index,
+			MinRepetitions: repetitions,
+			MaxRepetitions: repetitions,
+			Weight:         weight,
+		}
+	}
+
+	return sets
+}
+
+// Summarize returns the flat prescription matching sets: their count, and the
+// repetitions and weight shared by every set, if any.
+func Summarize(sets []*SetModel) (count int, repetitions *int, weight *float64) {
+	if len(sets) == 0 {
+		return 0, nil, nil
+	}
+
+	first := sets[0]
+	if first.MinRepetitions != nil && first.MaxRepetitions != nil && *first.MinRepetitions == *first.MaxRepetitions {
+		repetitions = first.MinRepetitions
+	}
+	weight = first.Weight
+
+	for _, set := range sets[1:] {
+		if repetitions != nil && (set.MinRepetitions == nil || set.MaxRepetitions == nil ||
+			*set.MinRepetitions != *repetitions || *set.MaxRepetitions != *repetitions) {
+			repetitions = nil
+		}
+
+		if weight != nil && (set.Weight == nil || *set.Weight != *weight) {
+			weight = nil
+		}
+	}
+
+	return len(sets), repetitions, weight
+}
@@ -0,0 +1,142 @@
+package workoutexercise_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotationUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var prescription workoutexercise.SetPrescription
+	err := json.Unmarshal([]byte(`{"count": 3, "reps": 5, "load": "75%"}`), &prescription)
+
+	assert.Nil(t, err)
+	assert.Equal(t, workoutexercise.Notation("5"), prescription.Reps)
+	assert.Equal(t, workoutexercise.Notation("75%"), prescription.Load)
+
+	err = json.Unmarshal([]byte(`{"reps": true}`), &prescription)
+	assert.NotNil(t, err)
+}
+
+func TestParseRepetitions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		notation workoutexercise.Notation
+		minimum  int
+		maximum  int
+		ok       bool
+	}{
+		{notation: "5", minimum: 5, maximum: 5, ok: true},
+		{notation: "8-12", minimum: 8, maximum: 12, ok: true},
+		{notation: " 8 - 12 ", minimum: 8, maximum: 12, ok: true},
+		{notation: "12-8", ok: false},
+		{notation: "0", ok: false},
+		{notation: "", ok: false},
+		{notation: "five", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.notation), func(t *testing.T) {
+			t.Parallel()
+
+			minimum, maximum, ok := workoutexercise.ParseRepetitions(tt.notation)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.minimum, minimum)
+			assert.Equal(t, tt.maximum, maximum)
+		})
+	}
+}
+
+func TestParseLoad(t *testing.T) {
+	t.Parallel()
+
+	weight, percentage, rpe := 102.5, 75.0, 8.5
+
+	tests := []struct {
+		notation workoutexercise.Notation
+		expected workoutexercise.Load
+		ok       bool
+	}{
+		{notation: "", expected: workoutexercise.Load{}, ok: true},
+		{notation: "102.5", expected: workoutexercise.Load{Weight: &weight}, ok: true},
+		{notation: "102.5 kg", expected: workoutexercise.Load{Weight: &weight}, ok: true},
+		{notation: "75%", expected: workoutexercise.Load{Percentage: &percentage}, ok: true},
+		{notation: "RPE 8.5", expected: workoutexercise.Load{RPE: &rpe}, ok: true},
+		{notation: "@8.5", expected: workoutexercise.Load{RPE: &rpe}, ok: true},
+		{notation: "150%", ok: false},
+		{notation: "RPE11", ok: false},
+		{notation: "-5", ok: false},
+		{notation: "heavy", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.notation), func(t *testing.T) {
+			t.Parallel()
+
+			load, ok := workoutexercise.ParseLoad(tt.notation)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, load)
+		})
+	}
+}
+
+func TestParseTempo(t *testing.T) {
+	t.Parallel()
+
+	tempo, ok := workoutexercise.ParseTempo("3-1-x-0")
+	assert.True(t, ok)
+	assert.Equal(t, "31X0", tempo)
+
+	_, ok = workoutexercise.ParseTempo("3-1-0")
+	assert.False(t, ok)
+
+	_, ok = workoutexercise.ParseTempo("3A10")
+	assert.False(t, ok)
+}
+
+func TestExpandSets(t *testing.T) {
+	t.Parallel()
+
+	rest := 180
+	sets := workoutexercise.ExpandSets([]workoutexercise.SetPrescription{
+		{Reps: "3", Load: "RPE8", Rest: &rest},
+		{Count: 3, Reps: "6-8", Load: "80%"},
+	})
+
+	assert.Len(t, sets, 4)
+	for index, set := range sets {
+		assert.Equal(t, index, set.Position)
+	}
+
+	assert.Equal(t, 3, *sets[0].MinRepetitions)
+	assert.Equal(t, 8.0, *sets[0].RPE)
+	assert.Equal(t, &rest, sets[0].RestTime)
+	assert.Equal(t, 6, *sets[3].MinRepetitions)
+	assert.Equal(t, 8, *sets[3].MaxRepetitions)
+	assert.Equal(t, 80.0, *sets[3].Percentage)
+	assert.Nil(t, sets[3].RestTime)
+}
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	repetitions, weight := 10, 20.0
+
+	count, summarizedRepetitions, summarizedWeight := workoutexercise.Summarize(workoutexercise.UniformSets(3, &repetitions, &weight))
+	assert.Equal(t, 3, count)
+	assert.Equal(t, &repetitions, summarizedRepetitions)
+	assert.Equal(t, &weight, summarizedWeight)
+
+	count, summarizedRepetitions, summarizedWeight = workoutexercise.Summarize(workoutexercise.ExpandSets([]workoutexercise.SetPrescription{
+		{Reps: "5", Load: "100"},
+		{Count: 2, Reps: "8-10", Load: "80"},
+	}))
+	assert.Equal(t, 3, count)
+	assert.Nil(t, summarizedRepetitions)
+	assert.Nil(t, summarizedWeight)
+}
@@ -0,0 +1,120 @@
+package workoutexercise
+
+import (
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/google/uuid"
+)
+
+type (
+	WorkoutExerciseResponse struct {
+		ID               uuid.UUID                  `json:"id"`
+		WorkoutID        uuid.UUID                  `json:"workout_id"`
+		ExerciseID       uuid.UUID                  `json:"exercise_id"`
+		Position         int                        `json:"position"`
+		GroupID          *uuid.UUID                 `json:"group_id"`
+		Sets             int                        `json:"sets"`
+		Repetitions      *int                       `json:"repetitions"`
+		Weight           *float64                   `json:"weight"`
+		Duration         *int                       `json:"duration"`
+		RestTime         int                        `json:"rest_time"`
+		Notes            *string                    `json:"notes"`
+		Exercise         *exercise.ExerciseResponse `json:"exercise,omitempty"`          // When loaded
+		SetPrescriptions []SetResponse              `json:"set_prescriptions,omitempty"` // Per set prescription, when loaded
+		CreatedAt        time.Time                  `json:"created_at"`
+		UpdatedAt        time.Time                  `json:"updated_at"`
+	}
+
+	SetResponse struct {
+		Position       int      `json:"position"`
+		MinRepetitions *int     `json:"min_repetitions"`
+		MaxRepetitions *int     `json:"max_repetitions"`
+		Weight         *float64 `json:"weight"`
+		Percentage     *float64 `json:"percentage"` // Load as a percentage of the one rep max
+		RPE            *float64 `json:"rpe"`
+		Tempo          *string  `json:"tempo"`
+		RestTime       *int     `json:"rest_time"`
+	}
+
+	GroupResponse struct {
+		ID      uuid.UUID `json:"id"`
+		Type    string    `json:"type"`
+		TimeCap *int      `json:"time_cap"` // Seconds, for timed groups
+	}
+
+	// BlockResponse is a step of a workout, referring to its exercises by id.
+	BlockResponse struct {
+		Group              *GroupResponse `json:"group"` // Nil for a single exercise
+		WorkoutExerciseIDs []uuid.UUID    `json:"workout_exercise_ids"`
+	}
+)
+
+func NewWorkoutExerciseResponse(model *Model) WorkoutExerciseResponse {
+	workoutExercise := WorkoutExerciseResponse{
+		ID:               model.ID,
+		WorkoutID:        model.WorkoutID,
+		ExerciseID:       model.ExerciseID,
+		Position:         model.Position,
+		GroupID:          model.GroupID,
+		Sets:             model.Sets,
+		Repetitions:      model.Repetitions,
+		Weight:           model.Weight,
+		Duration:         model.Duration,
+		RestTime:         model.RestTime,
+		Notes:            model.Notes,
+		SetPrescriptions: make([]SetResponse, len(model.SetPrescriptions)),
+		CreatedAt:        model.CreatedAt,
+		UpdatedAt:        model.UpdatedAt,
+	}
+
+	if model.Exercise != nil {
+		exerciseResponse := exercise.NewExerciseResponse(model.Exercise)
+		workoutExercise.Exercise = &exerciseResponse
+	}
+
+	for index, set := range model.SetPrescriptions {
+		workoutExercise.SetPrescriptions[index] = SetResponse{
+			Position:       set.Position,
+			MinRepetitions: set.MinRepetitions,
+			MaxRepetitions: set.MaxRepetitions,
+			Weight:         set.Weight,
+			Percentage:     set.Percentage,
+			RPE:            set.RPE,
+			Tempo:          set.Tempo,
+			RestTime:       set.RestTime,
+		}
+	}
+
+	return workoutExercise
+}
+
+func NewWorkoutExerciseResponses(models []*Model) []WorkoutExerciseResponse {
+	responses := make([]WorkoutExerciseResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewWorkoutExerciseResponse(model)
+	}
+
+	return responses
+}
+
+func NewBlockResponses(blocks []*Block) []BlockResponse {
+	responses := make([]BlockResponse, len(blocks))
+
+	for index, block := range blocks {
+		responses[index].WorkoutExerciseIDs = make([]uuid.UUID, len(block.Exercises))
+		for exerciseIndex, workoutExercise := range block.Exercises {
+			responses[index].WorkoutExerciseIDs[exerciseIndex] = workoutExercise.ID
+		}
+
+		if block.Group != nil {
+			responses[index].Group = &GroupResponse{
+				ID:      block.Group.ID,
+				Type:    block.Group.Type,
+				TimeCap: block.Group.TimeCap,
+			}
+		}
+	}
+
+	return responses
+}
@@ -0,0 +1,40 @@
+package workouthistory
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+)
+
+const (
+	// NotesMaxLength is the maximum number of characters allowed in session notes
+	NotesMaxLength = 2000
+
+	// EventSetLogged is published when a set of an exercise is logged
+	EventSetLogged = "set_logged"
+	// EventExerciseStarted is published when the user moves on to an exercise
+	EventExerciseStarted = "exercise_started"
+	// EventRestTimerStarted is published when a rest timer starts
+	EventRestTimerStarted = "rest_timer_started"
+	// EventSessionFinished is published when the session is completed
+	EventSessionFinished = "session_finished"
+)
+
+// PublishedEvents are the events clients publish. Sessions finish by being
+// completed.
+var PublishedEvents = []string{EventSetLogged, EventExerciseStarted, EventRestTimerStarted}
+
+var (
+	// ErrorWorkoutIsRequired is the error message for starting a session without a workout
+	ErrorWorkoutIsRequired response.ErrorDetail = validation.NewErrorDetail("workout_id", validation.CodeRequired, "Workout id or scheduled workout id is required")
+	// ErrorWorkoutIsAmbiguous is the error message for starting a session from both a workout and a scheduled workout
+	ErrorWorkoutIsAmbiguous response.ErrorDetail = validation.NewErrorDetail("workout_id", validation.CodeInvalid, "Provide either workout id or scheduled workout id, not both")
+	// ErrorWorkoutExerciseIsRequired is the error message for exercise events without an exercise
+	ErrorWorkoutExerciseIsRequired response.ErrorDetail = validation.NewErrorDetail("workout_exercise_id", validation.CodeRequired, "Workout exercise id is required")
+	// ErrorSetIsRequired is the error message for logged sets without a set number
+	ErrorSetIsRequired response.ErrorDetail = validation.NewErrorDetail("set", validation.CodeRequired, "Set is required")
+	// ErrorDurationIsRequired is the error message for rest timers without a duration
+	ErrorDurationIsRequired response.ErrorDetail = validation.NewErrorDetail("duration", validation.CodeRequired, "Duration is required")
+)
+
+// SortFields are the fields lists of sessions can be sorted by.
+var SortFields = []string{"started_at", "completed_at"}
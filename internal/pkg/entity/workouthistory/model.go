@@ -9,13 +9,32 @@ import (
 )
 
 type (
+	// Model is a workout session: one performance of a workout, started
+	// either ad hoc or from a scheduled workout, and completed once finished.
 	Model struct {
-		bun.BaseModel `bun:"workout_history"`
-		ID            uuid.UUID `bun:"id,pk"`
-		UserID        uuid.UUID `bun:"user_id"`
-		WorkoutID     uuid.UUID `bun:"workout_id"`
-		CompletedAt   time.Time `bun:"completed_at"`
-		Notes         *string   `bun:"notes"`
+		bun.BaseModel      `bun:"workout_history"`
+		ID                 uuid.UUID  `bun:"id,pk"`
+		UserID             uuid.UUID  `bun:"user_id"`
+		WorkoutID          uuid.UUID  `bun:"workout_id"`
+		ScheduledWorkoutID *uuid.UUID `bun:"scheduled_workout_id"`
+		StartedAt          time.Time  `bun:"started_at"`
+		CompletedAt        *time.Time `bun:"completed_at"`
+		Notes              *string    `bun:"notes"`
+		UpdatedAt          time.Time  `bun:"updated_at"`
+		DeletedAt          *time.Time `bun:"deleted_at,soft_delete,nullzero"`
+	}
+
+	// Event is something that happened during a session, streamed to the
+	// devices watching it as it happens. Events are not stored.
+	Event struct {
+		Type              string     `json:"type"`
+		SessionID         uuid.UUID  `json:"session_id"`
+		WorkoutExerciseID *uuid.UUID `json:"workout_exercise_id,omitempty"`
+		Set               *int       `json:"set,omitempty"`
+		Repetitions       *int       `json:"repetitions,omitempty"`
+		Weight            *float64   `json:"weight,omitempty"`
+		Duration          *int       `json:"duration,omitempty"` // In seconds
+		OccurredAt        time.Time  `json:"occurred_at"`
 	}
 )
 
@@ -23,7 +42,38 @@ func (m *Model) BeforeAppendModel(ctx context.Context, query bun.Query) error {
 	switch query.(type) {
 	case *bun.InsertQuery:
 		m.ID = uuid.New()
-		m.CompletedAt = time.Now()
+
+		// Sessions logged offline keep the start time the client gave them.
+		if m.StartedAt.IsZero() {
+			m.StartedAt = time.Now()
+		}
+
+		m.UpdatedAt = time.Now()
+	case *bun.UpdateQuery:
+		m.UpdatedAt = time.Now()
 	}
 	return nil
 }
+
+func (m *Model) IsCompleted() bool {
+	return m.CompletedAt != nil
+}
+
+// NewEvent returns the event a client published to the session.
+func (m *Model) NewEvent(params PublishEventRequest) Event {
+	return Event{
+		Type:              params.Type,
+		SessionID:         m.ID,
+		WorkoutExerciseID: params.WorkoutExerciseID,
+		Set:               params.Set,
+		Repetitions:       params.Repetitions,
+		Weight:            params.Weight,
+		Duration:          params.Duration,
+		OccurredAt:        time.Now(),
+	}
+}
+
+// EventTopic is the topic the events of session id are published to.
+func EventTopic(id uuid.UUID) string {
+	return "sessions/" + id.String()
+}
@@ -0,0 +1,93 @@
+package workouthistory
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/base"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+)
+
+type (
+	StartSessionRequest struct {
+		WorkoutID          *uuid.UUID `json:"workout_id"`
+		ScheduledWorkoutID *uuid.UUID `json:"scheduled_workout_id"`
+	}
+
+	CompleteSessionRequest struct {
+		Notes *string `json:"notes"`
+	}
+
+	ListSessionsQueryParams struct {
+		base.ListQueryParams
+	}
+
+	// PublishEventRequest reports what happened during a session to the
+	// devices watching it. Duration is in seconds: the time a set lasted or
+	// the length of a rest timer.
+	PublishEventRequest struct {
+		Type              string     `json:"type"`
+		WorkoutExerciseID *uuid.UUID `json:"workout_exercise_id"`
+		Set               *int       `json:"set"`
+		Repetitions       *int       `json:"repetitions"`
+		Weight            *float64   `json:"weight"`
+		Duration          *int       `json:"duration"`
+	}
+)
+
+func (r *StartSessionRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.Check(r.WorkoutID != nil || r.ScheduledWorkoutID != nil, ErrorWorkoutIsRequired)
+	v.Check(r.WorkoutID == nil || r.ScheduledWorkoutID == nil, ErrorWorkoutIsAmbiguous)
+
+	if r.WorkoutID != nil {
+		v.UUID("workout_id", *r.WorkoutID).Required()
+	}
+
+	if r.ScheduledWorkoutID != nil {
+		v.UUID("scheduled_workout_id", *r.ScheduledWorkoutID).Required()
+	}
+
+	return v.Errors()
+}
+
+func (r *CompleteSessionRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.OptionalString("notes", r.Notes).MaxLength(NotesMaxLength)
+
+	return v.Errors()
+}
+
+func (p *ListSessionsQueryParams) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	p.Rules(v)
+	p.SortRules(v, SortFields...)
+	p.CursorRules(v)
+
+	return v.Errors()
+}
+
+func (r *PublishEventRequest) Validate() *response.ErrorDetails {
+	v := validation.New()
+
+	v.String("type", r.Type).Required().OneOf(PublishedEvents...)
+
+	switch r.Type {
+	case EventSetLogged:
+		v.Check(r.WorkoutExerciseID != nil, ErrorWorkoutExerciseIsRequired)
+		v.Check(r.Set != nil, ErrorSetIsRequired)
+	case EventExerciseStarted:
+		v.Check(r.WorkoutExerciseID != nil, ErrorWorkoutExerciseIsRequired)
+	case EventRestTimerStarted:
+		v.Check(r.Duration != nil, ErrorDurationIsRequired)
+	}
+
+	v.OptionalInt("set", r.Set).Min(1)
+	v.OptionalInt("repetitions", r.Repetitions).Min(0)
+	v.OptionalFloat("weight", r.Weight).Min(0)
+	v.OptionalInt("duration", r.Duration).Min(1)
+
+	return v.Errors()
+}
@@ -0,0 +1,124 @@
+package workouthistory_test
+
+import (
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartSessionRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	workoutID := uuid.New()
+	scheduledWorkoutID := uuid.New()
+
+	tests := []struct {
+		name     string
+		request  workouthistory.StartSessionRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name:     "ad hoc session",
+			request:  workouthistory.StartSessionRequest{WorkoutID: &workoutID},
+			expected: nil,
+		},
+		{
+			name:     "scheduled session",
+			request:  workouthistory.StartSessionRequest{ScheduledWorkoutID: &scheduledWorkoutID},
+			expected: nil,
+		},
+		{
+			name:     "missing workout",
+			request:  workouthistory.StartSessionRequest{},
+			expected: &response.ErrorDetails{workouthistory.ErrorWorkoutIsRequired},
+		},
+		{
+			name:     "ambiguous workout",
+			request:  workouthistory.StartSessionRequest{WorkoutID: &workoutID, ScheduledWorkoutID: &scheduledWorkoutID},
+			expected: &response.ErrorDetails{workouthistory.ErrorWorkoutIsAmbiguous},
+		},
+		{
+			name:    "nil workout id",
+			request: workouthistory.StartSessionRequest{WorkoutID: &uuid.Nil},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("workout_id", validation.CodeRequired, "Workout id is required"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.request.Validate()
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func TestPublishEventRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	workoutExerciseID := uuid.New()
+	set := 2
+	repetitions := 8
+	duration := 90
+	negative := -1
+
+	tests := []struct {
+		name     string
+		request  workouthistory.PublishEventRequest
+		expected *response.ErrorDetails
+	}{
+		{
+			name:     "logged set",
+			request:  workouthistory.PublishEventRequest{Type: workouthistory.EventSetLogged, WorkoutExerciseID: &workoutExerciseID, Set: &set, Repetitions: &repetitions},
+			expected: nil,
+		},
+		{
+			name:     "started rest timer",
+			request:  workouthistory.PublishEventRequest{Type: workouthistory.EventRestTimerStarted, Duration: &duration},
+			expected: nil,
+		},
+		{
+			name:    "logged set without an exercise",
+			request: workouthistory.PublishEventRequest{Type: workouthistory.EventSetLogged, Set: &set},
+			expected: &response.ErrorDetails{
+				workouthistory.ErrorWorkoutExerciseIsRequired,
+			},
+		},
+		{
+			name:    "started exercise without an exercise",
+			request: workouthistory.PublishEventRequest{Type: workouthistory.EventExerciseStarted},
+			expected: &response.ErrorDetails{
+				workouthistory.ErrorWorkoutExerciseIsRequired,
+			},
+		},
+		{
+			name:    "finished session",
+			request: workouthistory.PublishEventRequest{Type: workouthistory.EventSessionFinished},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("type", validation.CodeInvalidChoice, "Type must be one of: set_logged, exercise_started, rest_timer_started"),
+			},
+		},
+		{
+			name:    "negative repetitions",
+			request: workouthistory.PublishEventRequest{Type: workouthistory.EventSetLogged, WorkoutExerciseID: &workoutExerciseID, Set: &set, Repetitions: &negative},
+			expected: &response.ErrorDetails{
+				validation.NewErrorDetail("repetitions", validation.CodeTooSmall, "Repetitions must be at least 0"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.request.Validate())
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package workouthistory
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	SessionResponse struct {
+		ID                 uuid.UUID  `json:"id"`
+		WorkoutID          uuid.UUID  `json:"workout_id"`
+		ScheduledWorkoutID *uuid.UUID `json:"scheduled_workout_id"` // Nil for sessions started ad hoc
+		StartedAt          time.Time  `json:"started_at"`
+		CompletedAt        *time.Time `json:"completed_at"` // Nil while the session is in progress
+		Notes              *string    `json:"notes"`
+		UpdatedAt          time.Time  `json:"updated_at"`
+	}
+)
+
+func NewSessionResponse(model *Model) SessionResponse {
+	return SessionResponse{
+		ID:                 model.ID,
+		WorkoutID:          model.WorkoutID,
+		ScheduledWorkoutID: model.ScheduledWorkoutID,
+		StartedAt:          model.StartedAt,
+		CompletedAt:        model.CompletedAt,
+		Notes:              model.Notes,
+		UpdatedAt:          model.UpdatedAt,
+	}
+}
+
+func NewSessionResponses(models []*Model) []SessionResponse {
+	responses := make([]SessionResponse, len(models))
+	for index, model := range models {
+		responses[index] = NewSessionResponse(model)
+	}
+
+	return responses
+}
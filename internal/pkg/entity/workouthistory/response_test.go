@@ -0,0 +1,41 @@
+package workouthistory_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionResponseContract(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2025, 2, 19, 10, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(time.Hour)
+	notes := "Felt strong"
+
+	model := &workouthistory.Model{
+		ID:          uuid.MustParse("3d1f7a2c-5b6e-4c8d-9e0f-1a2b3c4d5e01"),
+		UserID:      uuid.MustParse("3d1f7a2c-5b6e-4c8d-9e0f-1a2b3c4d5e02"),
+		WorkoutID:   uuid.MustParse("3d1f7a2c-5b6e-4c8d-9e0f-1a2b3c4d5e03"),
+		StartedAt:   startedAt,
+		CompletedAt: &completedAt,
+		Notes:       &notes,
+		UpdatedAt:   completedAt,
+	}
+
+	data, err := json.Marshal(workouthistory.NewSessionResponse(model))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"id": "3d1f7a2c-5b6e-4c8d-9e0f-1a2b3c4d5e01",
+		"workout_id": "3d1f7a2c-5b6e-4c8d-9e0f-1a2b3c4d5e03",
+		"scheduled_workout_id": null,
+		"started_at": "2025-02-19T10:00:00Z",
+		"completed_at": "2025-02-19T11:00:00Z",
+		"notes": "Felt strong",
+		"updated_at": "2025-02-19T11:00:00Z"
+	}`, string(data))
+}
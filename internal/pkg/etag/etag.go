@@ -0,0 +1,98 @@
+package etag
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	// Precondition is the list of entity tags of an If-Match or If-None-Match
+	// header. Malformed tags are kept out, so they never match.
+	Precondition struct {
+		wildcard bool
+		tags     []string
+	}
+)
+
+// Format returns the entity tag of a resource version.
+func Format(version int) string {
+	return FormatTag(strconv.Itoa(version))
+}
+
+// FormatTag returns the entity tag of a tag value, for resources whose
+// representation changes with more than their version.
+func FormatTag(tag string) string {
+	return `"` + tag + `"`
+}
+
+// Parse reads an If-Match or If-None-Match header, returning nil when it is
+// empty. Weak tags are compared as strong ones since every tag issued by the
+// API is strong.
+func Parse(header string) *Precondition {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return nil
+	}
+
+	if header == "*" {
+		return &Precondition{wildcard: true}
+	}
+
+	precondition := &Precondition{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+
+		precondition.tags = append(precondition.tags, tag[1:len(tag)-1])
+	}
+
+	return precondition
+}
+
+// Matches reports whether the precondition lists the tag of the version or
+// is a wildcard.
+func (p *Precondition) Matches(version int) bool {
+	return p.MatchesTag(strconv.Itoa(version))
+}
+
+// MatchesTag reports whether the precondition lists the tag value or is a
+// wildcard.
+func (p *Precondition) MatchesTag(tag string) bool {
+	return p.wildcard || slices.Contains(p.tags, tag)
+}
+
+// IfMatch returns the precondition of the If-Match header of the request, or
+// nil when the request is unconditional.
+func IfMatch(c *fiber.Ctx) *Precondition {
+	return Parse(c.Get(fiber.HeaderIfMatch))
+}
+
+// Set sets the ETag header of the response to the tag of version.
+func Set(c *fiber.Ctx, version int) {
+	SetTag(c, strconv.Itoa(version))
+}
+
+// SetTag sets the ETag header of the response to the tag value.
+func SetTag(c *fiber.Ctx, tag string) {
+	c.Set(fiber.HeaderETag, FormatTag(tag))
+}
+
+// NotModified sets the ETag header of the response to the tag of version and
+// reports whether the If-None-Match header of the request already lists it,
+// in which case the client copy is current and the handler should answer 304.
+func NotModified(c *fiber.Ctx, version int) bool {
+	return NotModifiedTag(c, strconv.Itoa(version))
+}
+
+// NotModifiedTag is NotModified for a tag value.
+func NotModifiedTag(c *fiber.Ctx, tag string) bool {
+	SetTag(c, tag)
+
+	ifNoneMatch := Parse(c.Get(fiber.HeaderIfNoneMatch))
+	return ifNoneMatch != nil && ifNoneMatch.MatchesTag(tag)
+}
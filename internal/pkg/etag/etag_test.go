@@ -0,0 +1,102 @@
+package etag_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/etag"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		header    string
+		isNil     bool
+		matches   []int
+		unmatched []int
+	}{
+		{name: "should ignore an empty header", header: " ", isNil: true},
+		{name: "should match any version with a wildcard", header: "*", matches: []int{1, 7}},
+		{name: "should match a listed version", header: `"3"`, matches: []int{3}, unmatched: []int{2, 4}},
+		{name: "should match every listed version", header: `"1", W/"2"`, matches: []int{1, 2}, unmatched: []int{3}},
+		{name: "should match nothing with foreign tags", header: `"abc", 4`, unmatched: []int{4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			precondition := etag.Parse(test.header)
+			if test.isNil {
+				assert.Nil(t, precondition)
+				return
+			}
+
+			assert.NotNil(t, precondition)
+
+			for _, version := range test.matches {
+				assert.True(t, precondition.Matches(version))
+			}
+
+			for _, version := range test.unmatched {
+				assert.False(t, precondition.Matches(version))
+			}
+		})
+	}
+}
+
+func TestMatchesTag(t *testing.T) {
+	t.Parallel()
+
+	precondition := etag.Parse(`"3-1k2f", "4"`)
+
+	assert.True(t, precondition.MatchesTag("3-1k2f"))
+	assert.True(t, precondition.Matches(4))
+	assert.False(t, precondition.MatchesTag("3"))
+	assert.True(t, etag.Parse("*").MatchesTag("3-1k2f"))
+}
+
+func TestNotModified(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if etag.NotModified(c, 2) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{name: "should answer an unconditional request", status: http.StatusOK},
+		{name: "should answer when the client copy is stale", ifNoneMatch: `"1"`, status: http.StatusOK},
+		{name: "should not answer when the client copy is current", ifNoneMatch: `"1", "2"`, status: http.StatusNotModified},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.ifNoneMatch != "" {
+				req.Header.Set(fiber.HeaderIfNoneMatch, test.ifNoneMatch)
+			}
+
+			resp, err := app.Test(req)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.status, resp.StatusCode)
+			assert.Equal(t, `"2"`, resp.Header.Get(fiber.HeaderETag))
+		})
+	}
+}
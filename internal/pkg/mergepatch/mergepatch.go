@@ -0,0 +1,116 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+)
+
+// MIMEMergePatchJSON is the media type of JSON merge patches.
+const MIMEMergePatchJSON = "application/merge-patch+json"
+
+var ErrNotAnObject = errors.New("merge patch is not a JSON object")
+
+type (
+	// Patch is a JSON merge patch (RFC 7396) of a resource. Members it sets
+	// to null are removed from the resource, and members it omits are left
+	// as they are.
+	Patch struct {
+		members map[string]any
+	}
+)
+
+// Parse reads a merge patch, which must be a JSON object.
+func Parse(data []byte) (Patch, error) {
+	var members map[string]any
+	if err := decode(data, &members); err != nil {
+		return Patch{}, err
+	}
+
+	if members == nil {
+		return Patch{}, ErrNotAnObject
+	}
+
+	return Patch{members: members}, nil
+}
+
+// Has reports whether the patch sets or removes any of the top level
+// members.
+func (p Patch) Has(names ...string) bool {
+	return slices.ContainsFunc(names, func(name string) bool {
+		_, ok := p.members[name]
+		return ok
+	})
+}
+
+// Members returns the names, out of the given ones, of the top level members
+// the patch sets or removes.
+func (p Patch) Members(names ...string) []string {
+	var members []string
+	for _, name := range names {
+		if p.Has(name) {
+			members = append(members, name)
+		}
+	}
+
+	return members
+}
+
+// Apply patches the JSON encoding of target, a pointer to the current state
+// of the resource, and decodes the result back into it. Removed members are
+// left at their zero value.
+func (p Patch) Apply(target any) error {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	var document any
+	if err := decode(data, &document); err != nil {
+		return err
+	}
+
+	data, err = json.Marshal(merge(document, p.members))
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(target).Elem()
+	value.SetZero()
+
+	return json.Unmarshal(data, target)
+}
+
+// merge implements the MergePatch function of RFC 7396.
+func merge(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+
+		targetObject[name] = merge(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// decode keeps numbers as written, so large integers survive the round trip.
+func decode(data []byte, value any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
@@ -0,0 +1,92 @@
+package mergepatch_test
+
+import (
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/stretchr/testify/assert"
+)
+
+type resource struct {
+	Name   string         `json:"name"`
+	Notes  *string        `json:"notes"`
+	Tags   []string       `json:"tags"`
+	Labels map[string]any `json:"labels"`
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		patch string
+		valid bool
+	}{
+		{name: "should parse an object", patch: `{"name":"Push Day"}`, valid: true},
+		{name: "should parse an empty object", patch: `{}`, valid: true},
+		{name: "should reject null", patch: `null`},
+		{name: "should reject an array", patch: `[{"name":"Push Day"}]`},
+		{name: "should reject invalid json", patch: `{"name":`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := mergepatch.Parse([]byte(test.patch))
+			assert.Equal(t, test.valid, err == nil)
+		})
+	}
+}
+
+func TestPatchApply(t *testing.T) {
+	t.Parallel()
+
+	notes := "Keep the elbows tucked"
+
+	tests := []struct {
+		name     string
+		patch    string
+		members  []string
+		expected resource
+	}{
+		{
+			name:     "should leave omitted members as they are",
+			patch:    `{}`,
+			expected: resource{Name: "Push Day", Notes: &notes, Tags: []string{"chest"}, Labels: map[string]any{"level": "beginner", "split": "ppl"}},
+		},
+		{
+			name:     "should replace set members",
+			patch:    `{"name":"Pull Day","tags":["back"]}`,
+			members:  []string{"name", "tags"},
+			expected: resource{Name: "Pull Day", Notes: &notes, Tags: []string{"back"}, Labels: map[string]any{"level": "beginner", "split": "ppl"}},
+		},
+		{
+			name:     "should remove members set to null",
+			patch:    `{"notes":null,"tags":null}`,
+			members:  []string{"notes", "tags"},
+			expected: resource{Name: "Push Day", Labels: map[string]any{"level": "beginner", "split": "ppl"}},
+		},
+		{
+			name:     "should merge nested objects",
+			patch:    `{"labels":{"level":"advanced","split":null}}`,
+			members:  []string{"labels"},
+			expected: resource{Name: "Push Day", Notes: &notes, Tags: []string{"chest"}, Labels: map[string]any{"level": "advanced"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			patch, err := mergepatch.Parse([]byte(test.patch))
+			assert.Nil(t, err)
+
+			target := resource{Name: "Push Day", Notes: &notes, Tags: []string{"chest"}, Labels: map[string]any{"level": "beginner", "split": "ppl"}}
+
+			assert.Nil(t, patch.Apply(&target))
+			assert.Equal(t, test.expected, target)
+			assert.Equal(t, test.members, patch.Members("name", "notes", "tags", "labels"))
+		})
+	}
+}
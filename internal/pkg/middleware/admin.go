@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminMiddleware only lets through sessions whose email is one of the
+// configured admin emails. It must run after AuthMiddleware.
+func AdminMiddleware(adminEmails []string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !IsAdmin(c, adminEmails) {
+			return domainerr.Forbidden("Forbidden")
+		}
+
+		return c.Next()
+	}
+}
+
+// IsAdmin reports whether the session of the request belongs to one of the
+// configured admin emails. The request must have passed AuthMiddleware.
+func IsAdmin(c *fiber.Ctx, adminEmails []string) bool {
+	claims, ok := c.Locals("session").(*jwt.Claims)
+
+	return ok && slices.ContainsFunc(adminEmails, func(email string) bool {
+		return strings.EqualFold(email, claims.Email)
+	})
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"strings"
 
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,18 +13,14 @@ func AuthMiddleware(secret string) fiber.Handler {
 		token := c.Get("Authorization")
 
 		if token == "" && !strings.HasPrefix(token, "Bearer ") {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"message": "Unauthorized",
-			})
+			return domainerr.Unauthorized("Unauthorized")
 		}
 
 		tokenStr := strings.TrimPrefix(token, "Bearer ")
 
 		claims, err := jwt.ValidateToken(tokenStr, secret)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-				"message": "Unauthorized",
-			})
+			return domainerr.Unauthorized("Unauthorized")
 		}
 
 		c.Locals("session", claims)
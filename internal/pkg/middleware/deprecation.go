@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	// DeprecationPolicy is announced on every response of a deprecated route.
+	DeprecationPolicy struct {
+		DeprecatedAt time.Time // Sent in the Deprecation header when set
+		SunsetAt     time.Time // Sent in the Sunset header when set
+	}
+
+	DeprecationParams struct {
+		Policy DeprecationPolicy // Policy of routes without their own
+
+		// Routes overrides the policy of single routes, keyed by method and
+		// Fiber path, e.g. "GET /workouts/:id".
+		Routes map[string]DeprecationPolicy
+
+		// Successor prefixes the request path to link the route that replaces
+		// the deprecated one, e.g. /v1.
+		Successor string
+	}
+)
+
+// DeprecationMiddleware marks the routes registered after it as deprecated,
+// following RFC 9745 and RFC 8594. The headers are set once the route is
+// known, so requests that match no route are left untouched.
+func DeprecationMiddleware(params DeprecationParams) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		route := c.Route()
+
+		err := c.Next()
+		if c.Route() == route {
+			return err
+		}
+
+		policy, ok := params.Routes[routeKey(c.Route())]
+		if !ok {
+			policy = params.Policy
+		}
+
+		if !policy.DeprecatedAt.IsZero() {
+			c.Set("Deprecation", fmt.Sprintf("@%d", policy.DeprecatedAt.Unix()))
+		}
+
+		if !policy.SunsetAt.IsZero() {
+			c.Set("Sunset", policy.SunsetAt.UTC().Format(http.TimeFormat))
+		}
+
+		if params.Successor != "" {
+			c.Append(fiber.HeaderLink, fmt.Sprintf(`<%s%s>; rel="successor-version"`, params.Successor, c.Path()))
+		}
+
+		return err
+	}
+}
+
+// routeKey identifies a route in DeprecationParams.Routes. The trailing slash
+// Fiber adds to the root route of a group is dropped.
+func routeKey(route *fiber.Route) string {
+	path := route.Path
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return route.Method + " " + path
+}
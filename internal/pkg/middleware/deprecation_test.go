@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationMiddleware(t *testing.T) {
+	t.Parallel()
+
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC)
+	routeSunsetAt := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	handler := func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	register := func(router fiber.Router) {
+		group := router.Group("/workouts")
+		group.Get("/", handler)
+		group.Get("/:id", handler)
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	register(app.Group("/v1"))
+	register(app.Group("", middleware.DeprecationMiddleware(middleware.DeprecationParams{
+		Policy: middleware.DeprecationPolicy{DeprecatedAt: deprecatedAt, SunsetAt: sunsetAt},
+		Routes: map[string]middleware.DeprecationPolicy{
+			"GET /workouts/:id": {DeprecatedAt: deprecatedAt, SunsetAt: routeSunsetAt},
+		},
+		Successor: "/v1",
+	})))
+
+	tests := []struct {
+		name        string
+		path        string
+		status      int
+		deprecation string
+		sunset      string
+		link        string
+	}{
+		{
+			name:   "versioned route",
+			path:   "/v1/workouts",
+			status: http.StatusOK,
+		},
+		{
+			name:        "legacy route with the default policy",
+			path:        "/workouts",
+			status:      http.StatusOK,
+			deprecation: "@1792368000",
+			sunset:      "Thu, 01 Apr 2027 00:00:00 GMT",
+			link:        `</v1/workouts>; rel="successor-version"`,
+		},
+		{
+			name:        "legacy route with its own policy",
+			path:        "/workouts/123",
+			status:      http.StatusOK,
+			deprecation: "@1792368000",
+			sunset:      "Fri, 01 Jan 2027 00:00:00 GMT",
+			link:        `</v1/workouts/123>; rel="successor-version"`,
+		},
+		{
+			name:   "unknown route",
+			path:   "/unknown",
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+			assert.Equal(t, tt.deprecation, resp.Header.Get("Deprecation"))
+			assert.Equal(t, tt.sunset, resp.Header.Get("Sunset"))
+			assert.Equal(t, tt.link, resp.Header.Get("Link"))
+		})
+	}
+}
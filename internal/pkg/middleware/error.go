@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// internalErrorCode is the problem code of errors that are not domain or
+// Fiber errors.
+const internalErrorCode = "internal"
+
+// ErrorHandler renders errors returned by handlers as response.ErrorResponse,
+// or as RFC 7807 problem details when the client prefers
+// application/problem+json. Domain and Fiber errors keep their status and
+// message; anything else is logged and reported as a generic internal error
+// so no internal details reach the client.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	code, errorResponse := buildErrorResponse(c, err)
+
+	if acceptsProblemJSON(c) {
+		return c.Status(errorResponse.Code).JSON(
+			response.NewProblemDetailsFromError(code, errorResponse, RequestID(c)),
+			response.MIMEProblemJSON)
+	}
+
+	return c.Status(errorResponse.Code).JSON(errorResponse)
+}
+
+func buildErrorResponse(c *fiber.Ctx, err error) (string, response.ErrorResponse) {
+	var domainErr *domainerr.Error
+	if errors.As(err, &domainErr) {
+		errorResponse := response.NewErrorResponse(domainErr.Message, domainErr.Status(), domainErr.Details)
+		errorResponse.Meta = domainErr.Meta
+
+		return string(domainErr.Kind), errorResponse
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return "", response.NewErrorResponse(fiberErr.Message, fiberErr.Code, nil)
+	}
+
+	fmt.Printf("request %s %s failed: %v\n", c.Method(), c.OriginalURL(), err)
+
+	return internalErrorCode, response.NewErrorResponse("Internal server error.", fiber.StatusInternalServerError, nil)
+}
+
+// acceptsProblemJSON reports whether the Accept header prefers problem details
+// over the default JSON error shape. Clients that send no Accept header or
+// accept anything keep the default shape.
+func acceptsProblemJSON(c *fiber.Ctx) bool {
+	return c.Accepts(fiber.MIMEApplicationJSON, response.MIMEProblemJSON) == response.MIMEProblemJSON
+}
@@ -0,0 +1,159 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected response.ErrorResponse
+	}{
+		{
+			name: "domain error",
+			err: domainerr.InvalidRequestBody(&response.ErrorDetails{
+				{Field: "name", Code: "required", Message: "Name is required"},
+			}),
+			expected: response.NewErrorResponse("Invalid request body.", http.StatusBadRequest, &response.ErrorDetails{
+				{Field: "name", Code: "required", Message: "Name is required"},
+			}),
+		},
+		{
+			name:     "fiber error",
+			err:      fiber.ErrMethodNotAllowed,
+			expected: response.NewErrorResponse("Method Not Allowed", http.StatusMethodNotAllowed, nil),
+		},
+		{
+			name:     "unexpected error",
+			err:      errors.New("pq: relation \"users\" does not exist"),
+			expected: response.NewErrorResponse("Internal server error.", http.StatusInternalServerError, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+			app.Get("/", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected.Code, resp.StatusCode)
+
+			var body response.ErrorResponse
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
+
+func TestErrorHandlerProblemDetails(t *testing.T) {
+	t.Parallel()
+
+	details := &response.ErrorDetails{
+		{Field: "exercises[0].sets", Code: "too_small", Message: "Sets must be at least 1"},
+	}
+
+	tests := []struct {
+		name            string
+		accept          string
+		err             error
+		expectedProblem bool
+		expected        response.ProblemDetails
+	}{
+		{
+			name:            "domain error with field errors",
+			accept:          response.MIMEProblemJSON,
+			err:             domainerr.InvalidRequestBody(details),
+			expectedProblem: true,
+			expected: response.ProblemDetails{
+				Type:   "/problems/validation",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "Invalid request body.",
+				Code:   "validation",
+				Errors: details,
+			},
+		},
+		{
+			name:            "fiber error",
+			accept:          "application/problem+json, text/html;q=0.5",
+			err:             fiber.ErrMethodNotAllowed,
+			expectedProblem: true,
+			expected: response.ProblemDetails{
+				Type:   response.ProblemTypeBlank,
+				Title:  "Method Not Allowed",
+				Status: http.StatusMethodNotAllowed,
+				Detail: "Method Not Allowed",
+			},
+		},
+		{
+			name:            "unexpected error",
+			accept:          response.MIMEProblemJSON,
+			err:             errors.New("pq: connection refused"),
+			expectedProblem: true,
+			expected: response.ProblemDetails{
+				Type:   "/problems/internal",
+				Title:  "Internal Server Error",
+				Status: http.StatusInternalServerError,
+				Detail: "Internal server error.",
+				Code:   "internal",
+			},
+		},
+		{
+			name:            "client preferring plain json",
+			accept:          "application/json, application/problem+json;q=0.5",
+			err:             domainerr.NotFound("Workout not found"),
+			expectedProblem: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+			app.Use(middleware.TraceMiddleware())
+			app.Get("/", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+
+			resp, err := app.Test(req)
+			assert.Nil(t, err)
+
+			if !tt.expectedProblem {
+				assert.Equal(t, fiber.MIMEApplicationJSON, resp.Header.Get("Content-Type"))
+				return
+			}
+
+			assert.Equal(t, response.MIMEProblemJSON, resp.Header.Get("Content-Type"))
+			assert.Equal(t, tt.expected.Status, resp.StatusCode)
+
+			var body response.ProblemDetails
+			assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			tt.expected.Instance = resp.Header.Get("X-Request-ID")
+			assert.NotEmpty(t, body.Instance)
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
@@ -5,13 +5,24 @@ import (
 	"github.com/google/uuid"
 )
 
+// RequestIDLocal is the Locals key holding the ID of the current request.
+const RequestIDLocal = "requestID"
+
 func TraceMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		requestID := uuid.New().String()
 		c.Set("X-Request-ID", requestID)
+		c.Locals(RequestIDLocal, requestID)
 
 		c.Response().Header.Set("X-Request-ID", requestID)
 
 		return c.Next()
 	}
 }
+
+// RequestID returns the ID assigned to the request by TraceMiddleware, or an
+// empty string when the middleware did not run.
+func RequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals(RequestIDLocal).(string)
+	return requestID
+}
@@ -0,0 +1,64 @@
+package openapi
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// docsPage renders the document with Swagger UI.
+//
+//go:embed docs.html
+var docsPage []byte
+
+type (
+	HTTPHandlerParams struct {
+		App      *fiber.App
+		Document *Document
+	}
+
+	httpHandler struct {
+		document *Document
+	}
+)
+
+// NewHTTPHandler serves the document as JSON and the docs UI. It documents
+// its own routes, so it may be registered before or after the routes of the
+// other handlers are added to the document.
+func NewHTTPHandler(params HTTPHandlerParams) {
+	httpHandler := &httpHandler{
+		document: params.Document,
+	}
+
+	params.Document.Add(
+		Route{
+			Method:      fiber.MethodGet,
+			Path:        "/docs",
+			Summary:     "Render the API docs",
+			Tag:         "docs",
+			Response:    "",
+			ContentType: fiber.MIMETextHTMLCharsetUTF8,
+		},
+		Route{
+			Method:   fiber.MethodGet,
+			Path:     "/docs/openapi.json",
+			Summary:  "Get the OpenAPI document",
+			Tag:      "docs",
+			Response: map[string]any{},
+		},
+	)
+
+	docsGroup := params.App.Group("/docs")
+	docsGroup.Get("/", httpHandler.GetDocsPage)
+	docsGroup.Get("/openapi.json", httpHandler.GetDocument)
+}
+
+func (h *httpHandler) GetDocsPage(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+
+	return c.Status(fiber.StatusOK).Send(docsPage)
+}
+
+func (h *httpHandler) GetDocument(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.document)
+}
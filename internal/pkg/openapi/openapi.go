@@ -0,0 +1,285 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// Version is the OpenAPI version of the generated documents.
+	Version = "3.0.3"
+
+	// bearerAuth is the name of the security scheme of authenticated routes.
+	bearerAuth = "bearerAuth"
+)
+
+// pathParamPattern matches Fiber route parameters such as :id.
+var pathParamPattern = regexp.MustCompile(`:(\w+)`)
+
+type (
+	Document struct {
+		OpenAPI    string              `json:"openapi"`
+		Info       Info                `json:"info"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components Components          `json:"components"`
+
+		prefix     string // Prepended to the paths of added routes
+		deprecated bool   // Marks added routes as deprecated
+	}
+
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	}
+
+	// PathItem holds the operations of a path, keyed by lowercase method.
+	PathItem map[string]*Operation
+
+	Operation struct {
+		Tags        []string              `json:"tags,omitempty"`
+		Summary     string                `json:"summary,omitempty"`
+		Parameters  []Parameter           `json:"parameters,omitempty"`
+		RequestBody *RequestBody          `json:"requestBody,omitempty"`
+		Responses   map[string]Response   `json:"responses"`
+		Security    []map[string][]string `json:"security,omitempty"`
+		Deprecated  bool                  `json:"deprecated,omitempty"`
+	}
+
+	Parameter struct {
+		Name     string  `json:"name"`
+		In       string  `json:"in"` // path or query
+		Required bool    `json:"required,omitempty"`
+		Schema   *Schema `json:"schema"`
+	}
+
+	RequestBody struct {
+		Content map[string]MediaType `json:"content"`
+	}
+
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content,omitempty"`
+	}
+
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	Components struct {
+		Schemas         map[string]*Schema        `json:"schemas"`
+		SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+	}
+
+	SecurityScheme struct {
+		Type         string `json:"type"`
+		Scheme       string `json:"scheme"`
+		BearerFormat string `json:"bearerFormat,omitempty"`
+	}
+
+	Schema struct {
+		Ref                  string             `json:"$ref,omitempty"`
+		Type                 string             `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Nullable             bool               `json:"nullable,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+		AllOf                []*Schema          `json:"allOf,omitempty"`
+	}
+
+	// Route describes an endpoint registered by an HTTP handler. Query, Body
+	// and Response are sample values whose types are turned into schemas:
+	// Query is read through its query tags, Body and Response through their
+	// json tags. A nil Response documents a response without content.
+	Route struct {
+		Method          string // HTTP method, e.g. fiber.MethodGet
+		Path            string // Fiber path, e.g. /workouts/:id
+		Summary         string
+		Tag             string
+		Auth            bool // Requires a bearer token
+		Query           any
+		Body            any
+		BodyContentType string // Media type of the body, defaults to application/json
+		Status          int    // Success status, defaults to 200
+		Response        any    // Success content
+		ContentType     string // Media type of the success content, defaults to application/json
+	}
+)
+
+func New(title string, version string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+}
+
+// Group returns a view of the document that adds routes under prefix, the
+// way a Fiber group registers them.
+func (d *Document) Group(prefix string) *Document {
+	group := *d
+	group.prefix += prefix
+
+	return &group
+}
+
+// Deprecated returns a view of the document that marks the routes it adds as
+// deprecated.
+func (d *Document) Deprecated() *Document {
+	deprecated := *d
+	deprecated.deprecated = true
+
+	return &deprecated
+}
+
+// Add documents routes. A route added twice replaces the earlier one.
+func (d *Document) Add(routes ...Route) {
+	for _, route := range routes {
+		route.Path = d.prefix + route.Path
+		path := Path(route.Path)
+
+		item, ok := d.Paths[path]
+		if !ok {
+			item = PathItem{}
+			d.Paths[path] = item
+		}
+
+		item[strings.ToLower(route.Method)] = d.operation(route)
+	}
+}
+
+// Operation returns the documented operation of a Fiber route, or nil when
+// the route is not documented.
+func (d *Document) Operation(method string, path string) *Operation {
+	return d.Paths[Path(path)][strings.ToLower(method)]
+}
+
+// Path converts a Fiber path into an OpenAPI path template. Trailing slashes,
+// which Fiber adds to the root route of a group, are dropped.
+func Path(path string) string {
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+func (d *Document) operation(route Route) *Operation {
+	operation := &Operation{
+		Summary:    route.Summary,
+		Responses:  map[string]Response{},
+		Deprecated: d.deprecated,
+	}
+
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+	}
+
+	if route.Auth {
+		operation.Security = []map[string][]string{{bearerAuth: {}}}
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   pathParamSchema(match[1]),
+		})
+	}
+
+	if route.Query != nil {
+		operation.Parameters = append(operation.Parameters, d.queryParameters(reflect.TypeOf(route.Query))...)
+	}
+
+	if route.Body != nil {
+		contentType := route.BodyContentType
+		if contentType == "" {
+			contentType = fiber.MIMEApplicationJSON
+		}
+
+		operation.RequestBody = &RequestBody{
+			Content: map[string]MediaType{
+				contentType: {Schema: d.schema(reflect.TypeOf(route.Body))},
+			},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	success := Response{Description: http.StatusText(status)}
+	if route.Response != nil {
+		contentType := route.ContentType
+		if contentType == "" {
+			contentType = fiber.MIMEApplicationJSON
+		}
+
+		success.Content = map[string]MediaType{
+			contentType: {Schema: d.schema(reflect.TypeOf(route.Response))},
+		}
+	}
+
+	operation.Responses[strconv.Itoa(status)] = success
+	operation.Responses["default"] = Response{
+		Description: "Error",
+		Content: map[string]MediaType{
+			fiber.MIMEApplicationJSON: {Schema: d.schema(reflect.TypeOf(response.ErrorResponse{}))},
+			response.MIMEProblemJSON:  {Schema: d.schema(reflect.TypeOf(response.ProblemDetails{}))},
+		},
+	}
+
+	return operation
+}
+
+// pathParamSchema types ID parameters as UUIDs and anything else, such as
+// share tokens, as plain strings.
+func pathParamSchema(name string) *Schema {
+	if name == "id" || strings.HasSuffix(name, "ID") {
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	return &Schema{Type: "string"}
+}
+
+func (d *Document) queryParameters(t reflect.Type) []Parameter {
+	t = indirect(t)
+
+	var parameters []Parameter
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := field.Tag.Get("query")
+		if name == "" && field.Anonymous {
+			parameters = append(parameters, d.queryParameters(field.Type)...)
+			continue
+		}
+
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		parameters = append(parameters, Parameter{
+			Name:   name,
+			In:     "query",
+			Schema: d.schema(indirect(field.Type)),
+		})
+	}
+
+	return parameters
+}
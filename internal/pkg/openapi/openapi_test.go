@@ -0,0 +1,171 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	sampleQuery struct {
+		response.Pagination
+		Name     string     `query:"name"`
+		ParentID *uuid.UUID `query:"parent_id"`
+	}
+
+	sampleResponse struct {
+		ID        uuid.UUID         `json:"id"`
+		Notes     *string           `json:"notes"`
+		Parent    *sampleResponse   `json:"parent,omitempty"`
+		Tags      []string          `json:"tags"`
+		CreatedAt time.Time         `json:"created_at"`
+		Internal  string            `json:"-"`
+		Extra     map[string]string `json:"extra"`
+	}
+)
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/", expected: "/"},
+		{path: "/workouts/", expected: "/workouts"},
+		{path: "/workouts/:id", expected: "/workouts/{id}"},
+		{path: "/workouts/:workoutID/exercises/:workoutExerciseID", expected: "/workouts/{workoutID}/exercises/{workoutExerciseID}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, openapi.Path(tt.path))
+		})
+	}
+}
+
+func TestDocumentAdd(t *testing.T) {
+	t.Parallel()
+
+	document := openapi.New("Test", "1.0.0")
+	document.Add(openapi.Route{
+		Method:   fiber.MethodGet,
+		Path:     "/samples/:id",
+		Tag:      "samples",
+		Auth:     true,
+		Query:    sampleQuery{},
+		Response: response.SuccessResponse[sampleResponse]{},
+	})
+
+	operation := document.Operation(fiber.MethodGet, "/samples/:id")
+	assert.NotNil(t, operation)
+	assert.Nil(t, document.Operation(fiber.MethodPost, "/samples/:id"))
+
+	t.Run("should document path and query parameters", func(t *testing.T) {
+		t.Parallel()
+
+		var names []string
+		for _, parameter := range operation.Parameters {
+			names = append(names, parameter.In+":"+parameter.Name)
+		}
+
+		assert.Equal(t, []string{"path:id", "query:name", "query:parent_id"}, names)
+		assert.Equal(t, "uuid", operation.Parameters[0].Schema.Format)
+		assert.True(t, operation.Parameters[0].Required)
+		assert.False(t, operation.Parameters[2].Schema.Nullable)
+	})
+
+	t.Run("should inline envelopes and reference named structs", func(t *testing.T) {
+		t.Parallel()
+
+		envelope := operation.Responses["200"].Content[fiber.MIMEApplicationJSON].Schema
+		assert.Equal(t, "#/components/schemas/openapi_test.sampleResponse", envelope.Properties["data"].Ref)
+		assert.Equal(t, "string", envelope.Properties["status"].Type)
+		assert.Equal(t, []string{"bearerAuth"}, keys(operation.Security[0]))
+
+		schema := document.Components.Schemas["openapi_test.sampleResponse"]
+		assert.Equal(t, &openapi.Schema{Type: "string", Format: "uuid"}, schema.Properties["id"])
+		assert.Equal(t, &openapi.Schema{Type: "string", Nullable: true}, schema.Properties["notes"])
+		assert.Equal(t, "#/components/schemas/openapi_test.sampleResponse", schema.Properties["parent"].AllOf[0].Ref)
+		assert.Equal(t, "string", schema.Properties["tags"].Items.Type)
+		assert.Equal(t, "date-time", schema.Properties["created_at"].Format)
+		assert.Equal(t, "object", schema.Properties["extra"].Type)
+		assert.NotContains(t, schema.Properties, "Internal")
+	})
+
+	t.Run("should document error responses", func(t *testing.T) {
+		t.Parallel()
+
+		errorResponse := operation.Responses["default"]
+		assert.Equal(t, "#/components/schemas/response.ErrorResponse", errorResponse.Content[fiber.MIMEApplicationJSON].Schema.Ref)
+		assert.Contains(t, document.Components.Schemas, "response.ProblemDetails")
+	})
+}
+
+func TestDocumentGroup(t *testing.T) {
+	t.Parallel()
+
+	document := openapi.New("Test", "1.0.0")
+	route := openapi.Route{Method: fiber.MethodGet, Path: "/samples"}
+
+	document.Group("/v1").Add(route)
+	document.Deprecated().Add(route)
+
+	assert.False(t, document.Operation(fiber.MethodGet, "/v1/samples").Deprecated)
+	assert.True(t, document.Operation(fiber.MethodGet, "/samples").Deprecated)
+	assert.Len(t, document.Paths, 2)
+}
+
+func TestHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	app := fiber.New()
+	document := openapi.New("Test", "1.0.0")
+	openapi.NewHTTPHandler(openapi.HTTPHandlerParams{App: app, Document: document})
+
+	t.Run("should serve the document", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body openapi.Document
+		assert.Nil(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, openapi.Version, body.OpenAPI)
+		assert.Contains(t, body.Paths, "/docs/openapi.json")
+	})
+
+	t.Run("should serve the docs page", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, fiber.MIMETextHTMLCharsetUTF8, resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		assert.Nil(t, err)
+		assert.Contains(t, string(body), "openapi.json")
+	})
+}
+
+func keys(m map[string][]string) []string {
+	var result []string
+	for key := range m {
+		result = append(result, key)
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+package response
+
+import "net/http"
+
+const (
+	// MIMEProblemJSON is the media type of RFC 7807 problem details.
+	MIMEProblemJSON = "application/problem+json"
+
+	// ProblemTypeBlank is the RFC 7807 type for problems that carry no
+	// semantics beyond their HTTP status.
+	ProblemTypeBlank = "about:blank"
+
+	// problemTypeBase prefixes the type of every problem with its own code.
+	problemTypeBase = "/problems/"
+)
+
+type (
+	// ProblemDetails is the RFC 7807 representation of an error, returned to
+	// clients that accept application/problem+json.
+	ProblemDetails struct {
+		Type     string        `json:"type"`               // URI identifying the problem type
+		Title    string        `json:"title"`              // Short summary of the problem type
+		Status   int           `json:"status"`             // HTTP status code
+		Detail   string        `json:"detail,omitempty"`   // Explanation of this occurrence
+		Instance string        `json:"instance,omitempty"` // Request ID of this occurrence
+		Code     string        `json:"code,omitempty"`     // Stable machine readable error code (extension)
+		Errors   *ErrorDetails `json:"errors,omitempty"`   // Field errors (extension)
+		Meta     any           `json:"meta,omitempty"`     // Additional error metadata (extension)
+	}
+)
+
+// ProblemType returns the type URI for an error code, or about:blank when
+// there is no code.
+func ProblemType(code string) string {
+	if code == "" {
+		return ProblemTypeBlank
+	}
+
+	return problemTypeBase + code
+}
+
+func NewProblemDetails(code string, status int, detail string, instance string) ProblemDetails {
+	return ProblemDetails{
+		Type:     ProblemType(code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
+	}
+}
+
+// NewProblemDetailsFromError converts an ErrorResponse into problem details.
+func NewProblemDetailsFromError(code string, errorResponse ErrorResponse, instance string) ProblemDetails {
+	problem := NewProblemDetails(code, errorResponse.Code, errorResponse.Message, instance)
+	problem.Meta = errorResponse.Meta
+
+	if errorResponse.Details != nil && len(*errorResponse.Details) > 0 {
+		problem.Errors = errorResponse.Details
+	}
+
+	return problem
+}
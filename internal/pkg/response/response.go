@@ -18,10 +18,11 @@ type (
 	}
 
 	Pagination struct {
-		Page       int `json:"page"`        // Current page number
-		PerPage    int `json:"per_page"`    // Number of items per page
-		TotalItems int `json:"total_items"` // Total number of items
-		TotalPages int `json:"total_pages"` // Total number of pages
+		Page       int    `json:"page,omitempty"`        // Current page number, unset when paging by cursor
+		PerPage    int    `json:"per_page"`              // Number of items per page
+		TotalItems *int   `json:"total_items,omitempty"` // Total number of items, unset when the count was skipped
+		TotalPages *int   `json:"total_pages,omitempty"` // Total number of pages, unset when the count was skipped
+		NextCursor string `json:"next_cursor,omitempty"` // Cursor of the next page, unset on the last page
 	}
 
 	ErrorResponse struct {
@@ -29,11 +30,13 @@ type (
 		Message string        `json:"message"`           // Error message
 		Code    int           `json:"code"`              // HTTP status code
 		Details *ErrorDetails `json:"details,omitempty"` // Error details (optional)
+		Meta    any           `json:"meta,omitempty"`    // Additional error metadata (optional)
 	}
 
 	ErrorDetail struct {
-		Field   string `json:"field"`
-		Message string `json:"message"`
+		Field   string `json:"field"`          // Path of the invalid field (e.g. exercises[2].sets)
+		Code    string `json:"code,omitempty"` // Stable machine readable error code
+		Message string `json:"message"`        // Human readable error message
 	}
 
 	ErrorDetails []ErrorDetail
@@ -64,11 +67,17 @@ func NewPaginationResponse(data any, pagination Pagination) PaginationResponse[a
 }
 
 func (p *Pagination) SetTotalPages() {
-	if p.TotalItems == 0 {
-		p.TotalPages = 0
-	} else {
-		p.TotalPages = int(math.Ceil(float64(p.TotalItems) / float64(p.PerPage)))
+	if p.TotalItems == nil {
+		p.TotalPages = nil
+		return
 	}
+
+	totalPages := 0
+	if *p.TotalItems > 0 {
+		totalPages = int(math.Ceil(float64(*p.TotalItems) / float64(p.PerPage)))
+	}
+
+	p.TotalPages = &totalPages
 }
 
 func NewErrorResponse(message string, code int, details *ErrorDetails) ErrorResponse {
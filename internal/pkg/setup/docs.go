@@ -0,0 +1,44 @@
+package setup
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/domain/batch"
+	"github.com/Gabukuro/gymratz-api/internal/domain/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/domain/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/domain/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/domain/program"
+	"github.com/Gabukuro/gymratz-api/internal/domain/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/domain/session"
+	"github.com/Gabukuro/gymratz-api/internal/domain/trash"
+	"github.com/Gabukuro/gymratz-api/internal/domain/user"
+	"github.com/Gabukuro/gymratz-api/internal/domain/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+)
+
+const (
+	docsTitle   = "Gymratz API"
+	docsVersion = "1.0.0"
+)
+
+// NewDocument documents the routes of every domain handler, both under
+// APIVersionPrefix and as deprecated legacy aliases.
+func NewDocument() *openapi.Document {
+	document := openapi.New(docsTitle, docsVersion)
+
+	for _, docs := range []func(*openapi.Document){
+		user.Docs,
+		exercise.Docs,
+		musclegroup.Docs,
+		workout.Docs,
+		trash.Docs,
+		schedule.Docs,
+		session.Docs,
+		program.Docs,
+		batch.Docs,
+		offlinesync.Docs,
+	} {
+		docs(document.Group(APIVersionPrefix))
+		docs(document.Deprecated())
+	}
+
+	return document
+}
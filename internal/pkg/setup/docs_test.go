@@ -0,0 +1,70 @@
+package setup_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	setup "github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDocument(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should document every registered route", func(t *testing.T) {
+		t.Parallel()
+
+		app, document := newRoutedApp()
+
+		routes := app.GetRoutes(true)
+		assert.NotEmpty(t, routes)
+
+		for _, route := range routes {
+			if route.Method == fiber.MethodHead {
+				continue
+			}
+
+			assert.NotNil(t, document.Operation(route.Method, route.Path), "%s %s is not documented", route.Method, route.Path)
+		}
+	})
+
+	t.Run("should deprecate the legacy aliases", func(t *testing.T) {
+		t.Parallel()
+
+		document := setup.NewDocument()
+
+		assert.False(t, document.Operation(fiber.MethodGet, setup.APIVersionPrefix+"/workouts/:id").Deprecated)
+		assert.True(t, document.Operation(fiber.MethodGet, "/workouts/:id").Deprecated)
+	})
+
+	t.Run("should only document registered routes", func(t *testing.T) {
+		t.Parallel()
+
+		app, document := newRoutedApp()
+
+		registered := map[string]bool{}
+		for _, route := range app.GetRoutes(true) {
+			registered[route.Method+" "+openapi.Path(route.Path)] = true
+		}
+
+		for path, item := range document.Paths {
+			for method := range item {
+				method = strings.ToUpper(method)
+				assert.True(t, registered[method+" "+path], "%s %s is documented but not registered", method, path)
+			}
+		}
+	})
+}
+
+// newRoutedApp registers the routes the way setup does, returning the app
+// with the document it serves. Services are left nil since no request is
+// made.
+func newRoutedApp() (*fiber.App, *openapi.Document) {
+	app := fiber.New()
+	document := setup.RegisterRoutes(app, setup.Services{}, setup.EnvVariables{}, middleware.DeprecationParams{})
+
+	return app, document
+}
@@ -0,0 +1,118 @@
+package setup
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/domain/batch"
+	"github.com/Gabukuro/gymratz-api/internal/domain/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/domain/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/domain/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/domain/program"
+	"github.com/Gabukuro/gymratz-api/internal/domain/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/domain/session"
+	"github.com/Gabukuro/gymratz-api/internal/domain/trash"
+	"github.com/Gabukuro/gymratz-api/internal/domain/user"
+	"github.com/Gabukuro/gymratz-api/internal/domain/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+type (
+	// Services are the services of the domain handlers. Handlers only call
+	// them to answer requests, so routes can be registered without them.
+	Services struct {
+		User        *user.Service
+		Exercise    *exercise.Service
+		MuscleGroup *musclegroup.Service
+		Workout     *workout.Service
+		Trash       *trash.Service
+		Schedule    *schedule.Service
+		Session     *session.Service
+		Program     *program.Service
+		Batch       *batch.Service
+		Sync        *offlinesync.Service
+	}
+)
+
+// RegisterRoutes registers the routes of every domain handler on app, both
+// under APIVersionPrefix and as legacy aliases deprecated with legacy, and
+// serves the API docs, returning the document served.
+func RegisterRoutes(app *fiber.App, services Services, envVariables EnvVariables, legacy middleware.DeprecationParams) *openapi.Document {
+	registerHandlers := func(router fiber.Router) {
+		user.NewHTTPHandler(user.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.User,
+			JWTSecret: envVariables.JWTSecret,
+		})
+
+		exercise.NewHTTPHandler(exercise.HTTPHandlerParams{
+			Router:      router,
+			Service:     services.Exercise,
+			JWTSecret:   envVariables.JWTSecret,
+			AdminEmails: envVariables.AdminEmails,
+		})
+
+		musclegroup.NewHTTPHandler(musclegroup.HTTPHandlerParams{
+			Router:      router,
+			Service:     services.MuscleGroup,
+			JWTSecret:   envVariables.JWTSecret,
+			AdminEmails: envVariables.AdminEmails,
+		})
+
+		workout.NewHTTPHandler(workout.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.Workout,
+			JWTSecret: envVariables.JWTSecret,
+		})
+
+		trash.NewHTTPHandler(trash.HTTPHandlerParams{
+			Router:      router,
+			Service:     services.Trash,
+			JWTSecret:   envVariables.JWTSecret,
+			AdminEmails: envVariables.AdminEmails,
+		})
+
+		schedule.NewHTTPHandler(schedule.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.Schedule,
+			JWTSecret: envVariables.JWTSecret,
+		})
+
+		session.NewHTTPHandler(session.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.Session,
+			JWTSecret: envVariables.JWTSecret,
+		})
+
+		program.NewHTTPHandler(program.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.Program,
+			JWTSecret: envVariables.JWTSecret,
+		})
+
+		batch.NewHTTPHandler(batch.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.Batch,
+			JWTSecret: envVariables.JWTSecret,
+		})
+
+		offlinesync.NewHTTPHandler(offlinesync.HTTPHandlerParams{
+			Router:    router,
+			Service:   services.Sync,
+			JWTSecret: envVariables.JWTSecret,
+		})
+	}
+
+	registerHandlers(app.Group(APIVersionPrefix))
+
+	document := NewDocument()
+	openapi.NewHTTPHandler(openapi.HTTPHandlerParams{
+		App:      app,
+		Document: document,
+	})
+
+	// The legacy aliases are registered last so their deprecation middleware
+	// only runs for requests no versioned route matched.
+	registerHandlers(app.Group("", middleware.DeprecationMiddleware(legacy)))
+
+	return document
+}
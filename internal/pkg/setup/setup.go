@@ -7,16 +7,26 @@ import (
 	"io/fs"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/Gabukuro/gymratz-api/internal/domain/batch"
 	"github.com/Gabukuro/gymratz-api/internal/domain/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/domain/idempotency"
 	"github.com/Gabukuro/gymratz-api/internal/domain/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/domain/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/domain/program"
+	"github.com/Gabukuro/gymratz-api/internal/domain/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/domain/session"
+	"github.com/Gabukuro/gymratz-api/internal/domain/trash"
 	"github.com/Gabukuro/gymratz-api/internal/domain/user"
 	"github.com/Gabukuro/gymratz-api/internal/domain/workout"
+	"github.com/Gabukuro/gymratz-api/internal/infra/adapters/memory"
 	"github.com/Gabukuro/gymratz-api/internal/infra/adapters/postgres"
 	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/infra/ports/repo"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/jwt"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/middleware"
 	"github.com/caarlos0/env/v11"
@@ -26,17 +36,36 @@ import (
 	"github.com/uptrace/bun/extra/bundebug"
 )
 
+// APIVersionPrefix prefixes the routes of the current API version.
+const APIVersionPrefix = "/v1"
+
 type (
 	EnvVariables struct {
 		GoEnv           string `env:"GO_ENV" envDefault:"development"`
 		ApplicationName string `env:"APPLICATION_NAME"`
 		DatabaseURL     string `env:"DATABASE_URL"`
 		JWTSecret       string `env:"JWT_SECRET"`
+
+		AdminEmails []string `env:"ADMIN_EMAILS" envSeparator:","`
+
+		TrashRetention     time.Duration `env:"TRASH_RETENTION" envDefault:"720h"`
+		TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL" envDefault:"1h"`
+
+		IdempotencyKeyTTL         time.Duration `env:"IDEMPOTENCY_KEY_TTL" envDefault:"24h"`
+		IdempotencyKeyLockTimeout time.Duration `env:"IDEMPOTENCY_KEY_LOCK_TIMEOUT" envDefault:"1m"`
+
+		// Legacy routes are the unversioned aliases of the versioned routes.
+		// Sunsets of single routes are keyed by method and Fiber path, e.g.
+		// LEGACY_ROUTE_SUNSETS="GET /workouts/:id=2027-01-01T00:00:00Z".
+		LegacyRoutesDeprecatedAt time.Time            `env:"LEGACY_ROUTES_DEPRECATED_AT" envDefault:"2026-10-19T00:00:00Z"`
+		LegacyRoutesSunsetAt     time.Time            `env:"LEGACY_ROUTES_SUNSET_AT"`
+		LegacyRouteSunsets       map[string]time.Time `env:"LEGACY_ROUTE_SUNSETS" envSeparator:";" envKeyValSeparator:"="`
 	}
 
 	Setup struct {
-		App *fiber.App
-		DB  *bun.DB
+		App      *fiber.App
+		DB       *bun.DB
+		PurgeJob *trash.PurgeJob
 
 		ApplicationName string
 		BRLocation      time.Location
@@ -59,6 +88,7 @@ func Init() (*Setup, context.Context) {
 
 	ctx = app.configureDatabase(ctx)
 	app.configureApp()
+	app.configureJobs(ctx)
 
 	return &app, ctx
 }
@@ -79,6 +109,7 @@ func (s *Setup) configureApp() {
 	s.App = fiber.New(fiber.Config{
 		AppName:           s.EnvVariables.ApplicationName,
 		EnablePrintRoutes: true,
+		ErrorHandler:      middleware.ErrorHandler,
 	})
 
 	s.App.Use(middleware.TraceMiddleware())
@@ -87,6 +118,15 @@ func (s *Setup) configureApp() {
 	exerciseRepository := postgres.NewExerciseRepository(s.DB)
 	muscleGroupRepository := postgres.NewMuscleGroupRepository(s.DB)
 	workoutRepository := postgres.NewWorkoutRepository(s.DB)
+	trashRepository := postgres.NewTrashRepository(s.DB)
+	scheduleRepository := postgres.NewScheduleRepository(s.DB)
+	sessionRepository := postgres.NewSessionRepository(s.DB)
+	programRepository := postgres.NewProgramRepository(s.DB)
+	idempotencyRepository := postgres.NewIdempotencyRepository(s.DB)
+	syncRepository := postgres.NewSyncRepository(s.DB)
+
+	txRepository := repo.BaseRepository{}
+	txRepository.SetDB(s.DB)
 
 	tokenService := jwt.NewTokenService(jwt.TokenServiceParams{
 		JwtSecret: s.EnvVariables.JWTSecret,
@@ -106,35 +146,114 @@ func (s *Setup) configureApp() {
 	})
 
 	workoutService := workout.NewService(workout.ServiceParams{
+		WorkoutRepo:  &workoutRepository,
+		ExerciseRepo: &exerciseRepository,
+		UserRepo:     &userRepository,
+	})
+
+	trashService := trash.NewService(trash.ServiceParams{
+		TrashRepo: &trashRepository,
+		UserRepo:  &userRepository,
+	})
+
+	scheduleService := schedule.NewService(schedule.ServiceParams{
+		ScheduleRepo: &scheduleRepository,
+		WorkoutRepo:  &workoutRepository,
+		UserRepo:     &userRepository,
+		Location:     &s.BRLocation,
+	})
+
+	sessionService := session.NewService(session.ServiceParams{
+		SessionRepo:  &sessionRepository,
+		ScheduleRepo: &scheduleRepository,
+		WorkoutRepo:  &workoutRepository,
+		UserRepo:     &userRepository,
+		EventHub:     memory.NewHub(),
+	})
+
+	programService := program.NewService(program.ServiceParams{
+		ProgramRepo: &programRepository,
 		WorkoutRepo: &workoutRepository,
 		UserRepo:    &userRepository,
+		Location:    &s.BRLocation,
 	})
 
-	user.NewHTTPHandler(user.HTTPHandlerParams{
-		App:       s.App,
-		Service:   userService,
-		JWTSecret: s.EnvVariables.JWTSecret,
+	idempotencyService := idempotency.NewService(idempotency.ServiceParams{
+		IdempotencyRepo: &idempotencyRepository,
+		UserRepo:        &userRepository,
+		TTL:             s.EnvVariables.IdempotencyKeyTTL,
+		LockTimeout:     s.EnvVariables.IdempotencyKeyLockTimeout,
 	})
 
-	exercise.NewHTTPHandler(exercise.HTTPHandlerParams{
-		App:       s.App,
-		Service:   exerciseService,
-		JWTSecret: s.EnvVariables.JWTSecret,
+	batchService := batch.NewService(batch.ServiceParams{
+		TxRepo: &txRepository,
 	})
 
-	musclegroup.NewHTTPHandler(musclegroup.HTTPHandlerParams{
-		App:       s.App,
-		Service:   muscleGroupService,
-		JWTSecret: s.EnvVariables.JWTSecret,
+	syncService := offlinesync.NewService(offlinesync.ServiceParams{
+		SyncRepo:     &syncRepository,
+		WorkoutRepo:  &workoutRepository,
+		SessionRepo:  &sessionRepository,
+		ExerciseRepo: &exerciseRepository,
+		UserRepo:     &userRepository,
+		Retention:    s.EnvVariables.TrashRetention,
 	})
 
-	workout.NewHTTPHandler(workout.HTTPHandlerParams{
-		App:       s.App,
-		Service:   workoutService,
+	s.App.Use(idempotency.NewMiddleware(idempotency.MiddlewareParams{
+		Service:   idempotencyService,
 		JWTSecret: s.EnvVariables.JWTSecret,
+	}))
+
+	RegisterRoutes(s.App, Services{
+		User:        userService,
+		Exercise:    exerciseService,
+		MuscleGroup: muscleGroupService,
+		Workout:     workoutService,
+		Trash:       trashService,
+		Schedule:    scheduleService,
+		Session:     sessionService,
+		Program:     programService,
+		Batch:       batchService,
+		Sync:        syncService,
+	}, s.EnvVariables, s.legacyDeprecationParams())
+
+	s.PurgeJob = trash.NewPurgeJob(trash.PurgeJobParams{
+		Service:   trashService,
+		Retention: s.EnvVariables.TrashRetention,
+		Interval:  s.EnvVariables.TrashPurgeInterval,
+		Cleanups: []trash.Cleanup{
+			{Name: "expired idempotency keys", Run: idempotencyService.PurgeExpired},
+		},
 	})
 }
 
+func (s *Setup) legacyDeprecationParams() middleware.DeprecationParams {
+	params := middleware.DeprecationParams{
+		Policy: middleware.DeprecationPolicy{
+			DeprecatedAt: s.EnvVariables.LegacyRoutesDeprecatedAt,
+			SunsetAt:     s.EnvVariables.LegacyRoutesSunsetAt,
+		},
+		Routes:    map[string]middleware.DeprecationPolicy{},
+		Successor: APIVersionPrefix,
+	}
+
+	for route, sunsetAt := range s.EnvVariables.LegacyRouteSunsets {
+		params.Routes[route] = middleware.DeprecationPolicy{
+			DeprecatedAt: s.EnvVariables.LegacyRoutesDeprecatedAt,
+			SunsetAt:     sunsetAt,
+		}
+	}
+
+	return params
+}
+
+func (s *Setup) configureJobs(ctx context.Context) {
+	if s.EnvVariables.GoEnv == "test" {
+		return
+	}
+
+	s.PurgeJob.Start(ctx)
+}
+
 func (s *Setup) configureBRLocation() {
 	loc, err := time.LoadLocation("America/Sao_Paulo")
 	if err != nil {
@@ -157,6 +276,10 @@ func (s *Setup) WaitShutdown() {
 }
 
 func (s *Setup) Shutdown(ctx context.Context) {
+	if s.EnvVariables.GoEnv != "test" {
+		s.PurgeJob.Stop()
+	}
+
 	s.shutdownWaitGroup.Done()
 	ctx.Done()
 
@@ -171,7 +294,15 @@ func (s *Setup) configureDevelopmentEnvironment() {
 }
 
 func (s *Setup) configureEnvironmentVariables() {
-	if err := env.Parse(&s.EnvVariables); err != nil {
+	options := env.Options{
+		FuncMap: map[reflect.Type]env.ParserFunc{
+			reflect.TypeOf(time.Time{}): func(value string) (any, error) {
+				return time.Parse(time.RFC3339, value)
+			},
+		},
+	}
+
+	if err := env.ParseWithOptions(&s.EnvVariables, options); err != nil {
 		panic("Error parsing environment variables")
 	}
 }
@@ -43,7 +43,7 @@ func RunRequest(
 
 	req.Header.Add("Content-Type", "application/json")
 	for key, value := range header {
-		req.Header.Add(key, value)
+		req.Header.Set(key, value)
 	}
 
 	return setup.App.Test(req, -1)
@@ -128,6 +128,22 @@ func ParseErrorResponseBody(body io.ReadCloser) response.ErrorResponse {
 	return responseBody
 }
 
+func ParseProblemResponseBody(body io.ReadCloser) response.ProblemDetails {
+	var responseBody response.ProblemDetails
+
+	bodyBytes, err := io.ReadAll(body)
+	if err != nil {
+		panic(err)
+	}
+
+	err = json.Unmarshal(bodyBytes, &responseBody)
+	if err != nil {
+		panic(err)
+	}
+
+	return responseBody
+}
+
 func GetPointer[data any](value data) *data {
 	return &value
 }
@@ -138,6 +154,13 @@ func CleanUpDatabase(ctx context.Context, database *bun.DB) {
 	dropUsers(ctx, database)
 }
 
+func SoftDelete(ctx context.Context, database *bun.DB, model any) {
+	_, err := database.NewDelete().Model(model).WherePK().Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+}
+
 func CreateUser(ctx context.Context, database *bun.DB, model *user.Model) user.Model {
 	if model == nil {
 		model = &user.Model{
@@ -157,7 +180,7 @@ func CreateUser(ctx context.Context, database *bun.DB, model *user.Model) user.M
 }
 
 func dropUsers(ctx context.Context, database *bun.DB) {
-	_, err := database.NewDelete().Model(&user.Model{}).Where("1 = 1").Exec(ctx)
+	_, err := database.NewDelete().Model(&user.Model{}).Where("1 = 1").ForceDelete().Exec(ctx)
 	if err != nil {
 		panic(err)
 	}
@@ -280,7 +303,7 @@ func getExerciseByID(ctx context.Context, database *bun.DB, id uuid.UUID) *exerc
 }
 
 func dropExercises(ctx context.Context, database *bun.DB) {
-	_, err := database.NewDelete().Model(&exercise.Model{}).Where("1 = 1").Exec(ctx)
+	_, err := database.NewDelete().Model(&exercise.Model{}).Where("1 = 1").ForceDelete().Exec(ctx)
 	if err != nil {
 		panic(err)
 	}
@@ -296,7 +319,7 @@ func createMuscleGroup(ctx context.Context, database *bun.DB, model *musclegroup
 }
 
 func dropMuscleGroups(ctx context.Context, database *bun.DB) {
-	_, err := database.NewDelete().Model(&musclegroup.Model{}).Where("1 = 1").Exec(ctx)
+	_, err := database.NewDelete().Model(&musclegroup.Model{}).Where("1 = 1").ForceDelete().Exec(ctx)
 	if err != nil {
 		panic(err)
 	}
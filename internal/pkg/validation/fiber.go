@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ParseBody decodes the request body into req and validates it.
+func ParseBody(c *fiber.Ctx, req Validatable) error {
+	if err := c.BodyParser(req); err != nil {
+		return domainerr.Malformed("Invalid request body.", nil).Wrap(err)
+	}
+
+	if details := req.Validate(); details != nil {
+		return domainerr.InvalidRequestBody(details)
+	}
+
+	return nil
+}
+
+// ParseQuery decodes the query string into req and validates it.
+func ParseQuery(c *fiber.Ctx, req Validatable) error {
+	if err := c.QueryParser(req); err != nil {
+		return domainerr.Malformed("Invalid query parameters.", nil).Wrap(err)
+	}
+
+	if details := req.Validate(); details != nil {
+		return domainerr.InvalidQueryParams(details)
+	}
+
+	return nil
+}
+
+// ParseUUIDParam reads a route parameter that must hold a UUID.
+func ParseUUIDParam(c *fiber.Ctx, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params(name))
+	if err != nil {
+		return uuid.Nil, domainerr.Malformed("Invalid URL parameter.", &response.ErrorDetails{
+			NewErrorDetail(name, CodeInvalidUUID, "Invalid UUID format"),
+		}).Wrap(err)
+	}
+
+	return id, nil
+}
+
+// ParseOptionalUUIDQuery reads a query parameter that, when present, must
+// hold a UUID.
+func ParseOptionalUUIDQuery(c *fiber.Ctx, name string) (*uuid.UUID, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, domainerr.Malformed("Invalid query parameters.", &response.ErrorDetails{
+			NewErrorDetail(name, CodeInvalidUUID, "Invalid UUID format"),
+		}).Wrap(err)
+	}
+
+	return &id, nil
+}
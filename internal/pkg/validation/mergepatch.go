@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"mime"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/domainerr"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/gofiber/fiber/v2"
+)
+
+var ErrUnsupportedPatch = domainerr.UnsupportedMedia("Request body must be a JSON merge patch (" + mergepatch.MIMEMergePatchJSON + ").")
+
+// ParseMergePatch reads the JSON merge patch sent as the request body.
+func ParseMergePatch(c *fiber.Ctx) (mergepatch.Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	if err != nil || mediaType != mergepatch.MIMEMergePatchJSON {
+		return mergepatch.Patch{}, ErrUnsupportedPatch
+	}
+
+	patch, err := mergepatch.Parse(c.Body())
+	if err != nil {
+		return mergepatch.Patch{}, domainerr.Malformed("Invalid request body.", nil).Wrap(err)
+	}
+
+	return patch, nil
+}
+
+// ApplyMergePatch applies patch to req, which holds the current state of the
+// resource, and validates the result like a full replacement.
+func ApplyMergePatch(patch mergepatch.Patch, req Validatable) error {
+	if err := patch.Apply(req); err != nil {
+		return domainerr.Malformed("Invalid request body.", nil).Wrap(err)
+	}
+
+	if details := req.Validate(); details != nil {
+		return domainerr.InvalidRequestBody(details)
+	}
+
+	return nil
+}
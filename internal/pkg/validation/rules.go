@@ -0,0 +1,230 @@
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DateLayout is the format of calendar dates exchanged with clients.
+const DateLayout = time.DateOnly
+
+type (
+	// rule holds the state shared by every rule builder. Once a rule fails,
+	// the following checks on the same field are skipped so each field
+	// reports at most one error.
+	rule struct {
+		validator *Validator
+		field     string
+		done      bool
+	}
+
+	StringRule struct {
+		rule
+		value string
+	}
+
+	NumberRule[T int | float64] struct {
+		rule
+		value T
+	}
+
+	UUIDRule struct {
+		rule
+		value uuid.UUID
+	}
+
+	UUIDsRule struct {
+		rule
+		values []uuid.UUID
+	}
+)
+
+func (r *rule) fail(code Code, message string) {
+	r.validator.Add(r.field, code, message)
+	r.done = true
+}
+
+// label names the field in messages. It is taken from the full path so rules
+// on collection items, e.g. "dates[1]", are named after the collection.
+func (r *rule) label() string {
+	return label(r.validator.fieldPath(r.field))
+}
+
+// String validates a string field. Values are trimmed before being checked.
+func (v *Validator) String(field, value string) *StringRule {
+	return &StringRule{
+		rule:  rule{validator: v, field: field},
+		value: strings.TrimSpace(value),
+	}
+}
+
+// OptionalString validates a string field only when it is present.
+func (v *Validator) OptionalString(field string, value *string) *StringRule {
+	if value == nil {
+		return &StringRule{rule: rule{validator: v, field: field, done: true}}
+	}
+
+	return v.String(field, *value)
+}
+
+func (r *StringRule) Required() *StringRule {
+	if !r.done && r.value == "" {
+		r.fail(CodeRequired, fmt.Sprintf("%s is required", r.label()))
+	}
+
+	return r
+}
+
+func (r *StringRule) MinLength(length int) *StringRule {
+	if !r.done && r.value != "" && utf8.RuneCountInString(r.value) < length {
+		r.fail(CodeTooShort, fmt.Sprintf("%s must have at least %d characters", r.label(), length))
+	}
+
+	return r
+}
+
+func (r *StringRule) MaxLength(length int) *StringRule {
+	if !r.done && utf8.RuneCountInString(r.value) > length {
+		r.fail(CodeTooLong, fmt.Sprintf("%s must have at most %d characters", r.label(), length))
+	}
+
+	return r
+}
+
+func (r *StringRule) Email() *StringRule {
+	if r.done || r.value == "" {
+		return r
+	}
+
+	if address, err := mail.ParseAddress(r.value); err != nil || address.Address != r.value {
+		r.fail(CodeInvalidEmail, fmt.Sprintf("%s must be a valid email address", r.label()))
+	}
+
+	return r
+}
+
+func (r *StringRule) Date() *StringRule {
+	if r.done || r.value == "" {
+		return r
+	}
+
+	if _, err := time.Parse(DateLayout, r.value); err != nil {
+		r.fail(CodeInvalidDate, fmt.Sprintf("%s must be a date in the YYYY-MM-DD format", r.label()))
+	}
+
+	return r
+}
+
+func (r *StringRule) OneOf(values ...string) *StringRule {
+	if !r.done && r.value != "" && !slices.Contains(values, r.value) {
+		r.fail(CodeInvalidChoice, fmt.Sprintf("%s must be one of: %s", r.label(), strings.Join(values, ", ")))
+	}
+
+	return r
+}
+
+// Int validates an integer field.
+func (v *Validator) Int(field string, value int) *NumberRule[int] {
+	return &NumberRule[int]{
+		rule:  rule{validator: v, field: field},
+		value: value,
+	}
+}
+
+// OptionalInt validates an integer field only when it is present.
+func (v *Validator) OptionalInt(field string, value *int) *NumberRule[int] {
+	if value == nil {
+		return &NumberRule[int]{rule: rule{validator: v, field: field, done: true}}
+	}
+
+	return v.Int(field, *value)
+}
+
+// Float validates a decimal field.
+func (v *Validator) Float(field string, value float64) *NumberRule[float64] {
+	return &NumberRule[float64]{
+		rule:  rule{validator: v, field: field},
+		value: value,
+	}
+}
+
+// OptionalFloat validates a decimal field only when it is present.
+func (v *Validator) OptionalFloat(field string, value *float64) *NumberRule[float64] {
+	if value == nil {
+		return &NumberRule[float64]{rule: rule{validator: v, field: field, done: true}}
+	}
+
+	return v.Float(field, *value)
+}
+
+func (r *NumberRule[T]) Min(min T) *NumberRule[T] {
+	if !r.done && r.value < min {
+		r.fail(CodeTooSmall, fmt.Sprintf("%s must be at least %v", r.label(), min))
+	}
+
+	return r
+}
+
+func (r *NumberRule[T]) Max(max T) *NumberRule[T] {
+	if !r.done && r.value > max {
+		r.fail(CodeTooLarge, fmt.Sprintf("%s must be at most %v", r.label(), max))
+	}
+
+	return r
+}
+
+// UUID validates an identifier field.
+func (v *Validator) UUID(field string, value uuid.UUID) *UUIDRule {
+	return &UUIDRule{
+		rule:  rule{validator: v, field: field},
+		value: value,
+	}
+}
+
+func (r *UUIDRule) Required() *UUIDRule {
+	if !r.done && r.value == uuid.Nil {
+		r.fail(CodeRequired, fmt.Sprintf("%s is required", r.label()))
+	}
+
+	return r
+}
+
+// UUIDs validates a list of identifiers.
+func (v *Validator) UUIDs(field string, values []uuid.UUID) *UUIDsRule {
+	return &UUIDsRule{
+		rule:   rule{validator: v, field: field},
+		values: values,
+	}
+}
+
+func (r *UUIDsRule) Required() *UUIDsRule {
+	if !r.done && len(r.values) == 0 {
+		r.fail(CodeRequired, fmt.Sprintf("%s must have at least one item", r.label()))
+	}
+
+	return r
+}
+
+func (r *UUIDsRule) Unique() *UUIDsRule {
+	if r.done {
+		return r
+	}
+
+	seen := make(map[uuid.UUID]struct{}, len(r.values))
+	for _, value := range r.values {
+		if _, ok := seen[value]; ok {
+			r.fail(CodeDuplicate, fmt.Sprintf("%s must not contain duplicates", r.label()))
+			return r
+		}
+
+		seen[value] = struct{}{}
+	}
+
+	return r
+}
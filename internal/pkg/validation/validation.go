@@ -0,0 +1,133 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+)
+
+// Code is a stable, machine readable identifier of a validation failure.
+// Clients may rely on these values, so they must never be renamed.
+type Code string
+
+const (
+	CodeRequired      Code = "required"
+	CodeTooShort      Code = "too_short"
+	CodeTooLong       Code = "too_long"
+	CodeTooSmall      Code = "too_small"
+	CodeTooLarge      Code = "too_large"
+	CodeInvalidEmail  Code = "invalid_email"
+	CodeInvalidUUID   Code = "invalid_uuid"
+	CodeInvalidDate   Code = "invalid_date"
+	CodeInvalidChoice Code = "invalid_choice"
+	CodeDuplicate     Code = "duplicate"
+	CodeAlreadyExists Code = "already_exists"
+	CodeNotFound      Code = "not_found"
+	CodeInvalid       Code = "invalid"
+	CodeExpired       Code = "expired"
+)
+
+type (
+	// Validatable is implemented by request types that know how to check
+	// their own fields.
+	Validatable interface {
+		Validate() *response.ErrorDetails
+	}
+
+	// Validator collects the errors produced by rules. Nested validators
+	// share the same errors and only differ by the path they prefix to
+	// field names.
+	Validator struct {
+		path   string
+		errors *response.ErrorDetails
+	}
+)
+
+func New() *Validator {
+	return &Validator{
+		errors: &response.ErrorDetails{},
+	}
+}
+
+func NewErrorDetail(field string, code Code, message string) response.ErrorDetail {
+	return response.ErrorDetail{
+		Field:   field,
+		Code:    string(code),
+		Message: message,
+	}
+}
+
+// Errors returns the collected errors, or nil when every rule passed.
+func (v *Validator) Errors() *response.ErrorDetails {
+	if len(*v.errors) == 0 {
+		return nil
+	}
+
+	return v.errors
+}
+
+// Add records an error for a field relative to the validator path.
+func (v *Validator) Add(field string, code Code, message string) {
+	*v.errors = append(*v.errors, NewErrorDetail(v.fieldPath(field), code, message))
+}
+
+// Check records detail, relative to the validator path, when ok is false.
+func (v *Validator) Check(ok bool, detail response.ErrorDetail) {
+	if ok {
+		return
+	}
+
+	detail.Field = v.fieldPath(detail.Field)
+	*v.errors = append(*v.errors, detail)
+}
+
+// Nested runs fn with a validator whose errors are reported under field,
+// e.g. "schedule.starts_at".
+func (v *Validator) Nested(field string, fn func(v *Validator)) {
+	fn(&Validator{
+		path:   v.fieldPath(field),
+		errors: v.errors,
+	})
+}
+
+// Each runs fn for every item of a collection, reporting errors under the
+// item index, e.g. "exercises[2].sets".
+func Each[T any](v *Validator, field string, items []T, fn func(v *Validator, item T)) {
+	for index, item := range items {
+		fn(&Validator{
+			path:   fmt.Sprintf("%s[%d]", v.fieldPath(field), index),
+			errors: v.errors,
+		}, item)
+	}
+}
+
+func (v *Validator) fieldPath(field string) string {
+	switch {
+	case v.path == "":
+		return field
+	case field == "":
+		return v.path
+	default:
+		return v.path + "." + field
+	}
+}
+
+// label turns the last segment of a field path into a human readable name,
+// so "exercises[2].rest_time" becomes "Rest time".
+func label(field string) string {
+	if index := strings.LastIndex(field, "."); index >= 0 {
+		field = field[index+1:]
+	}
+
+	if index := strings.Index(field, "["); index >= 0 {
+		field = field[:index]
+	}
+
+	field = strings.ReplaceAll(field, "_", " ")
+	if field == "" {
+		return field
+	}
+
+	return strings.ToUpper(field[:1]) + field[1:]
+}
@@ -0,0 +1,128 @@
+package validation_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type set struct {
+	Reps   int
+	Weight *float64
+}
+
+func TestValidator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return nil when every rule passes", func(t *testing.T) {
+		t.Parallel()
+
+		v := validation.New()
+		v.String("name", "Push Day").Required().MaxLength(255)
+		v.Int("sets", 3).Min(1).Max(10)
+		v.UUID("exercise_id", uuid.New()).Required()
+
+		assert.Nil(t, v.Errors())
+	})
+
+	t.Run("should report one error per field with a stable code", func(t *testing.T) {
+		t.Parallel()
+
+		v := validation.New()
+		v.String("name", "   ").Required().MaxLength(3)
+		v.String("email", "not-an-email").Required().Email()
+		v.Int("per_page", 500).Min(0).Max(100)
+
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("name", validation.CodeRequired, "Name is required"),
+			validation.NewErrorDetail("email", validation.CodeInvalidEmail, "Email must be a valid email address"),
+			validation.NewErrorDetail("per_page", validation.CodeTooLarge, "Per page must be at most 100"),
+		}, v.Errors())
+	})
+
+	t.Run("should skip optional values that are not present", func(t *testing.T) {
+		t.Parallel()
+
+		v := validation.New()
+		v.OptionalInt("repetitions", nil).Min(1)
+		v.OptionalFloat("weight", nil).Min(0)
+		v.OptionalString("notes", nil).MaxLength(1)
+		v.String("type", "").OneOf("exercise", "workout")
+
+		assert.Nil(t, v.Errors())
+	})
+
+	t.Run("should report nested fields with their path", func(t *testing.T) {
+		t.Parallel()
+
+		negative := -5.0
+		sets := []set{{Reps: 10}, {Reps: 0}, {Reps: 8, Weight: &negative}}
+
+		v := validation.New()
+		v.Nested("plan", func(v *validation.Validator) {
+			validation.Each(v, "sets", sets, func(v *validation.Validator, item set) {
+				v.Int("reps", item.Reps).Min(1)
+				v.OptionalFloat("weight", item.Weight).Min(0)
+			})
+		})
+
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("plan.sets[1].reps", validation.CodeTooSmall, "Reps must be at least 1"),
+			validation.NewErrorDetail("plan.sets[2].weight", validation.CodeTooSmall, "Weight must be at least 0"),
+		}, v.Errors())
+	})
+
+	t.Run("should prefix predefined errors added with Check", func(t *testing.T) {
+		t.Parallel()
+
+		detail := validation.NewErrorDetail("source_ids", validation.CodeRequired, "At least one source is required")
+
+		v := validation.New()
+		validation.Each(v, "merges", []int{0}, func(v *validation.Validator, _ int) {
+			v.Check(false, detail)
+		})
+
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("merges[0].source_ids", validation.CodeRequired, "At least one source is required"),
+		}, v.Errors())
+	})
+
+	t.Run("should validate string lengths, choices and dates", func(t *testing.T) {
+		t.Parallel()
+
+		v := validation.New()
+		v.String("name", strings.Repeat("a", 4)).MaxLength(3)
+		v.String("password", "abc").MinLength(8)
+		v.String("type", "routine").OneOf("exercise", "workout")
+		v.String("from", "2025-02-30").Date()
+		v.String("to", "2025-02-28").Date()
+
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("name", validation.CodeTooLong, "Name must have at most 3 characters"),
+			validation.NewErrorDetail("password", validation.CodeTooShort, "Password must have at least 8 characters"),
+			validation.NewErrorDetail("type", validation.CodeInvalidChoice, "Type must be one of: exercise, workout"),
+			validation.NewErrorDetail("from", validation.CodeInvalidDate, "From must be a date in the YYYY-MM-DD format"),
+		}, v.Errors())
+	})
+
+	t.Run("should validate identifier lists", func(t *testing.T) {
+		t.Parallel()
+
+		id := uuid.New()
+
+		v := validation.New()
+		v.UUIDs("source_ids", nil).Required()
+		v.UUIDs("muscle_group_ids", []uuid.UUID{id, id}).Unique()
+		v.UUID("exercise_id", uuid.Nil).Required()
+
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("source_ids", validation.CodeRequired, "Source ids must have at least one item"),
+			validation.NewErrorDetail("muscle_group_ids", validation.CodeDuplicate, "Muscle group ids must not contain duplicates"),
+			validation.NewErrorDetail("exercise_id", validation.CodeRequired, "Exercise id is required"),
+		}, v.Errors())
+	})
+}
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+CREATE INDEX idx_muscle_groups_deleted_at ON muscle_groups(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_exercises_deleted_at ON exercises(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_workouts_deleted_at ON workouts(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_muscle_groups_deleted_at;
+DROP INDEX IF EXISTS idx_exercises_deleted_at;
+DROP INDEX IF EXISTS idx_workouts_deleted_at;
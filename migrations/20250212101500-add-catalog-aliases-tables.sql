@@ -0,0 +1,26 @@
+-- +migrate Up
+
+CREATE TABLE exercise_aliases (
+    id UUID PRIMARY KEY NOT NULL,
+    exercise_id UUID REFERENCES exercises(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE muscle_group_aliases (
+    id UUID PRIMARY KEY NOT NULL,
+    muscle_group_id UUID REFERENCES muscle_groups(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_exercise_aliases_exercise_id_name ON exercise_aliases(exercise_id, lower(name));
+CREATE UNIQUE INDEX idx_muscle_group_aliases_muscle_group_id_name ON muscle_group_aliases(muscle_group_id, lower(name));
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_exercise_aliases_exercise_id_name;
+DROP INDEX IF EXISTS idx_muscle_group_aliases_muscle_group_id_name;
+
+DROP TABLE IF EXISTS exercise_aliases;
+DROP TABLE IF EXISTS muscle_group_aliases;
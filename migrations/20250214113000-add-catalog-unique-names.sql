@@ -0,0 +1,38 @@
+-- +migrate Up
+
+DROP INDEX IF EXISTS idx_muscle_groups_name;
+DROP INDEX IF EXISTS idx_exercises_name;
+
+-- Names differing only by case were accepted until now. Keep the oldest one
+-- of each name and suffix the others with their id, so the unique indexes
+-- can be built. Admins can then merge the duplicates.
+UPDATE muscle_groups AS mg
+SET name = left(mg.name, 206) || ' (duplicate ' || mg.id || ')',
+    updated_at = NOW()
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY lower(name) ORDER BY created_at, id) AS position
+    FROM muscle_groups
+    WHERE deleted_at IS NULL
+) AS duplicates
+WHERE mg.id = duplicates.id AND duplicates.position > 1;
+
+UPDATE exercises AS e
+SET name = left(e.name, 206) || ' (duplicate ' || e.id || ')',
+    updated_at = NOW()
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY lower(name) ORDER BY created_at, id) AS position
+    FROM exercises
+    WHERE deleted_at IS NULL
+) AS duplicates
+WHERE e.id = duplicates.id AND duplicates.position > 1;
+
+CREATE UNIQUE INDEX idx_muscle_groups_lower_name ON muscle_groups(lower(name)) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX idx_exercises_lower_name ON exercises(lower(name)) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_muscle_groups_lower_name;
+DROP INDEX IF EXISTS idx_exercises_lower_name;
+
+CREATE INDEX idx_muscle_groups_name ON muscle_groups(name) WHERE deleted_at IS NULL;
+CREATE INDEX idx_exercises_name ON exercises(name) WHERE deleted_at IS NULL;
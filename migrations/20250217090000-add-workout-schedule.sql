@@ -0,0 +1,32 @@
+-- +migrate Up
+
+CREATE TABLE scheduled_workouts (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    workout_id UUID REFERENCES workouts(id) ON DELETE CASCADE,
+    scheduled_for DATE NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE workout_history ADD COLUMN scheduled_workout_id UUID REFERENCES scheduled_workouts(id) ON DELETE SET NULL;
+ALTER TABLE workout_history ADD COLUMN started_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE workout_history SET started_at = completed_at;
+
+CREATE INDEX idx_scheduled_workouts_user_id_scheduled_for ON scheduled_workouts(user_id, scheduled_for) WHERE deleted_at IS NULL;
+CREATE INDEX idx_scheduled_workouts_deleted_at ON scheduled_workouts(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE UNIQUE INDEX idx_workout_history_scheduled_workout_id ON workout_history(scheduled_workout_id) WHERE scheduled_workout_id IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_workout_history_scheduled_workout_id;
+DROP INDEX IF EXISTS idx_scheduled_workouts_deleted_at;
+DROP INDEX IF EXISTS idx_scheduled_workouts_user_id_scheduled_for;
+
+ALTER TABLE workout_history DROP COLUMN IF EXISTS started_at;
+ALTER TABLE workout_history DROP COLUMN IF EXISTS scheduled_workout_id;
+
+DROP TABLE IF EXISTS scheduled_workouts;
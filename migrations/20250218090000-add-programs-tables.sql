@@ -0,0 +1,58 @@
+-- +migrate Up
+
+CREATE TABLE programs (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    training_max_percentage FLOAT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE program_weeks (
+    id UUID PRIMARY KEY NOT NULL,
+    program_id UUID REFERENCES programs(id) ON DELETE CASCADE,
+    week_number INT NOT NULL,
+    intensity_percentage FLOAT NOT NULL,
+    sets INT,
+    repetitions INT,
+    notes TEXT
+);
+
+CREATE TABLE program_days (
+    id UUID PRIMARY KEY NOT NULL,
+    program_week_id UUID REFERENCES program_weeks(id) ON DELETE CASCADE,
+    day_number INT NOT NULL,
+    workout_id UUID REFERENCES workouts(id) ON DELETE CASCADE
+);
+
+CREATE TABLE program_enrollments (
+    id UUID PRIMARY KEY NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    program_id UUID REFERENCES programs(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_programs_user_id ON programs(user_id) WHERE deleted_at IS NULL;
+CREATE INDEX idx_programs_deleted_at ON programs(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE UNIQUE INDEX idx_program_weeks_program_id_week_number ON program_weeks(program_id, week_number);
+CREATE UNIQUE INDEX idx_program_days_program_week_id_day_number ON program_days(program_week_id, day_number);
+CREATE UNIQUE INDEX idx_program_enrollments_user_id ON program_enrollments(user_id) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_program_enrollments_user_id;
+DROP INDEX IF EXISTS idx_program_days_program_week_id_day_number;
+DROP INDEX IF EXISTS idx_program_weeks_program_id_week_number;
+DROP INDEX IF EXISTS idx_programs_deleted_at;
+DROP INDEX IF EXISTS idx_programs_user_id;
+
+DROP TABLE IF EXISTS program_enrollments;
+DROP TABLE IF EXISTS program_days;
+DROP TABLE IF EXISTS program_weeks;
+DROP TABLE IF EXISTS programs;
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+CREATE TABLE workout_exercise_groups (
+    id UUID PRIMARY KEY NOT NULL,
+    workout_id UUID REFERENCES workouts(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    time_cap INT
+);
+
+ALTER TABLE workout_exercises
+    ADD COLUMN position INT NOT NULL DEFAULT 0,
+    ADD COLUMN group_id UUID REFERENCES workout_exercise_groups(id) ON DELETE SET NULL;
+
+UPDATE workout_exercises
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY workout_id ORDER BY created_at, id) - 1 AS position
+    FROM workout_exercises
+) AS ordered
+WHERE workout_exercises.id = ordered.id;
+
+CREATE INDEX idx_workout_exercise_groups_workout_id ON workout_exercise_groups(workout_id);
+CREATE INDEX idx_workout_exercises_workout_id_position ON workout_exercises(workout_id, position);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_workout_exercises_workout_id_position;
+DROP INDEX IF EXISTS idx_workout_exercise_groups_workout_id;
+
+ALTER TABLE workout_exercises
+    DROP COLUMN IF EXISTS group_id,
+    DROP COLUMN IF EXISTS position;
+
+DROP TABLE IF EXISTS workout_exercise_groups;
@@ -0,0 +1,26 @@
+-- +migrate Up
+
+CREATE TABLE workout_exercise_sets (
+    id UUID PRIMARY KEY NOT NULL,
+    workout_exercise_id UUID REFERENCES workout_exercises(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    min_repetitions INT,
+    max_repetitions INT,
+    weight FLOAT,
+    percentage FLOAT,
+    rpe FLOAT,
+    tempo VARCHAR(4),
+    rest_time INT
+);
+
+INSERT INTO workout_exercise_sets (id, workout_exercise_id, position, min_repetitions, max_repetitions, weight)
+SELECT gen_random_uuid(), workout_exercises.id, sets.position, workout_exercises.repetitions, workout_exercises.repetitions, workout_exercises.weight
+FROM workout_exercises
+CROSS JOIN LATERAL generate_series(0, workout_exercises.sets - 1) AS sets(position);
+
+CREATE UNIQUE INDEX idx_workout_exercise_sets_workout_exercise_id_position ON workout_exercise_sets(workout_exercise_id, position);
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_workout_exercise_sets_workout_exercise_id_position;
+DROP TABLE IF EXISTS workout_exercise_sets;
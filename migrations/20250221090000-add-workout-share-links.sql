@@ -0,0 +1,20 @@
+-- +migrate Up
+
+CREATE TABLE workout_share_links (
+    id UUID PRIMARY KEY NOT NULL,
+    workout_id UUID REFERENCES workouts(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX idx_workout_share_links_token ON workout_share_links(token);
+CREATE INDEX idx_workout_share_links_workout_id ON workout_share_links(workout_id) WHERE deleted_at IS NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_workout_share_links_workout_id;
+DROP INDEX IF EXISTS idx_workout_share_links_token;
+DROP TABLE IF EXISTS workout_share_links;
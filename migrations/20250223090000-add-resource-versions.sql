@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE workouts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE exercises ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE muscle_groups ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +migrate Down
+
+ALTER TABLE muscle_groups DROP COLUMN IF EXISTS version;
+ALTER TABLE exercises DROP COLUMN IF EXISTS version;
+ALTER TABLE workouts DROP COLUMN IF EXISTS version;
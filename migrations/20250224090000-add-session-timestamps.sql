@@ -0,0 +1,29 @@
+-- +migrate Up
+
+ALTER TABLE workout_history ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE workout_history ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE workout_history SET updated_at = COALESCE(completed_at, started_at);
+
+CREATE INDEX idx_workout_history_user_id_updated_at ON workout_history(user_id, updated_at) WHERE deleted_at IS NULL;
+CREATE INDEX idx_workout_history_deleted_at ON workout_history(user_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_workouts_user_id_updated_at ON workouts(user_id, updated_at) WHERE deleted_at IS NULL;
+CREATE INDEX idx_exercises_updated_at ON exercises(updated_at) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_workout_history_scheduled_workout_id;
+CREATE UNIQUE INDEX idx_workout_history_scheduled_workout_id ON workout_history(scheduled_workout_id) WHERE scheduled_workout_id IS NOT NULL AND deleted_at IS NULL;
+
+-- +migrate Down
+
+DELETE FROM workout_history WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_workout_history_scheduled_workout_id;
+CREATE UNIQUE INDEX idx_workout_history_scheduled_workout_id ON workout_history(scheduled_workout_id) WHERE scheduled_workout_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_exercises_updated_at;
+DROP INDEX IF EXISTS idx_workouts_user_id_updated_at;
+DROP INDEX IF EXISTS idx_workout_history_deleted_at;
+DROP INDEX IF EXISTS idx_workout_history_user_id_updated_at;
+
+ALTER TABLE workout_history DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE workout_history DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TABLE idempotency_keys ADD COLUMN locked_until TIMESTAMP WITH TIME ZONE;
+
+-- Keys still reserved were left by requests that can no longer answer, so
+-- their locks have already lapsed.
+UPDATE idempotency_keys SET locked_until = created_at WHERE status_code IS NULL;
+
+-- +migrate Down
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_until;
@@ -0,0 +1,34 @@
+-- +migrate Up
+
+-- Exercises created by users through sync belong to them, the others make
+-- up the global catalog. Exercises pushed before owners were recorded cannot
+-- be told apart and stay in the catalog.
+ALTER TABLE exercises ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX idx_exercises_user_id_updated_at ON exercises(user_id, updated_at) WHERE deleted_at IS NULL;
+
+-- Names are unique within the catalog and within the exercises of a user.
+DROP INDEX IF EXISTS idx_exercises_lower_name;
+CREATE UNIQUE INDEX idx_exercises_lower_name ON exercises(lower(name)) WHERE deleted_at IS NULL AND user_id IS NULL;
+CREATE UNIQUE INDEX idx_exercises_user_id_lower_name ON exercises(user_id, lower(name)) WHERE deleted_at IS NULL AND user_id IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX IF EXISTS idx_exercises_user_id_lower_name;
+DROP INDEX IF EXISTS idx_exercises_lower_name;
+DROP INDEX IF EXISTS idx_exercises_user_id_updated_at;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS user_id;
+
+-- Custom exercises join the catalog again, where their names may be taken.
+UPDATE exercises AS e
+SET name = left(e.name, 206) || ' (duplicate ' || e.id || ')',
+    updated_at = NOW()
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY lower(name) ORDER BY created_at, id) AS position
+    FROM exercises
+    WHERE deleted_at IS NULL
+) AS duplicates
+WHERE e.id = duplicates.id AND duplicates.position > 1;
+
+CREATE UNIQUE INDEX idx_exercises_lower_name ON exercises(lower(name)) WHERE deleted_at IS NULL;
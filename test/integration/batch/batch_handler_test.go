@@ -0,0 +1,96 @@
+package batch_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/batch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	t.Run("should run every operation of a batch", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/batch", batch.BatchRequest{
+			Operations: []batch.Operation{
+				{Method: http.MethodPost, Path: "/v1/workouts", Body: json.RawMessage(`{"name":"Push Day"}`)},
+				{Method: http.MethodGet, Path: "/v1/workouts/00000000-0000-0000-0000-000000000000"},
+				{Method: http.MethodPut, Path: "/v1/workouts/" + testWorkout.ID.String(), Body: json.RawMessage(`{"name":"Pull Day"}`)},
+				{Method: http.MethodPost, Path: "/v1/batch", Body: json.RawMessage(`{"operations":[]}`)},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[batch.BatchResponse](resp.Body)
+		assert.False(t, responseParsed.Data.RolledBack)
+		assert.Len(t, responseParsed.Data.Results, 4)
+		assert.Equal(t, http.StatusCreated, responseParsed.Data.Results[0].Status)
+		assert.Equal(t, http.StatusNotFound, responseParsed.Data.Results[1].Status)
+		assert.Equal(t, http.StatusOK, responseParsed.Data.Results[2].Status)
+		assert.Equal(t, http.StatusBadRequest, responseParsed.Data.Results[3].Status)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/workouts", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		list := testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+		assert.Len(t, list.Data, 2)
+	})
+
+	t.Run("should roll back an atomic batch when an operation fails", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/batch", batch.BatchRequest{
+			Atomic: true,
+			Operations: []batch.Operation{
+				{Method: http.MethodPost, Path: "/v1/workouts", Body: json.RawMessage(`{"name":"Push Day"}`)},
+				{Method: http.MethodPost, Path: "/v1/workouts", Body: json.RawMessage(`{"name":""}`)},
+				{Method: http.MethodPost, Path: "/v1/workouts", Body: json.RawMessage(`{"name":"Leg Day"}`)},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[batch.BatchResponse](resp.Body)
+		assert.True(t, responseParsed.Data.RolledBack)
+		assert.Equal(t, http.StatusCreated, responseParsed.Data.Results[0].Status)
+		assert.Equal(t, http.StatusBadRequest, responseParsed.Data.Results[1].Status)
+		assert.Equal(t, http.StatusFailedDependency, responseParsed.Data.Results[2].Status)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/workouts", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		list := testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+		assert.Empty(t, list.Data)
+	})
+}
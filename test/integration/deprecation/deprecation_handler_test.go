@@ -0,0 +1,62 @@
+package deprecation_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	os.Setenv("LEGACY_ROUTES_DEPRECATED_AT", "2026-10-19T00:00:00Z")
+	os.Setenv("LEGACY_ROUTES_SUNSET_AT", "2027-04-19T00:00:00Z")
+	os.Setenv("LEGACY_ROUTE_SUNSETS", "GET /workouts/:id=2027-01-01T00:00:00Z")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	testhelper.CleanUpDatabase(ctx, database.DB())
+
+	testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+	testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+	authHeader := map[string]string{
+		"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+	}
+
+	t.Run("should announce the deprecation of legacy routes", func(t *testing.T) {
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "@1792368000", resp.Header.Get("Deprecation"))
+		assert.Equal(t, "Mon, 19 Apr 2027 00:00:00 GMT", resp.Header.Get("Sunset"))
+		assert.Equal(t, `</v1/workouts>; rel="successor-version"`, resp.Header.Get("Link"))
+
+		// A single route can sunset earlier than the rest
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "@1792368000", resp.Header.Get("Deprecation"))
+		assert.Equal(t, "Fri, 01 Jan 2027 00:00:00 GMT", resp.Header.Get("Sunset"))
+		assert.Equal(t, `</v1/workouts/`+testWorkout.ID.String()+`>; rel="successor-version"`, resp.Header.Get("Link"))
+	})
+
+	t.Run("should not announce a deprecation on versioned routes", func(t *testing.T) {
+		for _, path := range []string{"/workouts", "/workouts/" + testWorkout.ID.String()} {
+			resp, err := testhelper.RunRequest(setup, http.MethodGet, "/v1"+path, nil, authHeader)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Empty(t, resp.Header.Get("Deprecation"))
+			assert.Empty(t, resp.Header.Get("Sunset"))
+			assert.Empty(t, resp.Header.Get("Link"))
+		}
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/Gabukuro/gymratz-api/internal/infra/database"
 	exerciseEntity "github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
@@ -18,10 +20,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const adminEmail = "admin@gymratz.com"
+
 func TestExerciseHandler(t *testing.T) {
 	t.Parallel()
 
 	os.Setenv("GO_ENV", "test")
+	os.Setenv("ADMIN_EMAILS", adminEmail)
 	setup, ctx := setup.Init()
 	defer database.CloseTestDB(ctx)
 
@@ -46,7 +51,7 @@ func TestExerciseHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[exerciseEntity.Model](resp.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[exerciseEntity.ExerciseResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 		assert.Equal(t, requestBody.Name, responseParsed.Data.Name)
 		assert.Equal(t, requestBody.Description, responseParsed.Data.Description)
@@ -76,14 +81,14 @@ func TestExerciseHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-		responseParsed := testhelper.ParsePaginationResponseBody[[]*exerciseEntity.Model](resp.Body)
+		responseParsed := testhelper.ParsePaginationResponseBody[[]*exerciseEntity.ExerciseResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 
 		// Check the response pagination metadata
 		assert.Equal(t, 1, responseParsed.Pagination.Page)
 		assert.Equal(t, 10, responseParsed.Pagination.PerPage) // Default per page
-		assert.Equal(t, 1, responseParsed.Pagination.TotalItems)
-		assert.Equal(t, 1, responseParsed.Pagination.TotalPages)
+		assert.Equal(t, 1, *responseParsed.Pagination.TotalItems)
+		assert.Equal(t, 1, *responseParsed.Pagination.TotalPages)
 
 		// Check the response data
 		assert.Equal(t, 1, len(responseParsed.Data))
@@ -96,6 +101,54 @@ func TestExerciseHandler(t *testing.T) {
 		assert.Equal(t, testMuscleGroup.Name, responseParsed.Data[0].MuscleGroups[0].Name)
 	})
 
+	t.Run("should list only the requested fields and relations of exercises", func(t *testing.T) {
+		// Clean up the database
+		cleanUpDatabase(ctx)
+
+		// Create a new exercise wit a muscle group
+		testExercise, _ := createExerciseWithMuscleGroup(ctx,
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		// Send a request to list exercise names only
+		resp, err := testhelper.RunRequest(setup,
+			http.MethodGet,
+			"/exercises?fields=name&expand=",
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		fieldsParsed := testhelper.ParsePaginationResponseBody[[]map[string]any](resp.Body)
+		assert.Equal(t, 1, len(fieldsParsed.Data))
+		assert.Equal(t, testExercise.ID.String(), fieldsParsed.Data[0]["id"])
+		assert.Equal(t, testExercise.Name, fieldsParsed.Data[0]["name"])
+
+		// Fields that were not requested are left out instead of zeroed
+		for _, key := range []string{"user_id", "description", "created_at", "updated_at", "muscle_groups"} {
+			assert.NotContains(t, fieldsParsed.Data[0], key)
+		}
+
+		// Send a request expanding a relation that is not loaded by default
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/exercises?expand=aliases,muscle_groups", nil, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParsePaginationResponseBody[[]*exerciseEntity.ExerciseResponse](resp.Body)
+		assert.Equal(t, 1, len(responseParsed.Data[0].MuscleGroups))
+
+		// Send a request with a field that can't be selected
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/exercises?fields=deleted_at", nil, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("should update an exercise", func(t *testing.T) {
 		// Clean up the database
 		cleanUpDatabase(ctx)
@@ -129,7 +182,7 @@ func TestExerciseHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, rep.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[exerciseEntity.Model](rep.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[exerciseEntity.ExerciseResponse](rep.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 		assert.Equal(t, updateExerciseRequest.Name, responseParsed.Data.Name)
 		assert.Equal(t, updateExerciseRequest.Description, responseParsed.Data.Description)
@@ -142,6 +195,124 @@ func TestExerciseHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("should patch an exercise", func(t *testing.T) {
+		// Clean up the database
+		cleanUpDatabase(ctx)
+
+		// Create a new exercise wit a muscle group
+		testExercise, _ := createExerciseWithMuscleGroup(ctx,
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+		patchHeader := map[string]string{"Content-Type": mergepatch.MIMEMergePatchJSON}
+		path := "/exercises/" + testExercise.ID.String()
+
+		// Send a request to rename the exercise, keeping its muscle groups
+		rep, err := testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"name": "pushup patched"}, patchHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rep.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[exerciseEntity.ExerciseResponse](rep.Body)
+		assert.Equal(t, "pushup patched", responseParsed.Data.Name)
+		assert.Equal(t, "pushup description", responseParsed.Data.Description)
+		assert.Equal(t, 1, len(responseParsed.Data.MuscleGroups))
+
+		// Send requests clearing the muscle groups, with null and with an empty list
+		for _, muscleGroupIDs := range []any{nil, []uuid.UUID{}} {
+			rep, err = testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"muscle_group_ids": muscleGroupIDs}, patchHeader)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, rep.StatusCode)
+
+			responseParsed = testhelper.ParseSuccessResponseBody[exerciseEntity.ExerciseResponse](rep.Body)
+			assert.Equal(t, "pushup patched", responseParsed.Data.Name)
+			assert.Empty(t, responseParsed.Data.MuscleGroups)
+		}
+	})
+
+	t.Run("should not create an exercise without a name", func(t *testing.T) {
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodPost,
+			"/exercises",
+			exerciseEntity.CreateExerciseRequest{
+				Description: "test description",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rep.StatusCode)
+
+		responseParsed := testhelper.ParseErrorResponseBody(rep.Body)
+		assert.Equal(t, &response.ErrorDetails{exerciseEntity.ErrorNameIsRequired}, responseParsed.Details)
+	})
+
+	t.Run("should not create an exercise with a name already in use", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		_, _ = createExerciseWithMuscleGroup(ctx,
+			"Pushup",
+			"pushup description",
+			"chest",
+		)
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodPost,
+			"/exercises",
+			exerciseEntity.CreateExerciseRequest{
+				Name:        "pushup",
+				Description: "another pushup",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, rep.StatusCode)
+
+		responseParsed := testhelper.ParseErrorResponseBody(rep.Body)
+		assert.Equal(t, http.StatusConflict, responseParsed.Code)
+		assert.Equal(t, &response.ErrorDetails{exerciseEntity.ErrorNameAlreadyExists}, responseParsed.Details)
+	})
+
+	t.Run("should allow reusing the name of a deleted exercise", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		testExercise, _ := createExerciseWithMuscleGroup(ctx,
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+		testhelper.SoftDelete(ctx, database.DB(), &testExercise)
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodPost,
+			"/exercises",
+			exerciseEntity.CreateExerciseRequest{
+				Name:        "Pushup",
+				Description: "pushup description",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, rep.StatusCode)
+
+		// Restoring the deleted exercise now conflicts with the new one
+		rep, err = testhelper.RunRequest(setup,
+			http.MethodPost,
+			"/exercises/"+testExercise.ID.String()+"/restore",
+			nil,
+			map[string]string{
+				"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, testhelper.GetPointer(adminEmail)),
+			},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, rep.StatusCode)
+	})
+
 	t.Run("should delete an exercise", func(t *testing.T) {
 		// Clean up the database
 		cleanUpDatabase(ctx)
@@ -168,6 +339,176 @@ func TestExerciseHandler(t *testing.T) {
 		exercise := getExerciseByID(ctx, testExercise.ID)
 		assert.Nil(t, exercise)
 	})
+
+	t.Run("should not delete an exercise used by a workout", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		testExercise := testWorkout.WorkoutExercises[0].Exercise
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodDelete,
+			"/exercises/"+testExercise.ID.String(),
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, rep.StatusCode)
+
+		responseParsed := testhelper.ParseErrorResponseBody(rep.Body)
+		assert.Equal(t, response.StatusError, responseParsed.Status)
+		assert.Equal(t, map[string]any{"workouts": float64(1), "progress_logs": float64(0)}, responseParsed.Meta)
+
+		// Check the exercise was kept
+		exercise := getExerciseByID(ctx, testExercise.ID)
+		assert.NotNil(t, exercise)
+	})
+
+	t.Run("should not delete an exercise used by a workout in the trash", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		testExercise := testWorkout.WorkoutExercises[0].Exercise
+
+		testhelper.SoftDelete(ctx, database.DB(), &testWorkout)
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodDelete,
+			"/exercises/"+testExercise.ID.String(),
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, rep.StatusCode)
+
+		responseParsed := testhelper.ParseErrorResponseBody(rep.Body)
+		assert.Equal(t, map[string]any{"workouts": float64(1), "progress_logs": float64(0)}, responseParsed.Meta)
+	})
+
+	t.Run("should delete an exercise moving its references to the replacement", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		testExercise := testWorkout.WorkoutExercises[0].Exercise
+		replacement, _ := createExerciseWithMuscleGroup(ctx,
+			"bench press",
+			"bench press description",
+			"chest",
+		)
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodDelete,
+			"/exercises/"+testExercise.ID.String()+"?replace_with="+replacement.ID.String(),
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, rep.StatusCode)
+
+		// Check the exercise was deleted and the workout now points to the replacement
+		exercise := getExerciseByID(ctx, testExercise.ID)
+		assert.Nil(t, exercise)
+
+		workoutExercise := &workoutexercise.Model{}
+		err = database.DB().NewSelect().Model(workoutExercise).Where("id = ?", testWorkout.WorkoutExercises[0].ID).Scan(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, replacement.ID, workoutExercise.ExerciseID)
+	})
+
+	t.Run("should not replace an exercise with itself", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		testExercise, _ := createExerciseWithMuscleGroup(ctx,
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodDelete,
+			"/exercises/"+testExercise.ID.String()+"?replace_with="+testExercise.ID.String(),
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, rep.StatusCode)
+	})
+
+	t.Run("should merge duplicate exercises into the target", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		source := testWorkout.WorkoutExercises[0].Exercise
+		target, targetMuscleGroup := createExerciseWithMuscleGroup(ctx,
+			"Bench Press",
+			"Bench Press description",
+			"chest",
+		)
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodPost,
+			"/exercises/"+target.ID.String()+"/merge",
+			exerciseEntity.MergeExercisesRequest{
+				SourceIDs: []uuid.UUID{source.ID},
+			},
+			map[string]string{
+				"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, testhelper.GetPointer(adminEmail)),
+			},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, rep.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[exerciseEntity.MergeExercisesResponse](rep.Body)
+		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
+		assert.Equal(t, []uuid.UUID{source.ID}, responseParsed.Data.MergedIDs)
+		assert.Equal(t, exerciseEntity.MovedReferences{
+			WorkoutExercises: 1,
+			ProgressLogs:     0,
+			MuscleGroups:     1,
+			Aliases:          1,
+		}, responseParsed.Data.Moved)
+
+		// Check the target received the source muscle group and name
+		assert.Equal(t, target.ID, responseParsed.Data.Target.ID)
+		assert.Len(t, responseParsed.Data.Target.MuscleGroups, 2)
+		muscleGroupNames := []string{targetMuscleGroup.Name, "Test muscle group #1"}
+		for _, muscleGroup := range responseParsed.Data.Target.MuscleGroups {
+			assert.True(t, slices.Contains(muscleGroupNames, muscleGroup.Name))
+		}
+		assert.Len(t, responseParsed.Data.Target.Aliases, 1)
+		assert.Equal(t, source.Name, responseParsed.Data.Target.Aliases[0])
+
+		// Check the source was deleted
+		assert.Nil(t, getExerciseByID(ctx, source.ID))
+	})
+
+	t.Run("should only allow admins to merge exercises", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		source, _ := createExerciseWithMuscleGroup(ctx, "Barbell Bench", "Barbell Bench description", "chest")
+		target, _ := createExerciseWithMuscleGroup(ctx, "Bench Press", "Bench Press description", "pectorals")
+
+		rep, err := testhelper.RunRequest(setup,
+			http.MethodPost,
+			"/exercises/"+target.ID.String()+"/merge",
+			exerciseEntity.MergeExercisesRequest{
+				SourceIDs: []uuid.UUID{source.ID},
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, rep.StatusCode)
+	})
 }
 
 func cleanUpDatabase(ctx context.Context) {
@@ -226,7 +567,7 @@ func getExerciseByID(ctx context.Context, id uuid.UUID) *exerciseEntity.Model {
 }
 
 func dropExercises(ctx context.Context) {
-	_, err := database.DB().NewDelete().Model(&exerciseEntity.Model{}).Where("1 = 1").Exec(ctx)
+	_, err := database.DB().NewDelete().Model(&exerciseEntity.Model{}).Where("1 = 1").ForceDelete().Exec(ctx)
 	if err != nil {
 		panic(err)
 	}
@@ -242,7 +583,7 @@ func createMuscleGroup(ctx context.Context, model *musclegroup.Model) musclegrou
 }
 
 func dropMuscleGroups(ctx context.Context) {
-	_, err := database.DB().NewDelete().Model(&musclegroup.Model{}).Where("1 = 1").Exec(ctx)
+	_, err := database.DB().NewDelete().Model(&musclegroup.Model{}).Where("1 = 1").ForceDelete().Exec(ctx)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,164 @@
+package idempotency_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/idempotency"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	t.Run("should replay the first response to a retried request", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		header := map[string]string{
+			"Authorization":    testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+			idempotency.Header: "create-push-day",
+		}
+		body := workout.CreateWorkoutRequest{Name: "Push Day", UserID: testUser.ID}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts", body, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+
+		created := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts", body, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "true", resp.Header.Get(idempotency.ReplayedHeader))
+
+		replayed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, created.Data.ID, replayed.Data.ID)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/workouts", nil, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		list := testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+		assert.Len(t, list.Data, 1)
+	})
+
+	t.Run("should run a retried request again once the first one outlived its lock", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		header := map[string]string{
+			"Authorization":    testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+			idempotency.Header: "create-push-day",
+		}
+		body := workout.CreateWorkoutRequest{Name: "Push Day", UserID: testUser.ID}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts", body, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		// Leave the key reserved, as if the first request never answered.
+		reserve := func(lockedUntil time.Time) {
+			_, err := database.DB().NewUpdate().
+				Model((*idempotency.Model)(nil)).
+				Set("status_code = NULL").
+				Set("content_type = NULL").
+				Set("body = NULL").
+				Set("locked_until = ?", lockedUntil).
+				Where("user_id = ?", testUser.ID).
+				Exec(ctx)
+			assert.Nil(t, err)
+		}
+
+		reserve(time.Now().Add(time.Minute))
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts", body, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		reserve(time.Now().Add(-time.Second))
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts", body, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts", body, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "true", resp.Header.Get(idempotency.ReplayedHeader))
+	})
+
+	t.Run("should reject a key reused with a different request", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		header := map[string]string{
+			"Authorization":    testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+			idempotency.Header: "create-workout",
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts",
+			workout.CreateWorkoutRequest{Name: "Push Day", UserID: testUser.ID}, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts",
+			workout.CreateWorkoutRequest{Name: "Pull Day", UserID: testUser.ID}, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, idempotency.ErrorKeyWasReused, (*errorResponse.Details)[0])
+	})
+
+	t.Run("should not share keys between users", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		owner := testhelper.CreateUser(ctx, database.DB(), nil)
+		header := map[string]string{
+			"Authorization":    testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &owner.Email),
+			idempotency.Header: "create-workout",
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts",
+			workout.CreateWorkoutRequest{Name: "Push Day", UserID: owner.ID}, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		friend := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Jane Doe", Email: "jane@doe.com", Password: "password"})
+		header = map[string]string{
+			"Authorization":    testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &friend.Email),
+			idempotency.Header: "create-workout",
+		}
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/workouts",
+			workout.CreateWorkoutRequest{Name: "Leg Day", UserID: friend.ID}, header)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(idempotency.ReplayedHeader))
+	})
+}
@@ -8,17 +8,23 @@ import (
 	"testing"
 
 	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	exerciseEntity "github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+const adminEmail = "admin@gymratz.com"
+
 func TestMuscleGroupHandler(t *testing.T) {
 	t.Parallel()
 
 	os.Setenv("GO_ENV", "test")
+	os.Setenv("ADMIN_EMAILS", adminEmail)
 	setup, ctx := setup.Init()
 	defer database.CloseTestDB(ctx)
 
@@ -36,7 +42,7 @@ func TestMuscleGroupHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.Model](resp.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.MuscleGroupResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 		assert.NotNil(t, responseParsed.Data)
 		assert.NotNil(t, responseParsed.Data.ID)
@@ -64,14 +70,14 @@ func TestMuscleGroupHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-		responseParsed := testhelper.ParsePaginationResponseBody[[]musclegroup.Model](resp.Body)
+		responseParsed := testhelper.ParsePaginationResponseBody[[]musclegroup.MuscleGroupResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 
 		// Check the response pagination metadata
 		assert.Equal(t, 1, responseParsed.Pagination.Page)
 		assert.Equal(t, 10, responseParsed.Pagination.PerPage) // Default per page
-		assert.Equal(t, 5, responseParsed.Pagination.TotalItems)
-		assert.Equal(t, 1, responseParsed.Pagination.TotalPages)
+		assert.Equal(t, 5, *responseParsed.Pagination.TotalItems)
+		assert.Equal(t, 1, *responseParsed.Pagination.TotalPages)
 
 		// Check the response data
 		assert.NotEmpty(t, responseParsed.Data)
@@ -101,12 +107,104 @@ func TestMuscleGroupHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.Model](resp.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.MuscleGroupResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 		assert.NotNil(t, responseParsed.Data)
 		assert.Equal(t, "Chest Updated", responseParsed.Data.Name)
 	})
 
+	t.Run("should patch a muscle group", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		muscleGroup := createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Chest",
+		})
+		path := "/muscle-groups/" + muscleGroup.ID.String()
+		patchHeader := map[string]string{"Content-Type": mergepatch.MIMEMergePatchJSON}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"name": "Chest Patched"}, patchHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.MuscleGroupResponse](resp.Body)
+		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
+		assert.Equal(t, "Chest Patched", responseParsed.Data.Name)
+
+		// Clearing the name leaves the muscle group invalid
+		resp, err = testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"name": nil}, patchHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should not create a muscle group without a name", func(t *testing.T) {
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/muscle-groups",
+			&musclegroup.CreateMuscleGroupRequest{
+				Name: "   ",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, &response.ErrorDetails{musclegroup.ErrorNameIsRequired}, errorResponse.Details)
+	})
+
+	t.Run("should not create a muscle group with a name already in use", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		_ = createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Chest",
+		})
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/muscle-groups",
+			&musclegroup.CreateMuscleGroupRequest{
+				Name: " chest ",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, http.StatusConflict, errorResponse.Code)
+		assert.Equal(t, &response.ErrorDetails{musclegroup.ErrorNameAlreadyExists}, errorResponse.Details)
+	})
+
+	t.Run("should not rename a muscle group to a name already in use", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		_ = createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Chest",
+		})
+		muscleGroup := createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Back",
+		})
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPut,
+			"/muscle-groups/"+muscleGroup.ID.String(),
+			&musclegroup.UpdateMuscleGroupRequest{
+				Name: "CHEST",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
 	t.Run("should delete a muscle group", func(t *testing.T) {
 		cleanUpDatabase(ctx)
 
@@ -125,10 +223,119 @@ func TestMuscleGroupHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
 	})
+
+	t.Run("should not delete a muscle group associated with exercises", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		_, muscleGroup := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Bench Press", "Bench Press Description", "Chest")
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodDelete,
+			"/muscle-groups/"+muscleGroup.ID.String(),
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		responseParsed := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, map[string]any{"exercises": float64(1), "workouts": float64(0)}, responseParsed.Meta)
+	})
+
+	t.Run("should delete a muscle group moving its exercises to the replacement", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		exercise, muscleGroup := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Bench Press", "Bench Press Description", "Chest")
+		replacement := createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Pectorals",
+		})
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodDelete,
+			"/muscle-groups/"+muscleGroup.ID.String()+"?replace_with="+replacement.ID.String(),
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		count, err := database.DB().NewSelect().
+			Model((*exerciseEntity.ExerciseMuscleGroupModel)(nil)).
+			Where("exercise_id = ? AND muscle_group_id = ?", exercise.ID, replacement.ID).
+			Count(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should merge duplicate muscle groups into the target", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		exercise, source := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Bench Press", "Bench Press Description", "chest")
+		target := createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Pectorals",
+		})
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/muscle-groups/"+target.ID.String()+"/merge",
+			musclegroup.MergeMuscleGroupsRequest{
+				SourceIDs: []uuid.UUID{source.ID},
+			},
+			map[string]string{
+				"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, testhelper.GetPointer(adminEmail)),
+			},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.MergeMuscleGroupsResponse](resp.Body)
+		assert.Equal(t, musclegroup.MovedReferences{Exercises: 1, Aliases: 1}, responseParsed.Data.Moved)
+		assert.Len(t, responseParsed.Data.Target.Aliases, 1)
+		assert.Equal(t, "chest", responseParsed.Data.Target.Aliases[0])
+
+		count, err := database.DB().NewSelect().
+			Model((*exerciseEntity.ExerciseMuscleGroupModel)(nil)).
+			Where("exercise_id = ? AND muscle_group_id = ?", exercise.ID, target.ID).
+			Count(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("should not merge a muscle group into itself", func(t *testing.T) {
+		cleanUpDatabase(ctx)
+
+		target := createMuscleGroup(ctx, &musclegroup.Model{
+			Name: "Chest",
+		})
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/muscle-groups/"+target.ID.String()+"/merge",
+			musclegroup.MergeMuscleGroupsRequest{
+				SourceIDs: []uuid.UUID{target.ID},
+			},
+			map[string]string{
+				"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, testhelper.GetPointer(adminEmail)),
+			},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		responseParsed := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, &response.ErrorDetails{musclegroup.ErrorSourceIDsContainsTarget}, responseParsed.Details)
+	})
 }
 
 func cleanUpDatabase(ctx context.Context) {
-	_, err := database.DB().NewDelete().Model((*musclegroup.Model)(nil)).Where("1 = 1").Exec(ctx)
+	_, err := database.DB().NewDelete().Model((*musclegroup.Model)(nil)).Where("1 = 1").ForceDelete().Exec(ctx)
 	if err != nil {
 		panic(err)
 	}
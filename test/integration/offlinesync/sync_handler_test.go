@@ -0,0 +1,252 @@
+package offlinesync_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/offlinesync"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	t.Run("should pull the changes made since a change token", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/v1/sync", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		full := testhelper.ParseSuccessResponseBody[offlinesync.PullResponse](resp.Body)
+		assert.Len(t, full.Data.Workouts, 1)
+		assert.Empty(t, full.Data.Deleted)
+		assert.NotEmpty(t, full.Data.ChangeToken)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete, "/v1/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/sync?since="+full.Data.ChangeToken, nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		delta := testhelper.ParseSuccessResponseBody[offlinesync.PullResponse](resp.Body)
+		assert.Empty(t, delta.Data.Workouts)
+		assert.Len(t, delta.Data.Deleted, 1)
+		assert.Equal(t, offlinesync.TypeWorkout, delta.Data.Deleted[0].Type)
+		assert.Equal(t, testWorkout.ID, delta.Data.Deleted[0].ID)
+	})
+
+	t.Run("should reject change tokens not issued by the server", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/v1/sync?since=yesterday", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("should ask for a full pull when the change token is older than the trash retention", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		changeToken, err := offlinesync.EncodeChangeToken(offlinesync.NewChangeToken(time.Now().Add(-setup.EnvVariables.TrashRetention)))
+		assert.Nil(t, err)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/v1/sync?since="+changeToken, nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusGone, resp.StatusCode)
+	})
+
+	t.Run("should push offline changes and report conflicts", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		staleUpdatedAt := testWorkout.UpdatedAt.Add(-time.Hour)
+		sessionID := uuid.New()
+		startedAt := time.Now().Add(-2 * time.Hour).UTC()
+		completedAt := startedAt.Add(time.Hour)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/sync", offlinesync.PushRequest{
+			Workouts: []offlinesync.WorkoutChange{
+				{ID: testWorkout.ID, Name: "Renamed Offline", UpdatedAt: &staleUpdatedAt},
+			},
+			Sessions: []offlinesync.SessionChange{
+				{ID: sessionID, WorkoutID: testWorkout.ID, StartedAt: startedAt, CompletedAt: &completedAt},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[offlinesync.PushResponse](resp.Body)
+		assert.Equal(t, []offlinesync.Ref{{Type: offlinesync.TypeSession, ID: sessionID}}, responseParsed.Data.Applied)
+		assert.Equal(t, []offlinesync.Ref{{Type: offlinesync.TypeWorkout, ID: testWorkout.ID}}, responseParsed.Data.Conflicts)
+		assert.Len(t, responseParsed.Data.Current.Workouts, 1)
+		assert.Equal(t, testWorkout.Name, responseParsed.Data.Current.Workouts[0].Name)
+
+		current := responseParsed.Data.Current.Workouts[0].UpdatedAt
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/sync", offlinesync.PushRequest{
+			Workouts: []offlinesync.WorkoutChange{
+				{ID: testWorkout.ID, Name: "Renamed Offline", UpdatedAt: &current},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed = testhelper.ParseSuccessResponseBody[offlinesync.PushResponse](resp.Body)
+		assert.Len(t, responseParsed.Data.Applied, 1)
+		assert.Empty(t, responseParsed.Data.Conflicts)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/sync", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		full := testhelper.ParseSuccessResponseBody[offlinesync.PullResponse](resp.Body)
+		assert.Equal(t, "Renamed Offline", full.Data.Workouts[0].Name)
+		assert.Len(t, full.Data.Sessions, 1)
+		assert.Equal(t, sessionID, full.Data.Sessions[0].ID)
+		assert.True(t, startedAt.Round(time.Microsecond).Equal(full.Data.Sessions[0].StartedAt))
+	})
+
+	t.Run("should keep custom exercises to their owner and refuse changes to the catalog", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		catalogExercise, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Bench Press", "Chest press", "Chest")
+
+		owner := testhelper.CreateUser(ctx, database.DB(), nil)
+		ownerHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &owner.Email),
+		}
+
+		customID := uuid.New()
+		catalogUpdatedAt := catalogExercise.UpdatedAt
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/sync", offlinesync.PushRequest{
+			Exercises: []offlinesync.ExerciseChange{
+				{ID: customID, Name: "Band Pull Apart"},
+				{ID: catalogExercise.ID, Name: "Renamed Offline", UpdatedAt: &catalogUpdatedAt},
+			},
+		}, ownerHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		pushed := testhelper.ParseSuccessResponseBody[offlinesync.PushResponse](resp.Body)
+		assert.Equal(t, []offlinesync.Ref{{Type: offlinesync.TypeExercise, ID: customID}}, pushed.Data.Applied)
+		assert.Equal(t, []offlinesync.Ref{{Type: offlinesync.TypeExercise, ID: catalogExercise.ID}}, pushed.Data.Conflicts)
+		assert.Len(t, pushed.Data.Current.Exercises, 1)
+		assert.Equal(t, "Bench Press", pushed.Data.Current.Exercises[0].Name)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/sync", nil, ownerHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		full := testhelper.ParseSuccessResponseBody[offlinesync.PullResponse](resp.Body)
+		assert.Len(t, full.Data.Exercises, 2)
+		for _, pulled := range full.Data.Exercises {
+			if pulled.ID == customID {
+				assert.Equal(t, &owner.ID, pulled.UserID)
+			} else {
+				assert.Nil(t, pulled.UserID)
+			}
+		}
+
+		other := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Jane Doe", Email: "jane@doe.com", Password: "password"})
+		otherHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &other.Email),
+		}
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/sync", nil, otherHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		full = testhelper.ParseSuccessResponseBody[offlinesync.PullResponse](resp.Body)
+		assert.Len(t, full.Data.Exercises, 1)
+		assert.Equal(t, catalogExercise.ID, full.Data.Exercises[0].ID)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/exercises", nil, ownerHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		catalog := testhelper.ParsePaginationResponseBody[[]exercise.ExerciseResponse](resp.Body)
+		assert.Len(t, catalog.Data, 1)
+	})
+
+	t.Run("should report a client id taken by another user's record as a conflict", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		owner := testhelper.CreateUser(ctx, database.DB(), nil)
+		ownerWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), owner.ID, 1)
+
+		other := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Jane Doe", Email: "jane@doe.com", Password: "password"})
+		otherHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &other.Email),
+		}
+
+		workoutID := uuid.New()
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/sync", offlinesync.PushRequest{
+			Workouts: []offlinesync.WorkoutChange{
+				{ID: ownerWorkout.ID, Name: "Taken"},
+				{ID: workoutID, Name: "Push Day"},
+			},
+		}, otherHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		pushed := testhelper.ParseSuccessResponseBody[offlinesync.PushResponse](resp.Body)
+		assert.Equal(t, []offlinesync.Ref{{Type: offlinesync.TypeWorkout, ID: workoutID}}, pushed.Data.Applied)
+		assert.Equal(t, []offlinesync.Ref{{Type: offlinesync.TypeWorkout, ID: ownerWorkout.ID}}, pushed.Data.Conflicts)
+		assert.Empty(t, pushed.Data.Current.Workouts)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/v1/workouts/"+workoutID.String(), nil, otherHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
@@ -0,0 +1,134 @@
+package program_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exerciseprogress"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/program"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgramHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	t.Run("should enroll in a program and resolve the workout of the day", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		_, err := database.DB().NewInsert().Model(&exerciseprogress.Model{
+			UserID:      testUser.ID,
+			ExerciseID:  testWorkout.WorkoutExercises[0].ExerciseID,
+			WorkoutID:   testWorkout.ID,
+			Sets:        1,
+			Repetitions: 1,
+			Weight:      100,
+		}).Exec(ctx)
+		assert.Nil(t, err)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/programs", program.CreateProgramRequest{
+			Name: "Strength block",
+			Weeks: []program.WeekRequest{
+				{Week: 1, IntensityPercentage: 85, Sets: testhelper.GetPointer(5), Days: []program.DayRequest{{Day: 1, WorkoutID: testWorkout.ID}}},
+				{Week: 2, IntensityPercentage: 90, Days: []program.DayRequest{{Day: 1, WorkoutID: testWorkout.ID}}},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		created := testhelper.ParseSuccessResponseBody[program.ProgramResponse](resp.Body)
+		assert.Len(t, created.Data.Weeks, 2)
+		assert.Equal(t, program.DefaultTrainingMaxPercentage, created.Data.TrainingMaxPercentage)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/programs/"+created.Data.ID.String()+"/enroll",
+			program.EnrollRequest{StartDate: "2025-03-10"}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/programs/today?date=2025-03-10", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		today := testhelper.ParseSuccessResponseBody[program.TodayResponse](resp.Body)
+		assert.Equal(t, 1, today.Data.Week)
+		assert.Equal(t, 1, today.Data.Day)
+		assert.Equal(t, testWorkout.ID, today.Data.Workout.ID)
+		assert.Len(t, today.Data.Workout.Exercises, 1)
+		assert.Equal(t, 5, today.Data.Workout.Exercises[0].Sets)
+		assert.Equal(t, 90.0, *today.Data.Workout.Exercises[0].TrainingMax)
+		assert.Equal(t, 77.5, *today.Data.Workout.Exercises[0].Weight)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/programs/today?date=2025-03-11", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		restDay := testhelper.ParseSuccessResponseBody[program.TodayResponse](resp.Body)
+		assert.True(t, restDay.Data.RestDay)
+		assert.Nil(t, restDay.Data.Workout)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/programs/today?date=2025-03-24", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		finished := testhelper.ParseSuccessResponseBody[program.TodayResponse](resp.Body)
+		assert.True(t, finished.Data.Finished)
+	})
+
+	t.Run("should reject days mapped to workouts of other users", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		otherUser := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Jane Doe", Email: "jane@doe.com", Password: "password"})
+		otherWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), otherUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/programs", program.CreateProgramRequest{
+			Name: "Strength block",
+			Weeks: []program.WeekRequest{
+				{Week: 1, IntensityPercentage: 85, Days: []program.DayRequest{{Day: 1, WorkoutID: otherWorkout.ID}}},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		body := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, &response.ErrorDetails{program.ErrorWorkoutsNotFound}, body.Details)
+	})
+
+	t.Run("should report when there is no active enrollment", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/programs/today", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,224 @@
+package schedule_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/schedule"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	pastDate := time.Now().AddDate(0, 0, -2).Format(validation.DateLayout)
+	futureDate := time.Now().AddDate(0, 0, 2).Format(validation.DateLayout)
+
+	t.Run("should schedule a workout, run its session and report planned vs completed", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/schedule", schedule.ScheduleWorkoutRequest{
+			WorkoutID: testWorkout.ID,
+			Dates:     []string{pastDate, futureDate},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		scheduled := testhelper.ParseSuccessResponseBody[[]schedule.ScheduledWorkoutResponse](resp.Body)
+		assert.Len(t, scheduled.Data, 2)
+		assert.Equal(t, schedule.StatusMissed, scheduled.Data[0].Status)
+		assert.Equal(t, schedule.StatusPlanned, scheduled.Data[1].Status)
+
+		upcoming := scheduled.Data[1]
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/sessions", workouthistory.StartSessionRequest{
+			ScheduledWorkoutID: &upcoming.ID,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		session := testhelper.ParseSuccessResponseBody[workouthistory.SessionResponse](resp.Body)
+		assert.Equal(t, testWorkout.ID, session.Data.WorkoutID)
+		assert.Equal(t, upcoming.ID, *session.Data.ScheduledWorkoutID)
+		assert.Nil(t, session.Data.CompletedAt)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/sessions", workouthistory.StartSessionRequest{
+			ScheduledWorkoutID: &upcoming.ID,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/sessions/"+session.Data.ID.String()+"/complete",
+			workouthistory.CompleteSessionRequest{Notes: testhelper.GetPointer("Felt strong")}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		completed := testhelper.ParseSuccessResponseBody[workouthistory.SessionResponse](resp.Body)
+		assert.NotNil(t, completed.Data.CompletedAt)
+		assert.Equal(t, "Felt strong", *completed.Data.Notes)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/sessions/"+session.Data.ID.String()+"/complete", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/schedule?from="+pastDate+"&to="+futureDate, nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		list := testhelper.ParseSuccessResponseBody[schedule.ListScheduleResponse](resp.Body)
+		assert.Equal(t, pastDate, list.Data.From)
+		assert.Equal(t, futureDate, list.Data.To)
+		assert.Len(t, list.Data.Items, 2)
+		assert.Equal(t, testWorkout.Name, list.Data.Items[0].Workout.Name)
+		assert.Equal(t, schedule.Summary{Planned: 2, Completed: 1, Missed: 1}, list.Data.Summary)
+	})
+
+	t.Run("should start a scheduled workout again once its session is deleted", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/schedule", schedule.ScheduleWorkoutRequest{
+			WorkoutID: testWorkout.ID,
+			Dates:     []string{futureDate},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		scheduled := testhelper.ParseSuccessResponseBody[[]schedule.ScheduledWorkoutResponse](resp.Body)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/sessions", workouthistory.StartSessionRequest{
+			ScheduledWorkoutID: &scheduled.Data[0].ID,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		session := testhelper.ParseSuccessResponseBody[workouthistory.SessionResponse](resp.Body)
+		testhelper.SoftDelete(ctx, database.DB(), &workouthistory.Model{ID: session.Data.ID})
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/sessions", workouthistory.StartSessionRequest{
+			ScheduledWorkoutID: &scheduled.Data[0].ID,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("should start and list ad hoc sessions", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/sessions", workouthistory.StartSessionRequest{
+			WorkoutID: &testWorkout.ID,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/sessions", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		sessions := testhelper.ParsePaginationResponseBody[[]workouthistory.SessionResponse](resp.Body)
+		assert.Equal(t, 1, *sessions.Pagination.TotalItems)
+		assert.Equal(t, testWorkout.ID, sessions.Data[0].WorkoutID)
+		assert.Nil(t, sessions.Data[0].ScheduledWorkoutID)
+	})
+
+	t.Run("should not schedule another user's workout", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		owner := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), owner.ID, 1)
+		otherUser := testhelper.CreateUser(ctx, database.DB(), &user.Model{
+			Name:     "Jane Doe",
+			Email:    "jane@doe.com",
+			Password: "password",
+		})
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/schedule", schedule.ScheduleWorkoutRequest{
+			WorkoutID: testWorkout.ID,
+			Dates:     []string{futureDate},
+		}, map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &otherUser.Email),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, response.StatusError, errorResponse.Status)
+		assert.Equal(t, "Workout not found", errorResponse.Message)
+	})
+
+	t.Run("should unschedule a workout", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/schedule", schedule.ScheduleWorkoutRequest{
+			WorkoutID: testWorkout.ID,
+			Dates:     []string{futureDate},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		scheduled := testhelper.ParseSuccessResponseBody[[]schedule.ScheduledWorkoutResponse](resp.Body)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete, "/schedule/"+scheduled.Data[0].ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/schedule?from="+futureDate, nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		list := testhelper.ParseSuccessResponseBody[schedule.ListScheduleResponse](resp.Body)
+		assert.Empty(t, list.Data.Items)
+		assert.Equal(t, 0, list.Data.Summary.Planned)
+	})
+}
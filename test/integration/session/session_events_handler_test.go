@@ -0,0 +1,116 @@
+package session_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/fasthttp/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionEventsHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	go setup.App.Listener(listener)
+	defer setup.App.Shutdown()
+
+	t.Run("should stream the events of a session to the devices watching it", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/sessions", workouthistory.StartSessionRequest{
+			WorkoutID: &testWorkout.ID,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		session := testhelper.ParseSuccessResponseBody[workouthistory.SessionResponse](resp.Body)
+		eventsPath := "/v1/sessions/" + session.Data.ID.String() + "/events"
+
+		watcher, _, err := websocket.DefaultDialer.Dial("ws://"+listener.Addr().String()+eventsPath, http.Header{
+			"Authorization": {authHeader["Authorization"]},
+		})
+		assert.Nil(t, err)
+		defer watcher.Close()
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, eventsPath, workouthistory.PublishEventRequest{
+			Type:              workouthistory.EventSetLogged,
+			WorkoutExerciseID: &testWorkout.WorkoutExercises[0].ID,
+			Set:               testhelper.GetPointer(1),
+			Repetitions:       testhelper.GetPointer(10),
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var event workouthistory.Event
+		assert.Nil(t, watcher.ReadJSON(&event))
+		assert.Equal(t, workouthistory.EventSetLogged, event.Type)
+		assert.Equal(t, session.Data.ID, event.SessionID)
+		assert.Equal(t, 10, *event.Repetitions)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/v1/sessions/"+session.Data.ID.String()+"/complete", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Nil(t, watcher.ReadJSON(&event))
+		assert.Equal(t, workouthistory.EventSessionFinished, event.Type)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, eventsPath, json.RawMessage(`{"type":"rest_timer_started","duration":90}`), authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("should not stream the events of another user's session", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		owner := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), owner.ID, 1)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/v1/sessions", workouthistory.StartSessionRequest{
+			WorkoutID: &testWorkout.ID,
+		}, map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &owner.Email),
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		session := testhelper.ParseSuccessResponseBody[workouthistory.SessionResponse](resp.Body)
+		other := testhelper.CreateUser(ctx, database.DB(), &user.Model{
+			Name:     "Jane Doe",
+			Email:    "jane@doe.com",
+			Password: "password",
+		})
+
+		_, resp, err = websocket.DefaultDialer.Dial("ws://"+listener.Addr().String()+"/v1/sessions/"+session.Data.ID.String()+"/events", http.Header{
+			"Authorization": {testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &other.Email)},
+		})
+
+		assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
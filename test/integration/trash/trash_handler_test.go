@@ -0,0 +1,277 @@
+package trash_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/adapters/postgres"
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/musclegroup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/trash"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workouthistory"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const adminEmail = "admin@gymratz.com"
+
+func TestTrashHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	os.Setenv("ADMIN_EMAILS", adminEmail)
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	adminHeader := map[string]string{
+		"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, testhelper.GetPointer(adminEmail)),
+	}
+
+	t.Run("should list soft deleted exercises, muscle groups and workouts to admins", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		admin := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Admin", Email: adminEmail, Password: "password"})
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		testExercise, testMuscleGroup := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(),
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		adminWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), admin.ID, 1)
+
+		testhelper.SoftDelete(ctx, database.DB(), &testWorkout)
+		testhelper.SoftDelete(ctx, database.DB(), &adminWorkout)
+		testhelper.SoftDelete(ctx, database.DB(), &testExercise)
+		testhelper.SoftDelete(ctx, database.DB(), &testMuscleGroup)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/trash", nil, adminHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParsePaginationResponseBody[[]trash.Item](resp.Body)
+		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
+		assert.Equal(t, 3, *responseParsed.Pagination.TotalItems)
+
+		itemTypes := make(map[uuid.UUID]string, len(responseParsed.Data))
+		for _, item := range responseParsed.Data {
+			itemTypes[item.ID] = item.Type
+		}
+
+		assert.Equal(t, trash.ItemTypeWorkout, itemTypes[adminWorkout.ID])
+		assert.Equal(t, trash.ItemTypeExercise, itemTypes[testExercise.ID])
+		assert.Equal(t, trash.ItemTypeMuscleGroup, itemTypes[testMuscleGroup.ID])
+	})
+
+	t.Run("should only list the user's own workouts to other users", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		testExercise, testMuscleGroup := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(),
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		testhelper.SoftDelete(ctx, database.DB(), &testWorkout)
+		testhelper.SoftDelete(ctx, database.DB(), &testExercise)
+		testhelper.SoftDelete(ctx, database.DB(), &testMuscleGroup)
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodGet,
+			"/trash",
+			nil,
+			map[string]string{
+				"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+			},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParsePaginationResponseBody[[]trash.Item](resp.Body)
+		assert.Equal(t, 1, *responseParsed.Pagination.TotalItems)
+		assert.Equal(t, testWorkout.ID, responseParsed.Data[0].ID)
+		assert.Equal(t, trash.ItemTypeWorkout, responseParsed.Data[0].Type)
+	})
+
+	t.Run("should filter the trash by item type", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Admin", Email: adminEmail, Password: "password"})
+		testExercise, testMuscleGroup := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(),
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		testhelper.SoftDelete(ctx, database.DB(), &testExercise)
+		testhelper.SoftDelete(ctx, database.DB(), &testMuscleGroup)
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodGet,
+			"/trash?type="+trash.ItemTypeExercise,
+			nil,
+			adminHeader,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParsePaginationResponseBody[[]trash.Item](resp.Body)
+		assert.Len(t, responseParsed.Data, 1)
+		assert.Equal(t, testExercise.ID, responseParsed.Data[0].ID)
+		assert.Equal(t, testExercise.Name, responseParsed.Data[0].Name)
+	})
+
+	t.Run("should restore a soft deleted exercise", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testExercise, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(),
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodDelete, "/exercises/"+testExercise.ID.String(), nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/exercises/"+testExercise.ID.String()+"/restore", nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/exercises/"+testExercise.ID.String()+"/restore", nil, adminHeader)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[exercise.ExerciseResponse](resp.Body)
+		assert.Equal(t, testExercise.ID, responseParsed.Data.ID)
+		assert.Len(t, responseParsed.Data.MuscleGroups, 1)
+	})
+
+	t.Run("should restore a soft deleted muscle group", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testMuscleGroup := musclegroup.Model{Name: "chest"}
+		_, err := database.DB().NewInsert().Model(&testMuscleGroup).Exec(ctx)
+		assert.Nil(t, err)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodDelete, "/muscle-groups/"+testMuscleGroup.ID.String(), nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/muscle-groups/"+testMuscleGroup.ID.String()+"/restore", nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/muscle-groups/"+testMuscleGroup.ID.String()+"/restore", nil, adminHeader)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[musclegroup.MuscleGroupResponse](resp.Body)
+		assert.Equal(t, testMuscleGroup.ID, responseParsed.Data.ID)
+	})
+
+	t.Run("should restore a soft deleted workout", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		testhelper.SoftDelete(ctx, database.DB(), &testWorkout)
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/workouts/"+testWorkout.ID.String()+"/restore",
+			nil,
+			map[string]string{
+				"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+			},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, testWorkout.ID, responseParsed.Data.ID)
+		assert.Len(t, responseParsed.Data.Exercises, 1)
+	})
+
+	t.Run("should return not found when restoring an item that is not in the trash", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testExercise, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(),
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/exercises/"+testExercise.ID.String()+"/restore", nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should purge items deleted before the retention period", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testExercise, testMuscleGroup := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(),
+			"pushup",
+			"pushup description",
+			"chest",
+		)
+
+		testhelper.SoftDelete(ctx, database.DB(), &testExercise)
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		testSession := &workouthistory.Model{UserID: testUser.ID, WorkoutID: testWorkout.ID}
+
+		_, err := database.DB().NewInsert().Model(testSession).Exec(ctx)
+		assert.Nil(t, err)
+
+		testhelper.SoftDelete(ctx, database.DB(), testSession)
+
+		trashRepository := postgres.NewTrashRepository(database.DB())
+		purged, err := trashRepository.Purge(ctx, time.Now().Add(time.Minute))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, purged)
+
+		count, err := database.DB().NewSelect().
+			Model((*workouthistory.Model)(nil)).
+			Where("id = ?", testSession.ID).
+			WhereAllWithDeleted().
+			Count(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, count)
+
+		count, err = database.DB().NewSelect().
+			Model((*exercise.Model)(nil)).
+			Where("id = ?", testExercise.ID).
+			WhereAllWithDeleted().
+			Count(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, count)
+
+		count, err = database.DB().NewSelect().
+			Model((*musclegroup.Model)(nil)).
+			Where("id = ?", testMuscleGroup.ID).
+			Count(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
@@ -137,6 +137,33 @@ func TestUserHandler(t *testing.T) {
 		assert.Empty(t, *errorResponse.Details)
 	})
 
+	t.Run("should return problem details when the client accepts them", func(t *testing.T) {
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			registerUserPath,
+			user.RegisterUserRequest{
+				Name:     "test",
+				Email:    "not-an-email",
+				Password: "password123",
+			},
+			map[string]string{"Accept": response.MIMEProblemJSON},
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, response.MIMEProblemJSON, resp.Header.Get("Content-Type"))
+
+		problem := testhelper.ParseProblemResponseBody(resp.Body)
+
+		assert.Equal(t, "/problems/validation", problem.Type)
+		assert.Equal(t, "Bad Request", problem.Title)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "Invalid request body.", problem.Detail)
+		assert.Equal(t, resp.Header.Get("X-Request-ID"), problem.Instance)
+		assert.Equal(t, &response.ErrorDetails{user.ErrorEmailIsInvalid}, problem.Errors)
+	})
+
 	t.Run("should get user profile", func(t *testing.T) {
 		userModel := createUser(ctx, user.Model{
 			Name:     "test",
@@ -0,0 +1,369 @@
+package workout_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/exercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkoutCRUDHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	t.Run("should get a workout by id", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, testWorkout.ID, responseParsed.Data.ID)
+		assert.Equal(t, testWorkout.Name, responseParsed.Data.Name)
+		assert.Len(t, responseParsed.Data.Exercises, 1)
+		assert.Equal(t, testWorkout.WorkoutExercises[0].ExerciseID, responseParsed.Data.Exercises[0].Exercise.ID)
+	})
+
+	t.Run("should not get a workout of another user", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		otherUser := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Jane Doe", Email: "jane@doe.com", Password: "password"})
+		otherWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), otherUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+otherWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut, "/workouts/"+otherWorkout.ID.String(), workout.UpdateWorkoutRequest{
+			Name: "Renamed",
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("ETag"))
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut,
+			"/workouts/"+otherWorkout.ID.String()+"/exercises/"+otherWorkout.WorkoutExercises[0].ID.String(),
+			workout.UpdateWorkoutExerciseRequest{Sets: 5, RestTime: 90}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete, "/workouts/"+otherWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("should soft delete a workout", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodDelete, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		deleted := workout.Model{}
+		err = database.DB().NewSelect().Model(&deleted).Where("id = ?", testWorkout.ID).WhereDeleted().Scan(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, deleted.DeletedAt)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+testWorkout.ID.String()+"/restore", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("should add and remove workout exercises", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		exercise, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Barbell Row", "Barbell Row Description", "Back")
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+testWorkout.ID.String()+"/exercises", workout.AddWorkoutExerciseRequest{
+			ExerciseID: exercise.ID,
+			RestTime:   90,
+			SetPrescriptions: []workoutexercise.SetPrescription{
+				{Count: 4, Reps: "8-10", Load: "70%"},
+			},
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		added := testhelper.ParseSuccessResponseBody[workoutexercise.WorkoutExerciseResponse](resp.Body)
+		assert.Equal(t, exercise.ID, added.Data.ExerciseID)
+		assert.Equal(t, 1, added.Data.Position)
+		assert.Equal(t, 4, added.Data.Sets)
+		assert.Len(t, added.Data.SetPrescriptions, 4)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+testWorkout.ID.String()+"/exercises", workout.AddWorkoutExerciseRequest{
+			ExerciseID: uuid.New(),
+			Sets:       3,
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete,
+			"/workouts/"+testWorkout.ID.String()+"/exercises/"+testWorkout.WorkoutExercises[0].ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete,
+			"/workouts/"+testWorkout.ID.String()+"/exercises/"+testWorkout.WorkoutExercises[0].ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Len(t, responseParsed.Data.Exercises, 1)
+		assert.Equal(t, added.Data.ID, responseParsed.Data.Exercises[0].ID)
+	})
+
+	t.Run("should guard workout updates with entity tags", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		tag := resp.Header.Get("ETag")
+		assert.NotEmpty(t, tag)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, map[string]string{
+			"Authorization": authHeader["Authorization"],
+			"If-None-Match": tag,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut, "/workouts/"+testWorkout.ID.String(), &workout.UpdateWorkoutRequest{
+			Name: "Push Day",
+		}, map[string]string{
+			"Authorization": authHeader["Authorization"],
+			"If-Match":      tag,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		updatedTag := resp.Header.Get("ETag")
+		assert.NotEqual(t, tag, updatedTag)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut, "/workouts/"+testWorkout.ID.String(), &workout.UpdateWorkoutRequest{
+			Name: "Pull Day",
+		}, map[string]string{
+			"Authorization": authHeader["Authorization"],
+			"If-Match":      tag,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete,
+			"/workouts/"+testWorkout.ID.String()+"/exercises/"+testWorkout.WorkoutExercises[0].ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, map[string]string{
+			"Authorization": authHeader["Authorization"],
+			"If-None-Match": updatedTag,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEqual(t, updatedTag, resp.Header.Get("ETag"))
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, "Push Day", responseParsed.Data.Name)
+	})
+
+	t.Run("should guard workout exercise changes with entity tags", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		workoutExercise := testWorkout.WorkoutExercises[0]
+		path := "/workouts/" + testWorkout.ID.String() + "/exercises/" + workoutExercise.ID.String()
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+		ifMatch := func(tag string) map[string]string {
+			return map[string]string{
+				"Authorization": authHeader["Authorization"],
+				"If-Match":      tag,
+			}
+		}
+		patchIfMatch := func(tag string) map[string]string {
+			header := ifMatch(tag)
+			header["Content-Type"] = mergepatch.MIMEMergePatchJSON
+
+			return header
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		tag := resp.Header.Get("ETag")
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut, path, &workout.UpdateWorkoutExerciseRequest{
+			Sets:     4,
+			RestTime: 60,
+		}, ifMatch(tag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		updatedTag := resp.Header.Get("ETag")
+		assert.NotEmpty(t, updatedTag)
+		assert.NotEqual(t, tag, updatedTag)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut, path, &workout.UpdateWorkoutExerciseRequest{
+			Sets:     5,
+			RestTime: 90,
+		}, ifMatch(tag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"rest_time": 90}, patchIfMatch(tag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+testWorkout.ID.String()+"/exercises", workout.AddWorkoutExerciseRequest{
+			ExerciseID: workoutExercise.ExerciseID,
+			Sets:       3,
+		}, ifMatch(tag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete, path, nil, ifMatch(tag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"rest_time": 90}, patchIfMatch(updatedTag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		patchedTag := resp.Header.Get("ETag")
+		assert.NotEqual(t, updatedTag, patchedTag)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete, path, nil, ifMatch(patchedTag))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		removedTag := resp.Header.Get("ETag")
+		assert.NotEqual(t, patchedTag, removedTag)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, removedTag, resp.Header.Get("ETag"))
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Empty(t, responseParsed.Data.Exercises)
+	})
+
+	t.Run("should change the entity tag of a workout when an exercise it embeds changes", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		testExercise := testWorkout.WorkoutExercises[0].Exercise
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		tag := resp.Header.Get("ETag")
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPut, "/exercises/"+testExercise.ID.String(), &exercise.UpdateExerciseRequest{
+			Name: "Renamed exercise",
+		}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts/"+testWorkout.ID.String(), nil, map[string]string{
+			"Authorization": authHeader["Authorization"],
+			"If-None-Match": tag,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.NotEqual(t, tag, resp.Header.Get("ETag"))
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, "Renamed exercise", responseParsed.Data.Exercises[0].Exercise.Name)
+	})
+}
@@ -8,9 +8,12 @@ import (
 	"github.com/Gabukuro/gymratz-api/internal/infra/database"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/mergepatch"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/response"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
 	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/validation"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,19 +55,76 @@ func TestWorkoutHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusCreated, resp.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[workout.Model](resp.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 
 		assert.Equal(t, "Chest Day", responseParsed.Data.Name)
 		assert.Equal(t, user.ID, responseParsed.Data.UserID)
-		assert.Equal(t, 1, len(responseParsed.Data.WorkoutExercises))
-		assert.Equal(t, exercise.ID, responseParsed.Data.WorkoutExercises[0].ExerciseID)
-		assert.Equal(t, 3, responseParsed.Data.WorkoutExercises[0].Sets)
-		assert.Equal(t, 10, *responseParsed.Data.WorkoutExercises[0].Repetitions)
-		assert.Equal(t, 20.0, *responseParsed.Data.WorkoutExercises[0].Weight)
-		assert.Equal(t, 0, *responseParsed.Data.WorkoutExercises[0].Duration)
-		assert.Equal(t, 60, responseParsed.Data.WorkoutExercises[0].RestTime)
-		assert.Equal(t, "This is a note", *responseParsed.Data.WorkoutExercises[0].Notes)
+		assert.Equal(t, 1, len(responseParsed.Data.Exercises))
+		assert.Equal(t, exercise.ID, responseParsed.Data.Exercises[0].ExerciseID)
+		assert.Equal(t, 3, responseParsed.Data.Exercises[0].Sets)
+		assert.Equal(t, 10, *responseParsed.Data.Exercises[0].Repetitions)
+		assert.Equal(t, 20.0, *responseParsed.Data.Exercises[0].Weight)
+		assert.Equal(t, 0, *responseParsed.Data.Exercises[0].Duration)
+		assert.Equal(t, 60, responseParsed.Data.Exercises[0].RestTime)
+		assert.Equal(t, "This is a note", *responseParsed.Data.Exercises[0].Notes)
+	})
+
+	t.Run("should not create a workout with an invalid exercise prescription", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+
+		exercise, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Barbell Bench Press", "Barbell Bench Press Description", "Chest")
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/workouts",
+			&workout.CreateWorkoutRequest{
+				Name:   "Chest Day",
+				UserID: user.ID,
+				Exercises: []workout.WorkoutExercise{
+					{
+						ExerciseID: exercise.ID,
+						Sets:       3,
+						RestTime:   60,
+					},
+					{
+						Sets:     -1,
+						RestTime: 60,
+					},
+				},
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, &response.ErrorDetails{
+			validation.NewErrorDetail("exercises[1].exercise_id", validation.CodeRequired, "Exercise id is required"),
+			validation.NewErrorDetail("exercises[1].sets", validation.CodeTooSmall, "Sets must be at least 1"),
+		}, errorResponse.Details)
+	})
+
+	t.Run("should reject invalid pagination parameters", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodGet,
+			"/workouts?per_page=500",
+			nil,
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, "Invalid query parameters.", errorResponse.Message)
+		assert.Equal(t, validation.NewErrorDetail("per_page", validation.CodeTooLarge, "Per page must be at most 100"), (*errorResponse.Details)[0])
 	})
 
 	t.Run("should list all user workouts", func(t *testing.T) {
@@ -86,7 +146,7 @@ func TestWorkoutHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-		responseParsed := testhelper.ParsePaginationResponseBody[[]workout.Model](resp.Body)
+		responseParsed := testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 
 		assert.Len(t, responseParsed.Data, len(testWorkouts))
@@ -98,8 +158,8 @@ func TestWorkoutHandler(t *testing.T) {
 			assert.Equal(t, testWorkout.Name, item.Name)
 			assert.Equal(t, testWorkout.UserID, item.UserID)
 
-			assert.Len(t, item.WorkoutExercises, len(testWorkout.WorkoutExercises))
-			for i, exercise := range item.WorkoutExercises {
+			assert.Len(t, item.Exercises, len(testWorkout.WorkoutExercises))
+			for i, exercise := range item.Exercises {
 				assert.Equal(t, testWorkout.WorkoutExercises[i].ExerciseID, exercise.ExerciseID)
 				assert.Equal(t, testWorkout.WorkoutExercises[i].Sets, exercise.Sets)
 				assert.Equal(t, testWorkout.WorkoutExercises[i].Repetitions, exercise.Repetitions)
@@ -111,6 +171,131 @@ func TestWorkoutHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("should filter and sort user workouts", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testhelper.CreateUserManyWorkout(ctx, database.DB(), user.ID, 3)
+		filteredWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 3)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts?sort=-name", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+		assert.Len(t, responseParsed.Data, 4)
+		assert.Equal(t, "Test workout #3", responseParsed.Data[0].Name)
+		assert.Equal(t, "Test workout #0", responseParsed.Data[3].Name)
+
+		resp, err = testhelper.RunRequest(
+			setup,
+			http.MethodGet,
+			"/workouts?exercise_id="+filteredWorkout.WorkoutExercises[0].ExerciseID.String(),
+			nil,
+			authHeader,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed = testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+		assert.Len(t, responseParsed.Data, 1)
+		assert.Equal(t, filteredWorkout.ID, responseParsed.Data[0].ID)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts?name=%232&created_from=2000-01-01", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed = testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+		assert.Len(t, responseParsed.Data, 1)
+		assert.Equal(t, "Test workout #2", responseParsed.Data[0].Name)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/workouts?sort=user_id", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, "sort", (*errorResponse.Details)[0].Field)
+	})
+
+	t.Run("should leave out the fields that were not requested", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts?fields=name&expand=", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParsePaginationResponseBody[[]map[string]any](resp.Body)
+		assert.Len(t, responseParsed.Data, 1)
+		assert.Equal(t, testWorkout.ID.String(), responseParsed.Data[0]["id"])
+		assert.Equal(t, testWorkout.Name, responseParsed.Data[0]["name"])
+		for _, key := range []string{"user_id", "created_at", "updated_at", "exercises", "blocks"} {
+			assert.NotContains(t, responseParsed.Data[0], key)
+		}
+	})
+
+	t.Run("should page user workouts by cursor", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkouts := testhelper.CreateUserManyWorkout(ctx, database.DB(), user.ID, 5)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+		}
+
+		seen := map[uuid.UUID]bool{}
+		path := "/workouts?sort=-created_at&per_page=2&skip_count=true"
+		cursor := ""
+
+		for page := 0; page < 3; page++ {
+			resp, err := testhelper.RunRequest(setup, http.MethodGet, path+cursor, nil, authHeader)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			responseParsed := testhelper.ParsePaginationResponseBody[[]workout.WorkoutResponse](resp.Body)
+			assert.Nil(t, responseParsed.Pagination.TotalItems)
+
+			for _, item := range responseParsed.Data {
+				assert.False(t, seen[item.ID])
+				seen[item.ID] = true
+			}
+
+			if page == 0 {
+				// Workouts created meanwhile sort before the cursor and must not shift the next pages.
+				testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 5)
+			}
+
+			if page == 2 {
+				assert.Empty(t, responseParsed.Pagination.NextCursor)
+				break
+			}
+
+			assert.NotEmpty(t, responseParsed.Pagination.NextCursor)
+			cursor = "&cursor=" + responseParsed.Pagination.NextCursor
+		}
+
+		assert.Len(t, seen, len(testWorkouts))
+
+		resp, err := testhelper.RunRequest(setup, http.MethodGet, "/workouts?sort=name"+cursor, nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("should update a workout", func(t *testing.T) {
 		testhelper.CleanUpDatabase(ctx, database.DB())
 
@@ -130,7 +315,7 @@ func TestWorkoutHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[workout.Model](resp.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 
 		assert.Equal(t, "Updated workout name", responseParsed.Data.Name)
@@ -165,7 +350,7 @@ func TestWorkoutHandler(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 
-		responseParsed := testhelper.ParseSuccessResponseBody[workoutexercise.Model](resp.Body)
+		responseParsed := testhelper.ParseSuccessResponseBody[workoutexercise.WorkoutExerciseResponse](resp.Body)
 		assert.Equal(t, response.StatusSuccess, responseParsed.Status)
 
 		assert.Equal(t, workoutExercise.ID, responseParsed.Data.ID)
@@ -177,5 +362,199 @@ func TestWorkoutHandler(t *testing.T) {
 		assert.Equal(t, *updateWorkoutExerciseRequest.Duration, *responseParsed.Data.Duration)
 		assert.Equal(t, updateWorkoutExerciseRequest.RestTime, responseParsed.Data.RestTime)
 		assert.Equal(t, *updateWorkoutExerciseRequest.Notes, *responseParsed.Data.Notes)
+		assert.Len(t, responseParsed.Data.SetPrescriptions, updateWorkoutExerciseRequest.Sets)
+	})
+
+	t.Run("should patch a workout exercise", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		workoutExercise := testWorkout.WorkoutExercises[0]
+		path := "/workouts/" + testWorkout.ID.String() + "/exercises/" + workoutExercise.ID.String()
+		patchHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+			"Content-Type":  mergepatch.MIMEMergePatchJSON,
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"notes": nil, "rest_time": 90}, patchHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workoutexercise.WorkoutExerciseResponse](resp.Body)
+		assert.Nil(t, responseParsed.Data.Notes)
+		assert.Equal(t, 90, responseParsed.Data.RestTime)
+		assert.Equal(t, workoutExercise.Sets, responseParsed.Data.Sets)
+		assert.Equal(t, *workoutExercise.Repetitions, *responseParsed.Data.Repetitions)
+		assert.Equal(t, *workoutExercise.Weight, *responseParsed.Data.Weight)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"sets": 0}, patchHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPatch, path, map[string]any{"rest_time": 30}, map[string]string{
+			"Authorization": patchHeader["Authorization"],
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	})
+
+	t.Run("should prescribe a workout exercise per set", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+		workoutExercise := testWorkout.WorkoutExercises[0]
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPut,
+			"/workouts/"+testWorkout.ID.String()+"/exercises/"+workoutExercise.ID.String(),
+			&workout.UpdateWorkoutExerciseRequest{
+				RestTime: 120,
+				SetPrescriptions: []workoutexercise.SetPrescription{
+					{Reps: "3", Load: "RPE8", Tempo: testhelper.GetPointer("3-1-X-0"), Rest: testhelper.GetPointer(180)},
+					{Count: 3, Reps: "6-8", Load: "80%"},
+				},
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		responseParsed := testhelper.ParseSuccessResponseBody[workoutexercise.WorkoutExerciseResponse](resp.Body)
+		assert.Equal(t, 4, responseParsed.Data.Sets)
+		assert.Nil(t, responseParsed.Data.Repetitions)
+		assert.Len(t, responseParsed.Data.SetPrescriptions, 4)
+
+		topSet := responseParsed.Data.SetPrescriptions[0]
+		assert.Equal(t, 8.0, *topSet.RPE)
+		assert.Equal(t, "31X0", *topSet.Tempo)
+		assert.Equal(t, 180, *topSet.RestTime)
+
+		backOff := responseParsed.Data.SetPrescriptions[3]
+		assert.Equal(t, 3, backOff.Position)
+		assert.Equal(t, 6, *backOff.MinRepetitions)
+		assert.Equal(t, 8, *backOff.MaxRepetitions)
+		assert.Equal(t, 80.0, *backOff.Percentage)
+	})
+
+	t.Run("should return not found when updating a missing workout", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPut,
+			"/workouts/"+uuid.New().String(),
+			&workout.UpdateWorkoutRequest{
+				Name: "Updated workout name",
+			},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, response.StatusError, errorResponse.Status)
+		assert.Equal(t, http.StatusNotFound, errorResponse.Code)
+		assert.Equal(t, "Workout not found", errorResponse.Message)
+	})
+
+	t.Run("should return not found when updating a missing workout exercise", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), user.ID, 1)
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPut,
+			"/workouts/"+testWorkout.ID.String()+"/exercises/"+uuid.New().String(),
+			&workout.UpdateWorkoutExerciseRequest{Sets: 3},
+			nil,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, "Workout exercise not found", errorResponse.Message)
+	})
+
+	t.Run("should create grouped exercises and reorder them", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		user := testhelper.CreateUser(ctx, database.DB(), nil)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &user.Email),
+		}
+
+		benchPress, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Barbell Bench Press", "Barbell Bench Press Description", "Chest")
+		row, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Barbell Row", "Barbell Row Description", "Back")
+		plank, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Plank", "Plank Description", "Core")
+
+		resp, err := testhelper.RunRequest(
+			setup,
+			http.MethodPost,
+			"/workouts",
+			&workout.CreateWorkoutRequest{
+				Name:   "Upper Day",
+				UserID: user.ID,
+				Exercises: []workout.WorkoutExercise{
+					{ExerciseID: benchPress.ID, Group: testhelper.GetPointer(0), Sets: 3, RestTime: 0},
+					{ExerciseID: row.ID, Group: testhelper.GetPointer(0), Sets: 3, RestTime: 90},
+					{ExerciseID: plank.ID, Sets: 3, Duration: testhelper.GetPointer(60), RestTime: 60},
+				},
+				Groups: []workout.ExerciseGroup{{Type: workoutexercise.GroupTypeSuperset}},
+			},
+			authHeader,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		created := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Len(t, created.Data.Blocks, 2)
+		assert.Equal(t, workoutexercise.GroupTypeSuperset, created.Data.Blocks[0].Group.Type)
+		assert.Len(t, created.Data.Blocks[0].WorkoutExerciseIDs, 2)
+		assert.Nil(t, created.Data.Blocks[1].Group)
+
+		exercises := created.Data.Exercises
+		assert.Equal(t, []uuid.UUID{benchPress.ID, row.ID, plank.ID}, []uuid.UUID{exercises[0].ExerciseID, exercises[1].ExerciseID, exercises[2].ExerciseID})
+
+		resp, err = testhelper.RunRequest(
+			setup,
+			http.MethodPut,
+			"/workouts/"+created.Data.ID.String()+"/exercises/order",
+			&workout.ReorderWorkoutExercisesRequest{WorkoutExerciseIDs: []uuid.UUID{exercises[2].ID, exercises[0].ID, exercises[1].ID}},
+			authHeader,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		reordered := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, plank.ID, reordered.Data.Exercises[0].ExerciseID)
+		assert.Nil(t, reordered.Data.Blocks[0].Group)
+		assert.Equal(t, workoutexercise.GroupTypeSuperset, reordered.Data.Blocks[1].Group.Type)
+
+		resp, err = testhelper.RunRequest(
+			setup,
+			http.MethodPut,
+			"/workouts/"+created.Data.ID.String()+"/exercises/order",
+			&workout.ReorderWorkoutExercisesRequest{WorkoutExerciseIDs: []uuid.UUID{exercises[0].ID, exercises[2].ID, exercises[1].ID}},
+			authHeader,
+		)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		errorResponse := testhelper.ParseErrorResponseBody(resp.Body)
+		assert.Equal(t, &response.ErrorDetails{workout.ErrorReorderSplitsGroup}, errorResponse.Details)
 	})
 }
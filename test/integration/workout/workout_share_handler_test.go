@@ -0,0 +1,131 @@
+package workout_test
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/Gabukuro/gymratz-api/internal/infra/database"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/user"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workout"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/entity/workoutexercise"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/setup"
+	"github.com/Gabukuro/gymratz-api/internal/pkg/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkoutShareHandler(t *testing.T) {
+	t.Parallel()
+
+	os.Setenv("GO_ENV", "test")
+	setup, ctx := setup.Init()
+	defer database.CloseTestDB(ctx)
+
+	t.Run("should duplicate a workout into the account of its owner", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		testUser := testhelper.CreateUser(ctx, database.DB(), nil)
+		testWorkout := testhelper.CreateSingleWorkout(ctx, database.DB(), testUser.ID, 1)
+		authHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &testUser.Email),
+		}
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+testWorkout.ID.String()+"/duplicate", nil, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		duplicated := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.NotEqual(t, testWorkout.ID, duplicated.Data.ID)
+		assert.Equal(t, testWorkout.Name+workout.CopySuffix, duplicated.Data.Name)
+		assert.Equal(t, testUser.ID, duplicated.Data.UserID)
+		assert.Len(t, duplicated.Data.Exercises, 1)
+		assert.NotEqual(t, testWorkout.WorkoutExercises[0].ID, duplicated.Data.Exercises[0].ID)
+		assert.Equal(t, testWorkout.WorkoutExercises[0].ExerciseID, duplicated.Data.Exercises[0].ExerciseID)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+testWorkout.ID.String()+"/duplicate",
+			workout.CopyWorkoutRequest{Name: testhelper.GetPointer("Push Day B")}, authHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		renamed := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, "Push Day B", renamed.Data.Name)
+	})
+
+	t.Run("should preview and import a shared workout until the link is revoked", func(t *testing.T) {
+		testhelper.CleanUpDatabase(ctx, database.DB())
+
+		owner := testhelper.CreateUser(ctx, database.DB(), nil)
+		friend := testhelper.CreateUser(ctx, database.DB(), &user.Model{Name: "Jane Doe", Email: "jane@doe.com", Password: "password"})
+		ownerHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &owner.Email),
+		}
+		friendHeader := map[string]string{
+			"Authorization": testhelper.GenerateAuthToken(setup.EnvVariables.JWTSecret, &friend.Email),
+		}
+
+		benchPress, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Barbell Bench Press", "Barbell Bench Press Description", "Chest")
+		row, _ := testhelper.CreateExerciseWithMuscleGroup(ctx, database.DB(), "Barbell Row", "Barbell Row Description", "Back")
+
+		resp, err := testhelper.RunRequest(setup, http.MethodPost, "/workouts", &workout.CreateWorkoutRequest{
+			Name:   "Upper Day",
+			UserID: owner.ID,
+			Exercises: []workout.WorkoutExercise{
+				{ExerciseID: benchPress.ID, Group: testhelper.GetPointer(0), SetPrescriptions: []workoutexercise.SetPrescription{{Count: 3, Reps: "8-10", Load: "75%"}}},
+				{ExerciseID: row.ID, Group: testhelper.GetPointer(0), Sets: 3, Repetitions: testhelper.GetPointer(10)},
+			},
+			Groups: []workout.ExerciseGroup{{Type: workoutexercise.GroupTypeSuperset}},
+		}, ownerHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		shared := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+shared.Data.ID.String()+"/share-links", nil, friendHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/workouts/"+shared.Data.ID.String()+"/share-links", nil, ownerHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		link := testhelper.ParseSuccessResponseBody[workout.ShareLinkResponse](resp.Body)
+		assert.NotEmpty(t, link.Data.Token)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/shared/workouts/"+link.Data.Token, nil, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		preview := testhelper.ParseSuccessResponseBody[workout.SharedWorkoutResponse](resp.Body)
+		assert.Equal(t, "Upper Day", preview.Data.Name)
+		assert.Len(t, preview.Data.Exercises, 2)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodPost, "/shared/workouts/"+link.Data.Token+"/import", nil, friendHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		imported := testhelper.ParseSuccessResponseBody[workout.WorkoutResponse](resp.Body)
+		assert.Equal(t, friend.ID, imported.Data.UserID)
+		assert.Len(t, imported.Data.Blocks, 1)
+		assert.Equal(t, workoutexercise.GroupTypeSuperset, imported.Data.Blocks[0].Group.Type)
+		assert.NotEqual(t, shared.Data.Blocks[0].Group.ID, imported.Data.Blocks[0].Group.ID)
+		assert.Len(t, imported.Data.Exercises[0].SetPrescriptions, 3)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodDelete,
+			"/workouts/"+shared.Data.ID.String()+"/share-links/"+link.Data.ID.String(), nil, ownerHeader)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+		resp, err = testhelper.RunRequest(setup, http.MethodGet, "/shared/workouts/"+link.Data.Token, nil, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}